@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -22,6 +23,23 @@ type Config struct {
 	InitialAdminEmail    string
 	InitialAdminPassword string
 	InitialAdminName     string
+	// Number of approvals a post-mortem needs before publishing (templates may override it)
+	PostMortemRequiredApprovals int
+	// IANA time zone in which post-mortem due dates count business days
+	BusinessTimezone string
+	// Issue trackers that action items can be opened in (each is enabled when its credentials are set)
+	GitHubAPIURL        string
+	GitHubToken         string
+	GitHubRepository    string // owner/repo
+	GitHubWebhookSecret string
+	JiraBaseURL         string
+	JiraEmail           string
+	JiraAPIToken        string
+	JiraProjectKey      string
+	JiraIssueType       string
+	JiraWebhookSecret   string
+	// Delivery attempts of a notification before it is dead-lettered
+	NotificationMaxAttempts int
 }
 
 // Insecure default values - only for local development
@@ -46,6 +64,22 @@ func Load() *Config {
 		InitialAdminEmail:    getEnv("INITIAL_ADMIN_EMAIL", ""),
 		InitialAdminPassword: getEnv("INITIAL_ADMIN_PASSWORD", ""),
 		InitialAdminName:     getEnv("INITIAL_ADMIN_NAME", ""),
+
+		PostMortemRequiredApprovals: getEnvInt("POSTMORTEM_REQUIRED_APPROVALS", 0),
+		BusinessTimezone:            getEnv("BUSINESS_TIMEZONE", "Asia/Tokyo"),
+
+		GitHubAPIURL:        getEnv("GITHUB_API_URL", "https://api.github.com"),
+		GitHubToken:         getEnv("GITHUB_TOKEN", ""),
+		GitHubRepository:    getEnv("GITHUB_REPOSITORY", ""),
+		GitHubWebhookSecret: getEnv("GITHUB_WEBHOOK_SECRET", ""),
+		JiraBaseURL:         getEnv("JIRA_BASE_URL", ""),
+		JiraEmail:           getEnv("JIRA_EMAIL", ""),
+		JiraAPIToken:        getEnv("JIRA_API_TOKEN", ""),
+		JiraProjectKey:      getEnv("JIRA_PROJECT_KEY", ""),
+		JiraIssueType:       getEnv("JIRA_ISSUE_TYPE", "Task"),
+		JiraWebhookSecret:   getEnv("JIRA_WEBHOOK_SECRET", ""),
+
+		NotificationMaxAttempts: getEnvInt("NOTIFICATION_MAX_ATTEMPTS", 8),
 	}
 
 	// Validate configuration for production environment
@@ -65,6 +99,19 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || parsed < 0 {
+		log.Printf("WARNING: invalid %s=%q, using %d\n", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
 // parseCORSOrigins parses a comma-separated string into a slice of origins
 func parseCORSOrigins(origins string) []string {
 	if origins == "" {
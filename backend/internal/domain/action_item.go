@@ -5,10 +5,12 @@ import (
 	"time"
 )
 
-// ActionItem represents an action item from a post-mortem.
+// ActionItem represents a follow-up task of an incident and/or its post-mortem.
+// At least one of IncidentID and PostMortemID is set; items of a post-mortem also carry its incident.
 type ActionItem struct {
 	ID           uint         `gorm:"primaryKey" json:"id"`
-	PostMortemID uint         `gorm:"not null;index" json:"post_mortem_id"`
+	IncidentID   *uint        `gorm:"index" json:"incident_id"`
+	PostMortemID *uint        `gorm:"index" json:"post_mortem_id"`
 	Title        string       `gorm:"size:500;not null" json:"title"`
 	Description  string       `gorm:"type:text" json:"description"`
 	AssigneeID   *uint        `gorm:"index" json:"assignee_id"`
@@ -20,9 +22,31 @@ type ActionItem struct {
 	UpdatedAt    time.Time    `json:"updated_at"`
 	CompletedAt  *time.Time   `json:"completed_at"`
 
+	// Verification that the completed item actually prevents a recurrence
+	VerifiedByID            *uint      `gorm:"index" json:"verified_by_id"`
+	VerifiedAt              *time.Time `json:"verified_at"`
+	VerificationNote        string     `gorm:"type:text" json:"verification_note"`
+	VerificationEvidenceURL string     `gorm:"size:1000" json:"verification_evidence_url"`
+
+	// Due-date notifications already sent (reset when the due date changes)
+	DueSoonNotifiedAt *time.Time `json:"-"`
+	OverdueNotifiedAt *time.Time `json:"overdue_notified_at"`
+	EscalatedAt       *time.Time `json:"escalated_at"`
+
+	// Issue opened in an external tracker (GitHub Issues, Jira); its webhooks keep the status in sync
+	ExternalTracker IssueTrackerKind `gorm:"size:20;not null;default:'';uniqueIndex:idx_action_items_external_issue,where:external_key <> ''" json:"external_tracker,omitempty"`
+	ExternalKey     string           `gorm:"size:200;not null;default:'';uniqueIndex:idx_action_items_external_issue,where:external_key <> ''" json:"external_key,omitempty"`
+	ExternalURL     string           `gorm:"size:500;not null;default:''" json:"external_url,omitempty"`
+
 	// Relations
+	Incident   *Incident   `gorm:"foreignKey:IncidentID" json:"-"`
 	PostMortem *PostMortem `gorm:"foreignKey:PostMortemID" json:"-"`
 	Assignee   *User       `gorm:"foreignKey:AssigneeID" json:"assignee,omitempty"`
+	VerifiedBy *User       `gorm:"foreignKey:VerifiedByID" json:"verified_by,omitempty"`
+	// Other incidents/post-mortems the same remediation came out of
+	Links []ActionItemLink `gorm:"foreignKey:ActionItemID" json:"links,omitempty"`
+	// Action items that must be completed first
+	BlockedBy []ActionItem `gorm:"many2many:action_item_dependencies;joinForeignKey:ActionItemID;joinReferences:BlockedByID" json:"blocked_by,omitempty"`
 }
 
 // Priority represents the priority level of an action item
@@ -41,24 +65,91 @@ const (
 	ActionStatusPending    ActionStatus = "pending"
 	ActionStatusInProgress ActionStatus = "in_progress"
 	ActionStatusCompleted  ActionStatus = "completed"
+	ActionStatusVerified   ActionStatus = "verified" // 完了後、再発防止の効果を確認済み
 )
 
+// DoneActionStatuses are the statuses of items that are no longer worked on
+var DoneActionStatuses = []ActionStatus{ActionStatusCompleted, ActionStatusVerified}
+
+// IsDone reports whether the item is completed (verified or not)
+func (s ActionStatus) IsDone() bool {
+	return s == ActionStatusCompleted || s == ActionStatusVerified
+}
+
+// ClearVerification drops the verification, e.g. when a verified item is reopened
+func (a *ActionItem) ClearVerification() {
+	a.VerifiedByID = nil
+	a.VerifiedAt = nil
+	a.VerificationNote = ""
+	a.VerificationEvidenceURL = ""
+	a.VerifiedBy = nil
+}
+
+// ActionItemSource filters action items by what they are attached to
+type ActionItemSource string
+
+const (
+	ActionItemSourceIncident   ActionItemSource = "incident"    // インシデントのみに紐づく（ポストモーテム未作成）
+	ActionItemSourcePostMortem ActionItemSource = "post_mortem" // ポストモーテムに紐づく
+)
+
+// IsValid reports whether the source is a known value
+func (s ActionItemSource) IsValid() bool {
+	return s == ActionItemSourceIncident || s == ActionItemSourcePostMortem
+}
+
 // ActionItemRepository defines the interface for action item data access.
 type ActionItemRepository interface {
 	Create(ctx context.Context, item *ActionItem) error
 	FindByID(ctx context.Context, id uint) (*ActionItem, error)
 	FindByPostMortemID(ctx context.Context, postMortemID uint) ([]*ActionItem, error)
+	FindByIncidentID(ctx context.Context, incidentID uint) ([]*ActionItem, error) // インシデント直下とポストモーテムの両方
+	// AttachToPostMortem moves the incident's items that have no post-mortem yet into the post-mortem, keeping the rows as they are
+	AttachToPostMortem(ctx context.Context, incidentID, postMortemID uint) (int64, error)
+
+	// Links to further incidents/post-mortems
+	CreateLink(ctx context.Context, link *ActionItemLink) error
+	FindLinkByID(ctx context.Context, id uint) (*ActionItemLink, error)
+	DeleteLink(ctx context.Context, id uint) error
+	// Blocking dependencies
+	AddDependency(ctx context.Context, itemID, blockedByID uint) error
+	RemoveDependency(ctx context.Context, itemID, blockedByID uint) error
+	FindAllDependencies(ctx context.Context) ([]ActionItemDependency, error)
+	FindOpenBlockers(ctx context.Context, itemID uint) ([]*ActionItem, error)
+	// FindRecurring counts the distinct incidents of every action item (own incident and links)
+	// and returns the items with at least minIncidents, most incidents first
+	FindRecurring(ctx context.Context, filters RecurringRemediationFilters) ([]RecurringRemediationCount, error)
+	// FindIncidents returns the own and linked incidents of each action item
+	FindIncidents(ctx context.Context, itemIDs []uint) (map[uint][]*Incident, error)
 	Update(ctx context.Context, item *ActionItem) error
 	Delete(ctx context.Context, id uint) error
 	FindAll(ctx context.Context, filters ActionItemFilters, pagination Pagination) ([]*ActionItem, *PaginationResult, error)
+	FindOpenWithDueDateByAssignee(ctx context.Context, assigneeID uint) ([]*ActionItem, error) // 期限付きの未完了アイテム
+	FindOpenWithDueDate(ctx context.Context) ([]*ActionItem, error)                            // 担当者・期限付きの未完了アイテム（リマインド用）
+	FindOpenByAssignee(ctx context.Context, assigneeID uint) ([]*ActionItem, error)            // 未完了アイテム（期限順、期限なしは最後）
+	// UpdateNotificationState saves only the due-date notification timestamps
+	UpdateNotificationState(ctx context.Context, item *ActionItem) error
+	// FindActiveBetween returns the items that existed and were not yet completed at some point of the period, with assignees
+	FindActiveBetween(ctx context.Context, start, end time.Time) ([]*ActionItem, error)
+	// FindUnverified returns the items completed before the time that have not been verified, oldest first
+	FindUnverified(ctx context.Context, completedBefore time.Time) ([]*ActionItem, error)
+	// FindRepeatIncidents returns the incidents detected after an item was completed that share
+	// the service or a tag of one of the item's incidents (one row per matching tag)
+	FindRepeatIncidents(ctx context.Context) ([]RepeatIncidentMatch, error)
+	// External issue tracker sync
+	FindByExternalIssue(ctx context.Context, tracker IssueTrackerKind, key string) (*ActionItem, error)
+	UpdateExternalIssue(ctx context.Context, item *ActionItem) error // saves only the external tracker, key and URL
 }
 
 // ActionItemFilters represents filtering options for action items.
 type ActionItemFilters struct {
-	Status     string
-	Priority   string
-	AssigneeID uint
-	Search     string
-	SortBy     string
-	Order      string
+	Status       string
+	Priority     string
+	AssigneeID   uint
+	IncidentID   uint             // Filter by incident (items of its post-mortem included)
+	PostMortemID uint             // Filter by post-mortem
+	Source       ActionItemSource // Filter by attachment
+	Search       string
+	SortBy       string
+	Order        string
 }
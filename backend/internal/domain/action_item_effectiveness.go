@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// RepeatIncidentMatch is a row of ActionItemRepository.FindRepeatIncidents: an incident detected after
+// the action item was completed, matched by the service (SameService) or a shared tag (TagName)
+type RepeatIncidentMatch struct {
+	ActionItemID uint
+	IncidentID   uint
+	Title        string
+	Severity     Severity
+	Service      string
+	DetectedAt   time.Time
+	SameService  bool
+	TagName      *string
+}
+
+// ActionItemEffectivenessReport flags action items whose effect is unknown or in doubt
+type ActionItemEffectivenessReport struct {
+	UnverifiedDays int `json:"unverified_days"`
+	// Completed more than UnverifiedDays days ago and still not verified
+	Unverified []*ActionItem `json:"unverified"`
+	// Completed (or verified) items whose service or tag had another incident after completion
+	Recurrences []ActionItemRecurrence `json:"recurrences"`
+}
+
+// ActionItemRecurrence is a completed action item followed by incidents in the same area
+type ActionItemRecurrence struct {
+	ActionItem *ActionItem      `json:"action_item"`
+	Incidents  []RepeatIncident `json:"incidents"`
+}
+
+// RepeatIncident is an incident after completion and why it is related to the action item
+type RepeatIncident struct {
+	RemediationIncident
+	SameService bool     `json:"same_service"`
+	SharedTags  []string `json:"shared_tags"`
+}
+
+// GroupRepeatIncidents merges the match rows into the repeat incidents of each action item, oldest first
+func GroupRepeatIncidents(matches []RepeatIncidentMatch) map[uint][]RepeatIncident {
+	type key struct{ itemID, incidentID uint }
+	index := make(map[key]int)
+	result := make(map[uint][]RepeatIncident)
+	for _, match := range matches {
+		k := key{match.ActionItemID, match.IncidentID}
+		i, ok := index[k]
+		if !ok {
+			result[match.ActionItemID] = append(result[match.ActionItemID], RepeatIncident{
+				RemediationIncident: RemediationIncident{
+					ID:         match.IncidentID,
+					Title:      match.Title,
+					Severity:   match.Severity,
+					Service:    match.Service,
+					DetectedAt: match.DetectedAt,
+				},
+				SharedTags: []string{},
+			})
+			i = len(result[match.ActionItemID]) - 1
+			index[k] = i
+		}
+		incident := &result[match.ActionItemID][i]
+		incident.SameService = incident.SameService || match.SameService
+		if match.TagName != nil && !containsString(incident.SharedTags, *match.TagName) {
+			incident.SharedTags = append(incident.SharedTags, *match.TagName)
+		}
+	}
+	for _, incidents := range result {
+		sort.Slice(incidents, func(i, j int) bool {
+			return incidents[i].DetectedAt.Before(incidents[j].DetectedAt)
+		})
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
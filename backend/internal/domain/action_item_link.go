@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ActionItemLink links an action item to a further incident (and its post-mortem)
+// whose remediation it also is.
+type ActionItemLink struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ActionItemID uint      `gorm:"not null;uniqueIndex:idx_action_item_link" json:"action_item_id"`
+	IncidentID   uint      `gorm:"not null;uniqueIndex:idx_action_item_link;index" json:"incident_id"`
+	PostMortemID *uint     `gorm:"index" json:"post_mortem_id"` // ポストモーテム経由でリンクした場合のみ
+	CreatedByID  uint      `gorm:"not null" json:"created_by_id"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relations
+	Incident *Incident `gorm:"foreignKey:IncidentID" json:"incident,omitempty"`
+}
+
+// ActionItemDependency states that ActionItemID cannot be completed before BlockedByID
+type ActionItemDependency struct {
+	ActionItemID uint `gorm:"primaryKey"`
+	BlockedByID  uint `gorm:"primaryKey"`
+}
+
+func (ActionItemDependency) TableName() string {
+	return "action_item_dependencies"
+}
+
+// DependencyCreatesCycle reports whether making itemID blocked by blockedByID would close a cycle,
+// i.e. blockedByID already (transitively) waits for itemID.
+func DependencyCreatesCycle(dependencies []ActionItemDependency, itemID, blockedByID uint) bool {
+	if itemID == blockedByID {
+		return true
+	}
+	blockers := make(map[uint][]uint)
+	for _, dep := range dependencies {
+		blockers[dep.ActionItemID] = append(blockers[dep.ActionItemID], dep.BlockedByID)
+	}
+
+	visited := map[uint]bool{blockedByID: true}
+	queue := []uint{blockedByID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range blockers[current] {
+			if next == itemID {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// RecurringRemediationFilters selects action items for the recurring remediation view
+type RecurringRemediationFilters struct {
+	MinIncidents int    // 既定値 2
+	Status       string // 空の場合は全てのステータス
+	Limit        int
+}
+
+// RecurringRemediationCount is the number of distinct incidents of an action item, as returned by the repository
+type RecurringRemediationCount struct {
+	ActionItemID  uint
+	IncidentCount int
+}
+
+// RecurringRemediation is an action item that came out of several incidents.
+// PreventableIncidents counts the incidents detected after the first one: they might have been
+// prevented had the remediation been done after the first occurrence. OccurredWhileOpen counts
+// those detected after the item was raised and before it was completed.
+type RecurringRemediation struct {
+	ActionItem           *ActionItem           `json:"action_item"`
+	IncidentCount        int                   `json:"incident_count"`
+	PreventableIncidents int                   `json:"preventable_incidents"`
+	OccurredWhileOpen    int                   `json:"occurred_while_open"`
+	Incidents            []RemediationIncident `json:"incidents"`
+}
+
+// RemediationIncident summarizes an incident of a recurring remediation
+type RemediationIncident struct {
+	ID         uint      `json:"id"`
+	Title      string    `json:"title"`
+	Severity   Severity  `json:"severity"`
+	Service    string    `json:"service"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// NewRecurringRemediation summarizes the incidents of an action item, oldest first
+func NewRecurringRemediation(item *ActionItem, incidents []*Incident) RecurringRemediation {
+	result := RecurringRemediation{
+		ActionItem:    item,
+		IncidentCount: len(incidents),
+		Incidents:     make([]RemediationIncident, 0, len(incidents)),
+	}
+	for _, incident := range incidents {
+		result.Incidents = append(result.Incidents, RemediationIncident{
+			ID:         incident.ID,
+			Title:      incident.Title,
+			Severity:   incident.Severity,
+			Service:    incident.Service,
+			DetectedAt: incident.DetectedAt,
+		})
+		if incident.DetectedAt.After(item.CreatedAt) && (item.CompletedAt == nil || incident.DetectedAt.Before(*item.CompletedAt)) {
+			result.OccurredWhileOpen++
+		}
+	}
+	sort.Slice(result.Incidents, func(i, j int) bool {
+		return result.Incidents[i].DetectedAt.Before(result.Incidents[j].DetectedAt)
+	})
+	if len(result.Incidents) > 1 {
+		result.PreventableIncidents = len(result.Incidents) - 1
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// ActionItemReport shows the remediation debt over a period: action items opened versus completed
+// per week, how long open items have been waiting and who is behind on their due dates.
+// Ages and overdue counts are taken at AsOf (the end of the period, or now for a period still running).
+type ActionItemReport struct {
+	Period   ReportPeriod `json:"period"`
+	Timezone string       `json:"timezone"`
+	AsOf     time.Time    `json:"as_of"`
+
+	Weeks               []ActionItemWeek              `json:"weeks"`
+	Aging               []ActionItemAgeBucket         `json:"aging"`
+	OverdueByAssignee   []ActionItemAssigneeOverdue   `json:"overdue_by_assignee"`
+	OverdueByDepartment []ActionItemDepartmentOverdue `json:"overdue_by_department"`
+
+	OpenAtEnd            int      `json:"open_at_end"`
+	CompletedInPeriod    int      `json:"completed_in_period"`
+	MedianDaysToComplete *float64 `json:"median_days_to_complete"` // 期間中に完了したアイテムの作成から完了までの日数（中央値）
+}
+
+// ActionItemWeek counts the items opened and completed in a week (Monday start, in the report timezone)
+type ActionItemWeek struct {
+	WeekStart time.Time `json:"week_start"`
+	Opened    int       `json:"opened"`
+	Completed int       `json:"completed"`
+	OpenAtEnd int       `json:"open_at_end"` // 週末時点の未完了数（バーンダウン）
+}
+
+// ActionItemAgeBucket counts the open items by age, split by priority
+type ActionItemAgeBucket struct {
+	Label   string `json:"label"`    // "0-7", "8-30", "31-90", "90+"
+	MinDays int    `json:"min_days"` // inclusive
+	MaxDays *int   `json:"max_days"` // inclusive; nil for the last bucket
+	High    int    `json:"high"`
+	Medium  int    `json:"medium"`
+	Low     int    `json:"low"`
+	Total   int    `json:"total"`
+}
+
+// ActionItemAssigneeOverdue counts the overdue open items of an assignee (UserID nil: unassigned)
+type ActionItemAssigneeOverdue struct {
+	UserID     *uint  `json:"user_id"`
+	UserName   string `json:"user_name"`
+	Department string `json:"department"`
+	Overdue    int    `json:"overdue"`
+	Open       int    `json:"open"`
+}
+
+// ActionItemDepartmentOverdue counts the overdue open items of a department's members
+type ActionItemDepartmentOverdue struct {
+	Department string `json:"department"`
+	Overdue    int    `json:"overdue"`
+	Open       int    `json:"open"`
+}
+
+// ActionItemAgeBuckets are the age ranges of the aging distribution
+var ActionItemAgeBuckets = []ActionItemAgeBucket{
+	{Label: "0-7", MinDays: 0, MaxDays: intPtr(7)},
+	{Label: "8-30", MinDays: 8, MaxDays: intPtr(30)},
+	{Label: "31-90", MinDays: 31, MaxDays: intPtr(90)},
+	{Label: "90+", MinDays: 91},
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CalendarFeedToken authenticates a user's iCalendar feed subscriptions.
+// Only the SHA-256 hash of the token is stored; the token itself is shown once when generated.
+type CalendarFeedToken struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	TokenHash  string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// CalendarFeedTokenRepository defines the interface for calendar feed token data access.
+type CalendarFeedTokenRepository interface {
+	FindByUserID(ctx context.Context, userID uint) (*CalendarFeedToken, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*CalendarFeedToken, error)
+	Replace(ctx context.Context, token *CalendarFeedToken) error // ユーザーの既存トークンを置き換える
+	DeleteByUserID(ctx context.Context, userID uint) error
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CausalCategory classifies a cause in the causal analysis tree
+type CausalCategory string
+
+const (
+	CausalCategoryPeople     CausalCategory = "people"     // 人（判断・スキル・コミュニケーション）
+	CausalCategoryProcess    CausalCategory = "process"    // プロセス（手順・レビュー・運用ルール）
+	CausalCategoryTechnology CausalCategory = "technology" // 技術（システム・設定・監視）
+	CausalCategoryExternal   CausalCategory = "external"   // 外部要因（ベンダー・クラウド障害など）
+)
+
+// CausalCategories lists the categories in display order
+var CausalCategories = []CausalCategory{CausalCategoryPeople, CausalCategoryProcess, CausalCategoryTechnology, CausalCategoryExternal}
+
+// Limits of a causal analysis tree
+const (
+	MaxCausalNodes = 200
+	MaxCausalDepth = 10
+)
+
+// IsValid reports whether the category is known
+func (c CausalCategory) IsValid() bool {
+	for _, category := range CausalCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// CausalNode is a cause in the causal analysis of a post-mortem.
+// Top-level nodes (ParentID nil) are direct causes of the incident; children explain their parent.
+// IsRootCause marks root causes; other nodes are contributing causes.
+type CausalNode struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	PostMortemID uint           `gorm:"not null;index" json:"post_mortem_id"`
+	ParentID     *uint          `gorm:"index" json:"parent_id"`
+	Description  string         `gorm:"type:text;not null" json:"description"`
+	Category     CausalCategory `gorm:"size:20;not null;index" json:"category"`
+	IsRootCause  bool           `gorm:"not null;default:false" json:"is_root_cause"`
+	Position     int            `gorm:"not null;default:0" json:"position"` // 兄弟ノード間の表示順
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// CausalTreeNode is a node with its children, for display
+type CausalTreeNode struct {
+	CausalNode
+	Children []*CausalTreeNode `json:"children"`
+}
+
+// CausalTree is the causal analysis of a post-mortem
+type CausalTree struct {
+	PostMortemID uint              `json:"post_mortem_id"`
+	Nodes        []*CausalTreeNode `json:"nodes"`
+	RootCauses   int               `json:"root_causes"`
+	Contributing int               `json:"contributing"`
+}
+
+// BuildCausalTree nests the nodes of a post-mortem; siblings are ordered by Position, then ID
+func BuildCausalTree(postMortemID uint, nodes []CausalNode) *CausalTree {
+	tree := &CausalTree{PostMortemID: postMortemID, Nodes: []*CausalTreeNode{}}
+
+	byID := make(map[uint]*CausalTreeNode, len(nodes))
+	for _, node := range nodes {
+		byID[node.ID] = &CausalTreeNode{CausalNode: node, Children: []*CausalTreeNode{}}
+		if node.IsRootCause {
+			tree.RootCauses++
+		} else {
+			tree.Contributing++
+		}
+	}
+	for _, node := range nodes {
+		treeNode := byID[node.ID]
+		if node.ParentID != nil {
+			if parent, ok := byID[*node.ParentID]; ok {
+				parent.Children = append(parent.Children, treeNode)
+				continue
+			}
+		}
+		tree.Nodes = append(tree.Nodes, treeNode)
+	}
+
+	sortCausalNodes(tree.Nodes)
+	return tree
+}
+
+func sortCausalNodes(nodes []*CausalTreeNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Position != nodes[j].Position {
+			return nodes[i].Position < nodes[j].Position
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	for _, node := range nodes {
+		sortCausalNodes(node.Children)
+	}
+}
+
+// Walk visits the nodes depth-first in display order; depth is 0 for top-level nodes
+func (t *CausalTree) Walk(fn func(node *CausalTreeNode, depth int)) {
+	var walk func(nodes []*CausalTreeNode, depth int)
+	walk = func(nodes []*CausalTreeNode, depth int) {
+		for _, node := range nodes {
+			fn(node, depth)
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(t.Nodes, 0)
+}
+
+// CausalDescendants returns the IDs of all descendants of the node
+func CausalDescendants(nodes []CausalNode, id uint) []uint {
+	children := make(map[uint][]uint)
+	for _, node := range nodes {
+		if node.ParentID != nil {
+			children[*node.ParentID] = append(children[*node.ParentID], node.ID)
+		}
+	}
+
+	var descendants []uint
+	queue := append([]uint(nil), children[id]...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		descendants = append(descendants, current)
+		queue = append(queue, children[current]...)
+	}
+	return descendants
+}
+
+// ValidateCausalParent checks that the node (0 for a new node) can be placed under parentID:
+// the parent must be in the same tree, must not be the node or one of its descendants,
+// and the tree must not become deeper than MaxCausalDepth.
+func ValidateCausalParent(nodes []CausalNode, nodeID uint, parentID *uint) error {
+	if parentID == nil {
+		return validateCausalDepth(nodes, nodeID, 0)
+	}
+
+	byID := make(map[uint]CausalNode, len(nodes))
+	for _, node := range nodes {
+		byID[node.ID] = node
+	}
+	if _, ok := byID[*parentID]; !ok {
+		return ErrValidation(fmt.Sprintf("parent node %d does not belong to this post-mortem", *parentID))
+	}
+	if nodeID != 0 {
+		if *parentID == nodeID {
+			return ErrValidation("a node cannot be its own parent")
+		}
+		for _, descendant := range CausalDescendants(nodes, nodeID) {
+			if descendant == *parentID {
+				return ErrValidation("a node cannot be moved under one of its descendants")
+			}
+		}
+	}
+
+	// Depth of the parent (top-level nodes have depth 1)
+	depth := 1
+	for current := byID[*parentID]; current.ParentID != nil && depth <= MaxCausalDepth; current = byID[*current.ParentID] {
+		depth++
+	}
+	return validateCausalDepth(nodes, nodeID, depth)
+}
+
+// validateCausalDepth checks that the node's subtree fits below a parent at parentDepth
+func validateCausalDepth(nodes []CausalNode, nodeID uint, parentDepth int) error {
+	height := 1
+	if nodeID != 0 {
+		height = causalSubtreeHeight(nodes, nodeID)
+	}
+	if parentDepth+height > MaxCausalDepth {
+		return ErrValidation(fmt.Sprintf("the causal tree can be at most %d levels deep", MaxCausalDepth))
+	}
+	return nil
+}
+
+func causalSubtreeHeight(nodes []CausalNode, id uint) int {
+	height := 1
+	for _, node := range nodes {
+		if node.ParentID != nil && *node.ParentID == id {
+			if h := causalSubtreeHeight(nodes, node.ID) + 1; h > height {
+				height = h
+			}
+		}
+	}
+	return height
+}
+
+// CausalCategoryStat counts the causes of one category across post-mortems
+type CausalCategoryStat struct {
+	Category       CausalCategory `json:"category"`
+	Total          int            `json:"total"`
+	RootCauses     int            `json:"root_causes"`
+	Contributing   int            `json:"contributing"`
+	PostMortems    int            `json:"post_mortems"`     // このカテゴリの原因を含むポストモーテム数
+	RootCauseShare float64        `json:"root_cause_share"` // 全根本原因に占める割合 (%)
+}
+
+// CausalCategorySummary aggregates causal analysis categories of post-mortems created in a period
+type CausalCategorySummary struct {
+	Period      ReportPeriod         `json:"period"`
+	PostMortems int                  `json:"post_mortems"` // 原因分析を含むポストモーテム数
+	RootCauses  int                  `json:"root_causes"`
+	Categories  []CausalCategoryStat `json:"categories"`
+}
+
+// CausalNodeRepository defines the interface for causal analysis data access
+type CausalNodeRepository interface {
+	Create(ctx context.Context, node *CausalNode) error
+	FindByID(ctx context.Context, id uint) (*CausalNode, error)
+	FindByPostMortemID(ctx context.Context, postMortemID uint) ([]CausalNode, error)
+	Update(ctx context.Context, node *CausalNode) error
+	Delete(ctx context.Context, ids []uint) error
+	// GetCategorySummary aggregates nodes of post-mortems created in [startDate, endDate);
+	// an empty status includes post-mortems of every status
+	GetCategorySummary(ctx context.Context, startDate, endDate time.Time, status PMStatus) (*CausalCategorySummary, error)
+}
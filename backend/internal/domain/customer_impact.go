@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CustomerImpact describes when and how much customers were affected by an incident.
+// The impact window is independent of DetectedAt/ResolvedAt: impact often starts
+// before detection and ends (e.g. after mitigation) before the incident is resolved.
+type CustomerImpact struct {
+	ImpactStartedAt      *time.Time `gorm:"index" json:"impact_started_at"`
+	ImpactEndedAt        *time.Time `json:"impact_ended_at"`
+	AffectedUsers        *int64     `json:"affected_users"`                                                  // 影響を受けたユーザー数
+	FailedRequests       *int64     `json:"failed_requests"`                                                 // 失敗したリクエスト数
+	EstimatedRevenueLoss *float64   `json:"estimated_revenue_loss"`                                          // 推定損失額
+	AffectedRegions      []string   `gorm:"type:jsonb;serializer:json;default:'[]'" json:"affected_regions"` // 影響を受けたリージョン
+}
+
+// CustomerImpactUpdate is a partial update of an incident's customer impact.
+// Nil fields keep their current value; the Clear flags remove a value.
+type CustomerImpactUpdate struct {
+	ImpactStartedAt           *time.Time
+	ImpactEndedAt             *time.Time
+	ClearImpactStartedAt      bool
+	ClearImpactEndedAt        bool
+	AffectedUsers             *int64
+	FailedRequests            *int64
+	EstimatedRevenueLoss      *float64
+	ClearAffectedUsers        bool
+	ClearFailedRequests       bool
+	ClearEstimatedRevenueLoss bool
+	AffectedRegions           []string // nil keeps the regions, an empty list clears them
+}
+
+// ApplyCustomerImpact changes the customer impact fields set in the update
+func (i *Incident) ApplyCustomerImpact(update CustomerImpactUpdate) {
+	if update.ClearImpactStartedAt {
+		i.ImpactStartedAt = nil
+	} else if update.ImpactStartedAt != nil {
+		i.ImpactStartedAt = update.ImpactStartedAt
+	}
+	if update.ClearImpactEndedAt {
+		i.ImpactEndedAt = nil
+	} else if update.ImpactEndedAt != nil {
+		i.ImpactEndedAt = update.ImpactEndedAt
+	}
+	if update.ClearAffectedUsers {
+		i.AffectedUsers = nil
+	} else if update.AffectedUsers != nil {
+		i.AffectedUsers = update.AffectedUsers
+	}
+	if update.ClearFailedRequests {
+		i.FailedRequests = nil
+	} else if update.FailedRequests != nil {
+		i.FailedRequests = update.FailedRequests
+	}
+	if update.ClearEstimatedRevenueLoss {
+		i.EstimatedRevenueLoss = nil
+	} else if update.EstimatedRevenueLoss != nil {
+		i.EstimatedRevenueLoss = update.EstimatedRevenueLoss
+	}
+	if update.AffectedRegions != nil {
+		i.AffectedRegions = update.AffectedRegions
+	}
+}
+
+// ValidateCustomerImpact checks that the impact window and figures are consistent with the incident's timeline
+func (i *Incident) ValidateCustomerImpact(now time.Time) error {
+	impact := i.CustomerImpact
+
+	if impact.ImpactEndedAt != nil && impact.ImpactStartedAt == nil {
+		return ErrValidation("impact_started_at is required when impact_ended_at is set")
+	}
+	if impact.ImpactStartedAt != nil {
+		if impact.ImpactStartedAt.After(now) {
+			return ErrValidation("impact_started_at must not be in the future")
+		}
+		if i.ResolvedAt != nil && impact.ImpactStartedAt.After(*i.ResolvedAt) {
+			return ErrValidation("impact_started_at must not be after resolved_at")
+		}
+	}
+	if impact.ImpactEndedAt != nil {
+		if impact.ImpactEndedAt.Before(*impact.ImpactStartedAt) {
+			return ErrValidation("impact_ended_at must not be before impact_started_at")
+		}
+		if impact.ImpactEndedAt.After(now) {
+			return ErrValidation("impact_ended_at must not be in the future")
+		}
+		if i.ResolvedAt != nil && impact.ImpactEndedAt.After(*i.ResolvedAt) {
+			return ErrValidation("impact_ended_at must not be after resolved_at")
+		}
+	}
+
+	if impact.AffectedUsers != nil && *impact.AffectedUsers < 0 {
+		return ErrValidation("affected_users must not be negative")
+	}
+	if impact.FailedRequests != nil && *impact.FailedRequests < 0 {
+		return ErrValidation("failed_requests must not be negative")
+	}
+	if impact.EstimatedRevenueLoss != nil && *impact.EstimatedRevenueLoss < 0 {
+		return ErrValidation("estimated_revenue_loss must not be negative")
+	}
+	for _, region := range impact.AffectedRegions {
+		if region == "" || len(region) > 50 {
+			return ErrValidation(fmt.Sprintf("invalid affected region %q", region))
+		}
+	}
+
+	return nil
+}
+
+// ImpactWindow returns the customer-impact window. An impact without an end is treated as
+// lasting until the incident was resolved, or until now while the incident is unresolved.
+func (i *Incident) ImpactWindow(now time.Time) (time.Time, time.Time, bool) {
+	if i.ImpactStartedAt == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end := now
+	switch {
+	case i.ImpactEndedAt != nil:
+		end = *i.ImpactEndedAt
+	case i.ResolvedAt != nil:
+		end = *i.ResolvedAt
+	}
+	if end.Before(*i.ImpactStartedAt) {
+		return time.Time{}, time.Time{}, false
+	}
+	return *i.ImpactStartedAt, end, true
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EscalationTrigger represents the condition that keeps an escalation running.
+type EscalationTrigger string
+
+const (
+	// EscalationTriggerUnacknowledged escalates until someone acknowledges the incident.
+	EscalationTriggerUnacknowledged EscalationTrigger = "unacknowledged"
+	// EscalationTriggerUnresolved escalates until the incident is resolved or closed.
+	EscalationTriggerUnresolved EscalationTrigger = "unresolved"
+)
+
+// EscalationTargetType represents who is notified at an escalation level.
+type EscalationTargetType string
+
+const (
+	EscalationTargetUser     EscalationTargetType = "user"
+	EscalationTargetSchedule EscalationTargetType = "schedule" // 対象スケジュールの現在のオンコール担当者
+)
+
+// EscalationStatus represents the progress of an incident's escalation.
+type EscalationStatus string
+
+const (
+	EscalationStatusActive    EscalationStatus = "active"    // 次のレベルへのエスカレーション待ち
+	EscalationStatusStopped   EscalationStatus = "stopped"   // 受諾・解決により停止
+	EscalationStatusExhausted EscalationStatus = "exhausted" // 全レベルに通知済み
+)
+
+// EscalationPolicy defines ordered escalation levels applied to matching incidents.
+// Empty match fields act as wildcards; a policy without any criteria is a default policy.
+type EscalationPolicy struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	Name        string            `gorm:"size:200;not null" json:"name"`
+	Description string            `gorm:"type:text" json:"description"`
+	Trigger     EscalationTrigger `gorm:"size:20;not null;default:'unacknowledged'" json:"trigger"`
+	IsActive    bool              `gorm:"default:true;index" json:"is_active"`
+
+	// Match criteria
+	Severity Severity `gorm:"size:20;index" json:"severity,omitempty"` // 対象の重要度
+	TagID    *uint    `gorm:"index" json:"tag_id,omitempty"`           // 対象のタグ
+	Service  string   `gorm:"size:100;index" json:"service,omitempty"` // 対象のサービス
+
+	CreatorID uint      `gorm:"not null;index" json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Tag     *Tag              `gorm:"foreignKey:TagID" json:"tag,omitempty"`
+	Creator *User             `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+	Levels  []EscalationLevel `gorm:"foreignKey:PolicyID;constraint:OnDelete:CASCADE" json:"levels"`
+}
+
+// EscalationLevel is one step of an escalation policy.
+type EscalationLevel struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	PolicyID       uint      `gorm:"not null;index" json:"policy_id"`
+	LevelNumber    int       `gorm:"not null" json:"level_number"`    // 1から始まる順序
+	TimeoutMinutes int       `gorm:"not null" json:"timeout_minutes"` // 次のレベルへ進むまでの待ち時間
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Relations
+	Targets []EscalationTarget `gorm:"foreignKey:LevelID;constraint:OnDelete:CASCADE" json:"targets"`
+}
+
+// EscalationTarget identifies who is notified at an escalation level.
+type EscalationTarget struct {
+	ID         uint                 `gorm:"primaryKey" json:"id"`
+	LevelID    uint                 `gorm:"not null;index" json:"level_id"`
+	TargetType EscalationTargetType `gorm:"size:20;not null" json:"target_type"`
+	TargetID   uint                 `gorm:"not null" json:"target_id"`
+}
+
+// IncidentEscalation tracks the escalation progress of a single incident.
+type IncidentEscalation struct {
+	ID               uint             `gorm:"primaryKey" json:"id"`
+	IncidentID       uint             `gorm:"uniqueIndex;not null" json:"incident_id"`
+	PolicyID         uint             `gorm:"not null;index" json:"policy_id"`
+	CurrentLevel     int              `gorm:"not null;default:0" json:"current_level"`
+	Status           EscalationStatus `gorm:"size:20;not null;default:'active';index" json:"status"`
+	LastEscalatedAt  *time.Time       `json:"last_escalated_at"`
+	NextEscalationAt *time.Time       `gorm:"index" json:"next_escalation_at"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+
+	// Relations
+	Policy *EscalationPolicy `gorm:"foreignKey:PolicyID" json:"policy,omitempty"`
+}
+
+// Matches reports whether the policy applies to the incident.
+func (p *EscalationPolicy) Matches(incident *Incident) bool {
+	if p.Severity != "" && p.Severity != incident.Severity {
+		return false
+	}
+	if p.TagID != nil && !incident.HasTag(*p.TagID) {
+		return false
+	}
+	if p.Service != "" && p.Service != incident.Service {
+		return false
+	}
+	return true
+}
+
+// Specificity scores how narrowly the policy is scoped.
+// Service is weighted highest, then tag, then severity.
+func (p *EscalationPolicy) Specificity() int {
+	score := 0
+	if p.Service != "" {
+		score += 4
+	}
+	if p.TagID != nil {
+		score += 2
+	}
+	if p.Severity != "" {
+		score++
+	}
+	return score
+}
+
+// LevelByNumber returns the level with the given number, or nil if it does not exist.
+func (p *EscalationPolicy) LevelByNumber(number int) *EscalationLevel {
+	for i := range p.Levels {
+		if p.Levels[i].LevelNumber == number {
+			return &p.Levels[i]
+		}
+	}
+	return nil
+}
+
+// EscalationPolicyRepository defines the interface for escalation policy data access.
+type EscalationPolicyRepository interface {
+	Create(ctx context.Context, policy *EscalationPolicy) error
+	FindAll(ctx context.Context) ([]*EscalationPolicy, error)
+	FindActive(ctx context.Context) ([]*EscalationPolicy, error)
+	FindByID(ctx context.Context, id uint) (*EscalationPolicy, error)
+	Update(ctx context.Context, policy *EscalationPolicy) error // レベルは丸ごと置き換える
+	Delete(ctx context.Context, id uint) error
+}
+
+// IncidentEscalationRepository defines the interface for incident escalation state access.
+type IncidentEscalationRepository interface {
+	Create(ctx context.Context, escalation *IncidentEscalation) error
+	FindByIncidentID(ctx context.Context, incidentID uint) (*IncidentEscalation, error)
+	FindDue(ctx context.Context, now time.Time) ([]*IncidentEscalation, error)
+	Update(ctx context.Context, escalation *IncidentEscalation) error
+}
@@ -34,6 +34,7 @@ type Incident struct {
 	Severity    Severity  `gorm:"size:20;not null;index" json:"severity"`
 	Status      Status    `gorm:"size:20;not null;default:'open';index" json:"status"`
 	ImpactScope string    `gorm:"size:500" json:"impact_scope"`
+	Service     string    `gorm:"size:100;index" json:"service"`
 	DetectedAt  time.Time `gorm:"not null;index" json:"detected_at"`
 	ResolvedAt  *time.Time `json:"resolved_at"`
 	AssigneeID  *uint     `gorm:"index" json:"assignee_id"`
@@ -46,12 +47,23 @@ type Incident struct {
 	SLADeadline              *time.Time `gorm:"index" json:"sla_deadline"`                     // SLA期限
 	SLAViolated              bool       `gorm:"default:false;index" json:"sla_violated"`       // SLA違反フラグ
 
+	// Acknowledgement Fields
+	AcknowledgedAt   *time.Time `json:"acknowledged_at"`          // 初動対応の受諾日時
+	AcknowledgedByID *uint      `json:"acknowledged_by_id"`       // 受諾したユーザー
+
+	// Customer Impact Fields
+	CustomerImpact `gorm:"embedded"`
+
 	// Relations
 	Assignee   *User       `gorm:"foreignKey:AssigneeID" json:"assignee"`
+	AcknowledgedBy *User   `gorm:"foreignKey:AcknowledgedByID" json:"acknowledged_by,omitempty"`
 	Assignees  []User      `gorm:"many2many:incident_assignees;" json:"assignees,omitempty"`
 	Creator    *User       `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
 	Tags       []Tag       `gorm:"many2many:incident_tags" json:"tags,omitempty"`
 	PostMortem *PostMortem `gorm:"foreignKey:IncidentID" json:"post_mortem,omitempty"`
+
+	// Similar past post-mortems, set only in the response of incident creation
+	SimilarPostMortems []KnowledgeBaseHit `gorm:"-" json:"similar_post_mortems,omitempty"`
 }
 
 // IncidentFilters represents filtering options for incidents.
@@ -63,6 +75,7 @@ type IncidentFilters struct {
 	SortBy       string
 	Order        string
 	AssignedToID *uint  // Filter by assignee ID
+	Service      string // Filter by service
 }
 
 // Pagination represents pagination parameters.
@@ -134,6 +147,21 @@ func (i *Incident) IsOpen() bool {
 	return i.Status == StatusOpen || i.Status == StatusInvestigating
 }
 
+// IsAcknowledged returns true if someone has acknowledged the incident
+func (i *Incident) IsAcknowledged() bool {
+	return i.AcknowledgedAt != nil
+}
+
+// HasTag returns true if the incident is labelled with the given tag
+func (i *Incident) HasTag(tagID uint) bool {
+	for _, tag := range i.Tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+	return false
+}
+
 // SLAMetrics represents SLA performance metrics
 type SLAMetrics struct {
 	TotalIncidents      int64   `json:"total_incidents"`
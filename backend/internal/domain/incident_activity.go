@@ -13,6 +13,8 @@ const (
 	ActivityTypeAssigneeChange  ActivityType = "assignee_change"
 	ActivityTypeResolved        ActivityType = "resolved"
 	ActivityTypeReopened        ActivityType = "reopened"
+	ActivityTypeAcknowledged    ActivityType = "acknowledged"
+	ActivityTypeEscalated       ActivityType = "escalated"
 	// Timeline event types
 	ActivityTypeDetected              ActivityType = "detected"
 	ActivityTypeInvestigationStarted   ActivityType = "investigation_started"
@@ -22,6 +24,26 @@ const (
 	ActivityTypeOther                  ActivityType = "other"
 )
 
+// TimelineEventTypes are the activity types recorded as timeline events (editable after the fact)
+var TimelineEventTypes = []ActivityType{
+	ActivityTypeDetected,
+	ActivityTypeInvestigationStarted,
+	ActivityTypeRootCauseIdentified,
+	ActivityTypeMitigation,
+	ActivityTypeTimelineResolved,
+	ActivityTypeOther,
+}
+
+// IsTimelineEvent returns true if the activity type is a timeline event type
+func (t ActivityType) IsTimelineEvent() bool {
+	for _, timelineType := range TimelineEventTypes {
+		if t == timelineType {
+			return true
+		}
+	}
+	return false
+}
+
 // IncidentActivity represents an activity or event related to an incident.
 type IncidentActivity struct {
 	ID          uint         `gorm:"primaryKey" json:"id"`
@@ -38,9 +60,45 @@ type IncidentActivity struct {
 	Incident *Incident `gorm:"foreignKey:IncidentID" json:"-"`
 }
 
+// TimelineRevisionAction is what was done to a timeline event
+type TimelineRevisionAction string
+
+const (
+	TimelineRevisionUpdated TimelineRevisionAction = "updated"
+	TimelineRevisionDeleted TimelineRevisionAction = "deleted"
+)
+
+// TimelineEventRevision keeps the values of a timeline event before it was edited or deleted.
+// New values are empty for deletions.
+type TimelineEventRevision struct {
+	ID             uint                   `gorm:"primaryKey" json:"id"`
+	ActivityID     uint                   `gorm:"not null;index" json:"activity_id"`
+	IncidentID     uint                   `gorm:"not null;index" json:"incident_id"`
+	EditorID       uint                   `gorm:"not null" json:"editor_id"`
+	Action         TimelineRevisionAction `gorm:"size:20;not null" json:"action"`
+	OldEventType   ActivityType           `gorm:"size:50;not null" json:"old_event_type"`
+	OldEventTime   time.Time              `gorm:"not null" json:"old_event_time"`
+	OldDescription string                 `gorm:"type:text" json:"old_description"`
+	NewEventType   ActivityType           `gorm:"size:50" json:"new_event_type,omitempty"`
+	NewEventTime   *time.Time             `json:"new_event_time,omitempty"`
+	NewDescription string                 `gorm:"type:text" json:"new_description,omitempty"`
+	CreatedAt      time.Time              `gorm:"index" json:"created_at"`
+
+	// Relations
+	Editor *User `gorm:"foreignKey:EditorID" json:"editor,omitempty"`
+}
+
 // IncidentActivityRepository defines the interface for incident activity data access.
 type IncidentActivityRepository interface {
 	Create(activity *IncidentActivity) error
+	CreateBatch(activities []*IncidentActivity) error
+	FindByID(id uint) (*IncidentActivity, error)
 	FindByIncidentID(incidentID uint, limit int) ([]*IncidentActivity, error)
 	FindRecent(limit int) ([]*IncidentActivity, error)
+
+	// Timeline events
+	FindTimelineByIncidentID(incidentID uint) ([]*IncidentActivity, error)
+	UpdateTimelineEvent(activity *IncidentActivity, revision *TimelineEventRevision) error
+	DeleteTimelineEvent(activity *IncidentActivity, revision *TimelineEventRevision) error
+	FindRevisionsByIncidentID(incidentID uint) ([]*TimelineEventRevision, error)
 }
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// IncidentAssignment is one period during which a user was the assignee of an incident.
+// Rows are maintained by the incident repository whenever the assignee changes.
+type IncidentAssignment struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	IncidentID   uint       `gorm:"not null;index" json:"incident_id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	AssignedAt   time.Time  `gorm:"not null;index" json:"assigned_at"`
+	UnassignedAt *time.Time `gorm:"index" json:"unassigned_at,omitempty"` // nilは現在も担当中
+
+	// Relations
+	Incident *Incident `gorm:"foreignKey:IncidentID" json:"-"`
+}
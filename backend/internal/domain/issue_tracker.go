@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// IssueTrackerKind identifies an external issue tracker
+type IssueTrackerKind string
+
+const (
+	IssueTrackerGitHub IssueTrackerKind = "github"
+	IssueTrackerJira   IssueTrackerKind = "jira"
+)
+
+// ExternalIssue is an issue opened in an external tracker for an action item
+type ExternalIssue struct {
+	Key string // e.g. "owner/repo#123" (GitHub), "OPS-42" (Jira)
+	URL string
+}
+
+// IssueEvent is a state change of an external issue received by webhook
+type IssueEvent struct {
+	Key      string
+	Closed   bool       // false: the issue was (re)opened
+	ClosedAt *time.Time // when the tracker reports it
+}
+
+// IssueTracker opens issues for action items in an external tracker and reads its webhooks.
+// Adapters talk to the tracker's REST API over HTTP.
+type IssueTracker interface {
+	Kind() IssueTrackerKind
+	CreateIssue(ctx context.Context, item *ActionItem) (*ExternalIssue, error)
+	// ParseWebhook verifies the webhook signature and extracts the issue state change.
+	// It returns nil without error for events that do not change the open/closed state.
+	ParseWebhook(header http.Header, body []byte) (*IssueEvent, error)
+}
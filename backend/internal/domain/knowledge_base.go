@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"context"
+	"html"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits of knowledge base searches
+const (
+	MaxKnowledgeBaseTerms   = 10  // 検索語の最大数
+	KnowledgeBaseSnippetLen = 160 // ハイライト抜粋の最大文字数
+	MaxSimilarPostMortems   = 5
+)
+
+// KnowledgeBaseQuery searches the lessons learned of published post-mortems.
+// An empty query lists every published post-mortem, newest first.
+type KnowledgeBaseQuery struct {
+	Query    string
+	TagIDs   []uint
+	Service  string
+	Category CausalCategory
+	Severity Severity
+}
+
+// Terms splits the query into lower-cased search terms (at most MaxKnowledgeBaseTerms)
+func (q KnowledgeBaseQuery) Terms() []string {
+	return SearchTerms(q.Query)
+}
+
+// SearchTerms splits free text into distinct lower-cased terms (at most MaxKnowledgeBaseTerms)
+func SearchTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range strings.Fields(strings.ToLower(text)) {
+		term = strings.Trim(term, `"'`)
+		if term == "" || seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+		if len(terms) == MaxKnowledgeBaseTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// SimilarityTerms splits an incident's text into the terms used to find similar post-mortems
+// (at most MaxKnowledgeBaseTerms). Japanese has no spaces between words, so runs of kanji are
+// split into overlapping two-character terms and katakana words are kept, while hiragana
+// (mostly particles and endings) and very short words ("a", "of", single kanji) are dropped.
+func SimilarityTerms(text string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	add := func(term string) bool {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+		return len(terms) < MaxKnowledgeBaseTerms
+	}
+
+	runes := []rune(strings.ToLower(text))
+	for start := 0; start < len(runes); {
+		class := scriptClass(runes[start])
+		end := start + 1
+		for end < len(runes) && scriptClass(runes[end]) == class {
+			end++
+		}
+		run := runes[start:end]
+		start = end
+
+		more := true
+		switch class {
+		case scriptWord:
+			if len(string(run)) >= 3 {
+				more = add(string(run))
+			}
+		case scriptKatakana:
+			if len(run) >= 2 {
+				more = add(string(run))
+			}
+		case scriptKanji:
+			for i := 0; i+2 <= len(run) && more; i++ {
+				more = add(string(run[i : i+2]))
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return terms
+}
+
+type script int
+
+const (
+	scriptOther script = iota // spaces, punctuation and hiragana
+	scriptWord
+	scriptKanji
+	scriptKatakana
+)
+
+func scriptClass(r rune) script {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return scriptKanji
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return scriptKatakana
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptOther
+	case unicode.IsLetter(r) || unicode.IsDigit(r):
+		return scriptWord
+	default:
+		return scriptOther
+	}
+}
+
+// KnowledgeBaseMatch is a matching post-mortem with its relevance, as returned by the repository
+type KnowledgeBaseMatch struct {
+	PostMortemID uint
+	Score        float64
+}
+
+// KnowledgeBaseHit is one post-mortem in the knowledge base search results
+type KnowledgeBaseHit struct {
+	PostMortemID     uint                     `json:"post_mortem_id"`
+	IncidentID       uint                     `json:"incident_id"`
+	IncidentTitle    string                   `json:"incident_title"`
+	Severity         Severity                 `json:"severity"`
+	Service          string                   `json:"service"`
+	Tags             []Tag                    `json:"tags"`
+	CausalCategories []CausalCategory         `json:"causal_categories"`
+	PublishedAt      *time.Time               `json:"published_at"`
+	Score            float64                  `json:"score"`
+	Highlights       []KnowledgeBaseHighlight `json:"highlights"`
+}
+
+// KnowledgeBaseHighlight is an excerpt of a field around the search terms.
+// The snippet is HTML-escaped with the matches wrapped in <mark> tags.
+type KnowledgeBaseHighlight struct {
+	Field   string `json:"field"` // root_cause / lessons_learned / what_went_wrong
+	Snippet string `json:"snippet"`
+}
+
+// KnowledgeBaseFacetCount is the number of matching post-mortems for one facet value
+type KnowledgeBaseFacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// KnowledgeBaseFacets counts the matching post-mortems by tag, service and causal category
+type KnowledgeBaseFacets struct {
+	Tags             []KnowledgeBaseFacetCount `json:"tags"` // value はタグID
+	Services         []KnowledgeBaseFacetCount `json:"services"`
+	CausalCategories []KnowledgeBaseFacetCount `json:"causal_categories"` // 原因分析ツリーのカテゴリ
+}
+
+// KnowledgeBaseResult is a page of knowledge base search results
+type KnowledgeBaseResult struct {
+	Hits       []KnowledgeBaseHit  `json:"hits"`
+	Facets     KnowledgeBaseFacets `json:"facets"`
+	Pagination *PaginationResult   `json:"pagination"`
+}
+
+// HighlightSnippet returns an excerpt of text around the first match of any term, HTML-escaped
+// with every match wrapped in <mark>. ok is false when no term occurs in the text.
+// Matching is case-insensitive and works on substrings, so it also finds words in Japanese text.
+func HighlightSnippet(text string, terms []string, maxLen int) (snippet string, ok bool) {
+	text = strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(text)
+	// Byte offsets match between text and lower only if lower-casing keeps the length
+	if len(lower) != len(text) {
+		return "", false
+	}
+
+	type span struct{ start, end int }
+	var matches []span
+	for _, term := range terms {
+		for from := 0; from < len(lower); {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			matches = append(matches, span{from + i, from + i + len(term)})
+			from += i + len(term)
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+
+	// Merge overlapping matches so each part of the text is marked at most once
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	merged := matches[:1]
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if m.start <= last.end {
+			if m.end > last.end {
+				last.end = m.end
+			}
+			continue
+		}
+		merged = append(merged, m)
+	}
+
+	// Window of maxLen characters starting a little before the first match
+	start := merged[0].start
+	for back := maxLen / 4; back > 0 && start > 0; back-- {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	end := start
+	for n := 0; n < maxLen && end < len(text); n++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range merged {
+		if m.start >= end {
+			break
+		}
+		matchEnd := m.end
+		if matchEnd > end {
+			matchEnd = end
+		}
+		b.WriteString(html.EscapeString(text[pos:m.start]))
+		b.WriteString("<mark>" + html.EscapeString(text[m.start:matchEnd]) + "</mark>")
+		pos = matchEnd
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// KnowledgeBaseRepository defines the interface for searching published post-mortems
+type KnowledgeBaseRepository interface {
+	// Search returns a page of matching published post-mortems by relevance, and the total number of matches
+	Search(ctx context.Context, query KnowledgeBaseQuery, pagination Pagination) ([]KnowledgeBaseMatch, int64, error)
+	// Facets counts all matches of the query by tag, service and causal category
+	Facets(ctx context.Context, query KnowledgeBaseQuery) (*KnowledgeBaseFacets, error)
+	// FindSimilar ranks published post-mortems by how many of the terms they share with an incident,
+	// boosted by the same service and shared tags. Incidents in excludeIncidentIDs are skipped.
+	FindSimilar(ctx context.Context, terms []string, service string, tagIDs []uint, excludeIncidentIDs []uint, limit int) ([]KnowledgeBaseMatch, error)
+	// FindPostMortems loads the post-mortems with their incidents and incident tags
+	FindPostMortems(ctx context.Context, ids []uint) ([]*PostMortem, error)
+	// FindCausalCategories returns the distinct causal categories used by each post-mortem
+	FindCausalCategories(ctx context.Context, postMortemIDs []uint) (map[uint][]CausalCategory, error)
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimilarityTerms(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "english",
+			text: "Database connection pool exhausted on API",
+			want: []string{"database", "connection", "pool", "exhausted", "api"},
+		},
+		{
+			name: "japanese title",
+			text: "決済APIのタイムアウトで注文が失敗",
+			want: []string{"決済", "api", "タイムアウト", "注文", "失敗"},
+		},
+		{
+			name: "kanji run split into bigrams",
+			text: "決済障害",
+			want: []string{"決済", "済障", "障害"},
+		},
+		{
+			name: "short words, single kanji and duplicates dropped",
+			text: "DB of 死 「データベース」 データベース db",
+			want: []string{"データベース"},
+		},
+		{
+			name: "at most MaxKnowledgeBaseTerms",
+			text: "one two three four five six seven eight nine ten eleven twelve",
+			want: []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimilarityTerms(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SimilarityTerms(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
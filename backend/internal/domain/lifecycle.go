@@ -0,0 +1,300 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LifecyclePhase is the interval between two consecutive timeline milestones
+type LifecyclePhase string
+
+const (
+	PhaseInvestigate LifecyclePhase = "investigate" // 検知 → 調査開始
+	PhaseDiagnose    LifecyclePhase = "diagnose"    // 調査開始 → 原因特定
+	PhaseMitigate    LifecyclePhase = "mitigate"    // 原因特定 → 緩和
+	PhaseResolve     LifecyclePhase = "resolve"     // 緩和 → 解決
+)
+
+// LifecyclePhases lists the phases in the order an incident goes through them
+var LifecyclePhases = []LifecyclePhase{PhaseInvestigate, PhaseDiagnose, PhaseMitigate, PhaseResolve}
+
+// LifecycleMilestones holds the earliest time each timeline milestone was recorded
+type LifecycleMilestones struct {
+	Detected             time.Time  `json:"detected"`
+	InvestigationStarted *time.Time `json:"investigation_started,omitempty"`
+	RootCauseIdentified  *time.Time `json:"root_cause_identified,omitempty"`
+	Mitigated            *time.Time `json:"mitigated,omitempty"`
+	Resolved             *time.Time `json:"resolved,omitempty"`
+}
+
+// IncidentLifecycle holds the intervals of a single incident, in hours.
+// Intervals whose milestones were not recorded (or are out of order) are nil.
+type IncidentLifecycle struct {
+	IncidentID uint                `json:"incident_id"`
+	Severity   Severity            `json:"severity"`
+	Milestones LifecycleMilestones `json:"milestones"`
+
+	TimeToAcknowledge *float64 `json:"time_to_acknowledge_hours,omitempty"` // MTTA: 検知 → 調査開始
+	TimeToDiagnose    *float64 `json:"time_to_diagnose_hours,omitempty"`    // MTTD: 検知 → 原因特定
+	TimeToMitigate    *float64 `json:"time_to_mitigate_hours,omitempty"`    // MTTM: 検知 → 緩和
+	TimeToResolve     *float64 `json:"time_to_resolve_hours,omitempty"`     // MTTR: 検知 → 解決
+
+	Phases map[LifecyclePhase]float64 `json:"phases"`
+}
+
+// DurationStat summarizes a set of intervals in hours
+type DurationStat struct {
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+}
+
+// PhaseStat summarizes one lifecycle phase
+type PhaseStat struct {
+	Phase LifecyclePhase `json:"phase"`
+	DurationStat
+}
+
+// LifecycleStats aggregates incident lifecycles.
+// SlowestPhase is the phase with the longest average, or empty if no phase was measured.
+type LifecycleStats struct {
+	Incidents    int            `json:"incidents"`
+	MTTA         DurationStat   `json:"mtta"`
+	MTTD         DurationStat   `json:"mttd"`
+	MTTM         DurationStat   `json:"mttm"`
+	MTTR         DurationStat   `json:"mttr"`
+	Phases       []PhaseStat    `json:"phases"`
+	SlowestPhase LifecyclePhase `json:"slowest_phase,omitempty"`
+}
+
+// LifecycleGroup is LifecycleStats for one severity, tag or month
+type LifecycleGroup struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	LifecycleStats
+}
+
+// LifecycleMetrics aggregates lifecycle intervals of incidents detected in a period
+type LifecycleMetrics struct {
+	Period     ReportPeriod     `json:"period"`
+	Overall    LifecycleStats   `json:"overall"`
+	BySeverity []LifecycleGroup `json:"by_severity"`
+	ByTag      []LifecycleGroup `json:"by_tag"`
+	ByMonth    []LifecycleGroup `json:"by_month"`
+}
+
+// NewIncidentLifecycle derives the lifecycle of an incident from its timeline events.
+// Detection falls back to Incident.DetectedAt and resolution to Incident.ResolvedAt
+// when the corresponding timeline event was not recorded.
+func NewIncidentLifecycle(incident *Incident, events []IncidentActivity) IncidentLifecycle {
+	earliest := make(map[ActivityType]time.Time)
+	for _, event := range events {
+		if event.IncidentID != incident.ID {
+			continue
+		}
+		if t, ok := earliest[event.ActivityType]; !ok || event.CreatedAt.Before(t) {
+			earliest[event.ActivityType] = event.CreatedAt
+		}
+	}
+	milestone := func(activityType ActivityType) *time.Time {
+		if t, ok := earliest[activityType]; ok {
+			return &t
+		}
+		return nil
+	}
+
+	milestones := LifecycleMilestones{
+		Detected:             incident.DetectedAt,
+		InvestigationStarted: milestone(ActivityTypeInvestigationStarted),
+		RootCauseIdentified:  milestone(ActivityTypeRootCauseIdentified),
+		Mitigated:            milestone(ActivityTypeMitigation),
+		Resolved:             milestone(ActivityTypeTimelineResolved),
+	}
+	if detected := milestone(ActivityTypeDetected); detected != nil {
+		milestones.Detected = *detected
+	}
+	if milestones.Resolved == nil {
+		milestones.Resolved = incident.ResolvedAt
+	}
+
+	lifecycle := IncidentLifecycle{
+		IncidentID: incident.ID,
+		Severity:   incident.Severity,
+		Milestones: milestones,
+		Phases:     make(map[LifecyclePhase]float64),
+	}
+
+	detected := &milestones.Detected
+	lifecycle.TimeToAcknowledge = hoursBetween(detected, milestones.InvestigationStarted)
+	lifecycle.TimeToDiagnose = hoursBetween(detected, milestones.RootCauseIdentified)
+	lifecycle.TimeToMitigate = hoursBetween(detected, milestones.Mitigated)
+	lifecycle.TimeToResolve = hoursBetween(detected, milestones.Resolved)
+
+	ordered := []*time.Time{detected, milestones.InvestigationStarted, milestones.RootCauseIdentified, milestones.Mitigated, milestones.Resolved}
+	for i, phase := range LifecyclePhases {
+		if hours := hoursBetween(ordered[i], ordered[i+1]); hours != nil {
+			lifecycle.Phases[phase] = *hours
+		}
+	}
+
+	return lifecycle
+}
+
+// BuildLifecycleMetrics aggregates the lifecycles of the incidents overall and by severity, tag and month (UTC).
+// Incidents must have Tags loaded to be grouped by tag.
+func BuildLifecycleMetrics(startDate, endDate time.Time, incidents []*Incident, events []IncidentActivity) *LifecycleMetrics {
+	eventsByIncident := make(map[uint][]IncidentActivity)
+	for _, event := range events {
+		eventsByIncident[event.IncidentID] = append(eventsByIncident[event.IncidentID], event)
+	}
+
+	var all []IncidentLifecycle
+	bySeverity := make(map[string][]IncidentLifecycle)
+	byTag := make(map[string][]IncidentLifecycle)
+	byMonth := make(map[string][]IncidentLifecycle)
+	tagNames := make(map[string]string)
+
+	for _, incident := range incidents {
+		lifecycle := NewIncidentLifecycle(incident, eventsByIncident[incident.ID])
+		all = append(all, lifecycle)
+
+		bySeverity[string(incident.Severity)] = append(bySeverity[string(incident.Severity)], lifecycle)
+
+		month := incident.DetectedAt.UTC().Format("2006-01")
+		byMonth[month] = append(byMonth[month], lifecycle)
+
+		for _, tag := range incident.Tags {
+			key := fmt.Sprintf("%d", tag.ID)
+			tagNames[key] = tag.Name
+			byTag[key] = append(byTag[key], lifecycle)
+		}
+	}
+
+	metrics := &LifecycleMetrics{
+		Period: ReportPeriod{
+			StartDate: startDate,
+			EndDate:   endDate,
+			Month:     int(startDate.Month()),
+			Year:      startDate.Year(),
+		},
+		Overall:    aggregateLifecycles(all),
+		BySeverity: []LifecycleGroup{},
+		ByTag:      []LifecycleGroup{},
+		ByMonth:    []LifecycleGroup{},
+	}
+
+	for _, severity := range []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow} {
+		if lifecycles, ok := bySeverity[string(severity)]; ok {
+			metrics.BySeverity = append(metrics.BySeverity, LifecycleGroup{
+				Key:            string(severity),
+				Name:           string(severity),
+				LifecycleStats: aggregateLifecycles(lifecycles),
+			})
+		}
+	}
+
+	for key, lifecycles := range byTag {
+		metrics.ByTag = append(metrics.ByTag, LifecycleGroup{
+			Key:            key,
+			Name:           tagNames[key],
+			LifecycleStats: aggregateLifecycles(lifecycles),
+		})
+	}
+	sort.Slice(metrics.ByTag, func(i, j int) bool {
+		if metrics.ByTag[i].Incidents != metrics.ByTag[j].Incidents {
+			return metrics.ByTag[i].Incidents > metrics.ByTag[j].Incidents
+		}
+		return metrics.ByTag[i].Name < metrics.ByTag[j].Name
+	})
+
+	for key, lifecycles := range byMonth {
+		metrics.ByMonth = append(metrics.ByMonth, LifecycleGroup{
+			Key:            key,
+			Name:           key,
+			LifecycleStats: aggregateLifecycles(lifecycles),
+		})
+	}
+	sort.Slice(metrics.ByMonth, func(i, j int) bool {
+		return metrics.ByMonth[i].Key < metrics.ByMonth[j].Key
+	})
+
+	return metrics
+}
+
+func aggregateLifecycles(lifecycles []IncidentLifecycle) LifecycleStats {
+	var mtta, mttd, mttm, mttr []float64
+	phases := make(map[LifecyclePhase][]float64)
+
+	for _, lifecycle := range lifecycles {
+		mtta = appendHours(mtta, lifecycle.TimeToAcknowledge)
+		mttd = appendHours(mttd, lifecycle.TimeToDiagnose)
+		mttm = appendHours(mttm, lifecycle.TimeToMitigate)
+		mttr = appendHours(mttr, lifecycle.TimeToResolve)
+		for phase, hours := range lifecycle.Phases {
+			phases[phase] = append(phases[phase], hours)
+		}
+	}
+
+	stats := LifecycleStats{
+		Incidents: len(lifecycles),
+		MTTA:      summarizeHours(mtta),
+		MTTD:      summarizeHours(mttd),
+		MTTM:      summarizeHours(mttm),
+		MTTR:      summarizeHours(mttr),
+		Phases:    make([]PhaseStat, 0, len(LifecyclePhases)),
+	}
+
+	slowest := -1.0
+	for _, phase := range LifecyclePhases {
+		stat := PhaseStat{Phase: phase, DurationStat: summarizeHours(phases[phase])}
+		stats.Phases = append(stats.Phases, stat)
+		if stat.Count > 0 && stat.AverageHours > slowest {
+			slowest = stat.AverageHours
+			stats.SlowestPhase = phase
+		}
+	}
+
+	return stats
+}
+
+func summarizeHours(values []float64) DurationStat {
+	if len(values) == 0 {
+		return DurationStat{}
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, v := range sorted {
+		total += v
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return DurationStat{
+		Count:        len(sorted),
+		AverageHours: total / float64(len(sorted)),
+		MedianHours:  median,
+	}
+}
+
+// hoursBetween returns the hours from start to end, or nil if either is missing or end precedes start
+func hoursBetween(start, end *time.Time) *float64 {
+	if start == nil || end == nil || end.Before(*start) {
+		return nil
+	}
+	hours := end.Sub(*start).Hours()
+	return &hours
+}
+
+func appendHours(values []float64, hours *float64) []float64 {
+	if hours == nil {
+		return values
+	}
+	return append(values, *hours)
+}
@@ -8,10 +8,12 @@ type NotificationSetting struct {
 	UserID    uint      `gorm:"not null;index" json:"user_id"`
 	User      *User     `gorm:"foreignKey:UserID" json:"user,omitempty"`
 
-	// 通知チャネル
+	// 通知チャネル（アカウントのメールアドレス以外の通知先は NotificationEndpoint で設定します）
 	EmailEnabled  bool   `gorm:"default:true" json:"email_enabled"`
-	SlackEnabled  bool   `gorm:"default:false" json:"slack_enabled"`
-	SlackWebhook  string `gorm:"type:varchar(512)" json:"slack_webhook,omitempty"`
+
+	// Deprecated: 最初のSlack通知先を表す旧形式のフィールドです（/api/notifications/endpoints を使ってください）
+	SlackEnabled *bool   `gorm:"-" json:"slack_enabled,omitempty"`
+	SlackWebhook *string `gorm:"-" json:"slack_webhook,omitempty"`
 
 	// 通知イベントの有効/無効
 	NotifyOnIncidentCreated       bool `gorm:"default:true" json:"notify_on_incident_created"`
@@ -21,11 +23,48 @@ type NotificationSetting struct {
 	NotifyOnSeverityChange        bool `gorm:"default:true" json:"notify_on_severity_change"`
 	NotifyOnResolved              bool `gorm:"default:true" json:"notify_on_resolved"`
 	NotifyOnEscalation            bool `gorm:"default:true" json:"notify_on_escalation"`
+	NotifyOnPostMortemReview      bool `gorm:"default:true" json:"notify_on_post_mortem_review"`
+	NotifyOnPostMortemDue         bool `gorm:"default:true" json:"notify_on_post_mortem_due"`
+	NotifyOnActionItemDue         bool `gorm:"default:true" json:"notify_on_action_item_due"`
+
+	// アクションアイテムのリマインド・週次ダイジェスト
+	ActionItemDueSoonDays     int        `gorm:"default:2" json:"action_item_due_soon_days"`    // 期限の何日前にリマインドするか（0 は当日）
+	ActionItemOverdueInterval int        `gorm:"default:1" json:"action_item_overdue_interval"` // 期限超過の再通知間隔（日数、0 は一度だけ）
+	WeeklyDigestEnabled       bool       `gorm:"default:true" json:"weekly_digest_enabled"`
+	WeeklyDigestWeekday       int        `gorm:"default:1" json:"weekly_digest_weekday"` // 0=日曜 ... 6=土曜
+	WeeklyDigestHour          int        `gorm:"default:9" json:"weekly_digest_hour"`    // 0-23
+	QuietHoursStart           *int       `json:"quiet_hours_start"`                      // 通知しない時間帯の開始時（0-23、null は無効）
+	QuietHoursEnd             *int       `json:"quiet_hours_end"`                        // 通知しない時間帯の終了時（この時刻から再開）
+	Timezone                  string     `gorm:"size:64" json:"timezone"`                // IANAタイムゾーン名（空の場合は BUSINESS_TIMEZONE）
+	LastDigestSentAt          *time.Time `json:"last_digest_sent_at"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// DefaultNotificationSetting は通知設定を保存していないユーザーに適用する設定を返します
+func DefaultNotificationSetting(userID uint) *NotificationSetting {
+	return &NotificationSetting{
+		UserID:                    userID,
+		EmailEnabled:              true,
+		NotifyOnIncidentCreated:   true,
+		NotifyOnAssigned:          true,
+		NotifyOnComment:           true,
+		NotifyOnStatusChange:      true,
+		NotifyOnSeverityChange:    true,
+		NotifyOnResolved:          true,
+		NotifyOnEscalation:        true,
+		NotifyOnPostMortemReview:  true,
+		NotifyOnPostMortemDue:     true,
+		NotifyOnActionItemDue:     true,
+		ActionItemDueSoonDays:     2,
+		ActionItemOverdueInterval: 1,
+		WeeklyDigestEnabled:       true,
+		WeeklyDigestWeekday:       1,
+		WeeklyDigestHour:          9,
+	}
+}
+
 // NotificationSettingRepository は通知設定のリポジトリインターフェース
 type NotificationSettingRepository interface {
 	Create(setting *NotificationSetting) error
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// NotificationEvent is what a notification is about
+type NotificationEvent string
+
+const (
+	NotificationEventIncidentCreated  NotificationEvent = "incident_created"
+	NotificationEventAssigned         NotificationEvent = "assigned"
+	NotificationEventComment          NotificationEvent = "comment"
+	NotificationEventStatusChange     NotificationEvent = "status_change"
+	NotificationEventResolved         NotificationEvent = "resolved"
+	NotificationEventEscalation       NotificationEvent = "escalation"
+	NotificationEventPostMortemReview NotificationEvent = "post_mortem_review"
+	NotificationEventPostMortemDue    NotificationEvent = "post_mortem_due"
+	NotificationEventActionItemDue    NotificationEvent = "action_item_due"
+	NotificationEventActionItemDigest NotificationEvent = "action_item_digest"
+	NotificationEventHandoffReport    NotificationEvent = "handoff_report"
+)
+
+// DeliveryResult is the outcome of one delivery attempt
+type DeliveryResult string
+
+const (
+	DeliverySent   DeliveryResult = "sent"
+	DeliveryFailed DeliveryResult = "failed"
+)
+
+func (r DeliveryResult) IsValid() bool {
+	return r == DeliverySent || r == DeliveryFailed
+}
+
+// NotificationDelivery records one attempt to deliver a notification, as evidence of who was
+// notified of what and when. Records are never updated.
+type NotificationDelivery struct {
+	ID             uint                `gorm:"primaryKey" json:"id"`
+	NotificationID *uint               `gorm:"index" json:"notification_id"` // queued notification (outbox) that was attempted
+	UserID         *uint               `gorm:"index:idx_notification_deliveries_user,priority:1" json:"user_id"`
+	User           *User               `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Channel        NotificationChannel `gorm:"size:20;not null" json:"channel"`
+	// Email address, or webhook URL with its secret path masked
+	Recipient   string            `gorm:"type:text;not null" json:"recipient"`
+	EventType   NotificationEvent `gorm:"size:50;not null" json:"event_type"`
+	IncidentID  *uint             `gorm:"index:idx_notification_deliveries_incident,priority:1" json:"incident_id"`
+	Subject     string            `gorm:"type:text" json:"subject"`
+	PayloadHash string            `gorm:"size:64;not null" json:"payload_hash"` // SHA-256 of the delivered payload
+	Attempt     int               `gorm:"not null" json:"attempt"`
+	Result      DeliveryResult    `gorm:"size:20;not null" json:"result"`
+	Error       string            `gorm:"type:text" json:"error"`
+	LatencyMs   int64             `gorm:"not null" json:"latency_ms"`
+	CreatedAt   time.Time         `gorm:"index:idx_notification_deliveries_user,priority:2;index:idx_notification_deliveries_incident,priority:2" json:"created_at"` // time of the attempt
+}
+
+// NewNotificationDelivery records an attempt to deliver a queued notification that took latency and failed with err (nil on success)
+func NewNotificationDelivery(message *NotificationOutbox, attempt int, err error, latency time.Duration, at time.Time) *NotificationDelivery {
+	delivery := &NotificationDelivery{
+		NotificationID: &message.ID,
+		UserID:         message.UserID,
+		Channel:        message.Channel,
+		Recipient:      MaskRecipient(message.Channel, message.Recipient),
+		EventType:      message.EventType,
+		IncidentID:     message.IncidentID,
+		Subject:        message.Subject,
+		PayloadHash:    PayloadHash(message.Payload),
+		Attempt:        attempt,
+		Result:         DeliverySent,
+		LatencyMs:      latency.Milliseconds(),
+		CreatedAt:      at,
+	}
+	if err != nil {
+		delivery.Result = DeliveryFailed
+		delivery.Error = message.ErrorText(err)
+	}
+	return delivery
+}
+
+// PayloadHash returns the hex SHA-256 of a notification payload
+func PayloadHash(payload string) string {
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// NotificationDeliveryFilters narrows the delivery log search
+type NotificationDeliveryFilters struct {
+	UserID     *uint
+	IncidentID *uint
+	Channel    NotificationChannel
+	EventType  NotificationEvent
+	Result     DeliveryResult
+	Recipient  string // partial match
+	StartDate  *time.Time
+	EndDate    *time.Time
+}
+
+type NotificationDeliveryRepository interface {
+	Create(ctx context.Context, delivery *NotificationDelivery) error
+	// FindAll returns matching attempts, newest first, with their users
+	FindAll(ctx context.Context, filters NotificationDeliveryFilters, pagination Pagination) ([]*NotificationDelivery, int64, error)
+}
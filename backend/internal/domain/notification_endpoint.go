@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NotificationChannel is the medium a notification is delivered through
+type NotificationChannel string
+
+const (
+	ChannelEmail      NotificationChannel = "email"
+	ChannelSlack      NotificationChannel = "slack"
+	ChannelTeams      NotificationChannel = "teams"
+	ChannelDiscord    NotificationChannel = "discord"
+	ChannelMattermost NotificationChannel = "mattermost"
+)
+
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case ChannelEmail, ChannelSlack, ChannelTeams, ChannelDiscord, ChannelMattermost:
+		return true
+	}
+	return false
+}
+
+// NotificationEndpoint is a place a user is notified at besides their account email:
+// an incoming webhook of a chat channel, or another email address.
+// A user can have any number of endpoints, of any channel.
+type NotificationEndpoint struct {
+	ID      uint                `gorm:"primaryKey" json:"id"`
+	UserID  uint                `gorm:"not null;index" json:"user_id"`
+	Channel NotificationChannel `gorm:"size:20;not null" json:"channel"`
+	Name    string              `gorm:"size:100" json:"name"`             // label shown in the settings, e.g. "#sre-oncall"
+	Target  string              `gorm:"type:text;not null" json:"target"` // email address or webhook URL
+	Enabled bool                `gorm:"not null" json:"enabled"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks the channel and that the target is an email address (email) or an http(s) URL (webhooks)
+func (e *NotificationEndpoint) Validate() error {
+	if !e.Channel.IsValid() {
+		return ErrValidation("invalid channel")
+	}
+	e.Target = strings.TrimSpace(e.Target)
+	if e.Target == "" {
+		return ErrValidation("target is required")
+	}
+	if len(e.Name) > 100 {
+		return ErrValidation("name must be at most 100 characters")
+	}
+
+	if e.Channel == ChannelEmail {
+		if _, err := mail.ParseAddress(e.Target); err != nil {
+			return ErrValidation("target must be an email address")
+		}
+		return nil
+	}
+	u, err := url.Parse(e.Target)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return ErrValidation("target must be an http(s) webhook URL")
+	}
+	return nil
+}
+
+type NotificationEndpointRepository interface {
+	Create(ctx context.Context, endpoint *NotificationEndpoint) error
+	FindByUserID(ctx context.Context, userID uint) ([]*NotificationEndpoint, error)
+	FindByID(ctx context.Context, id uint) (*NotificationEndpoint, error)
+	Update(ctx context.Context, endpoint *NotificationEndpoint) error
+	Delete(ctx context.Context, id uint) error
+}
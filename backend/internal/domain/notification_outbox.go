@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OutboxStatus is the delivery state of a queued notification
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending" // waiting for its (next) delivery attempt
+	OutboxSent    OutboxStatus = "sent"
+	OutboxDead    OutboxStatus = "dead" // gave up after MaxAttempts; can be replayed by an admin
+)
+
+func (s OutboxStatus) IsValid() bool {
+	return s == OutboxPending || s == OutboxSent || s == OutboxDead
+}
+
+// Delays between delivery attempts: the first retry waits outboxBaseDelay and every further retry
+// doubles it, up to outboxMaxDelay
+const (
+	outboxBaseDelay = 30 * time.Second
+	outboxMaxDelay  = time.Hour
+)
+
+// NotificationOutbox is a notification queued for delivery. Notifications are written here in the
+// request path and delivered by a background worker, which retries failures with exponential backoff.
+type NotificationOutbox struct {
+	ID      uint                `gorm:"primaryKey" json:"id"`
+	Channel NotificationChannel `gorm:"size:20;not null" json:"channel"`
+	UserID  *uint               `gorm:"index" json:"user_id"` // recipient user
+	// Email address, or webhook URL (masked in JSON)
+	Recipient  string            `gorm:"type:text;not null" json:"recipient"`
+	EventType  NotificationEvent `gorm:"size:50;not null;default:''" json:"event_type"`
+	IncidentID *uint             `json:"incident_id"`
+	Subject    string            `gorm:"type:text" json:"subject"`
+	// Email HTML body, or the JSON posted to the webhook
+	Payload       string       `gorm:"type:text;not null" json:"payload"`
+	Status        OutboxStatus `gorm:"size:20;not null;default:'pending';index:idx_notification_outbox_due,priority:1" json:"status"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts   int          `gorm:"not null" json:"max_attempts"`
+	NextAttemptAt time.Time    `gorm:"not null;index:idx_notification_outbox_due,priority:2" json:"next_attempt_at"`
+	LastError     string       `gorm:"type:text" json:"last_error"`
+	SentAt        *time.Time   `json:"sent_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+func (NotificationOutbox) TableName() string {
+	return "notification_outbox"
+}
+
+// MarshalJSON hides the path of webhook URLs, which carries the webhook's secret
+func (m NotificationOutbox) MarshalJSON() ([]byte, error) {
+	type outbox NotificationOutbox
+	masked := outbox(m)
+	masked.Recipient = MaskRecipient(m.Channel, m.Recipient)
+	masked.LastError = m.maskText(m.LastError)
+	return json.Marshal(masked)
+}
+
+// MaskRecipient returns the recipient as it may be shown: email addresses as they are,
+// webhook URLs with their secret path hidden
+func MaskRecipient(channel NotificationChannel, recipient string) string {
+	if channel == ChannelEmail {
+		return recipient
+	}
+	u, err := url.Parse(recipient)
+	if err != nil || u.Host == "" {
+		return "***"
+	}
+	return u.Scheme + "://" + u.Host + "/***"
+}
+
+// ErrorText returns the text of a delivery error as it may be stored and shown, with the webhook URL masked
+func (m *NotificationOutbox) ErrorText(err error) string {
+	return m.maskText(err.Error())
+}
+
+func (m *NotificationOutbox) maskText(text string) string {
+	if m.Channel == ChannelEmail || m.Recipient == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, m.Recipient, MaskRecipient(m.Channel, m.Recipient))
+}
+
+// NotificationOrigin tells whom and what a queued notification is about, for the delivery log
+type NotificationOrigin struct {
+	UserID     *uint
+	EventType  NotificationEvent
+	IncidentID *uint
+}
+
+// NewNotificationOutbox queues a notification for immediate delivery
+func NewNotificationOutbox(origin NotificationOrigin, channel NotificationChannel, recipient, subject, payload string, maxAttempts int, now time.Time) *NotificationOutbox {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &NotificationOutbox{
+		Channel:       channel,
+		UserID:        origin.UserID,
+		Recipient:     recipient,
+		EventType:     origin.EventType,
+		IncidentID:    origin.IncidentID,
+		Subject:       subject,
+		Payload:       payload,
+		Status:        OutboxPending,
+		MaxAttempts:   maxAttempts,
+		NextAttemptAt: now,
+	}
+}
+
+// OutboxBackoff returns the delay before the next attempt after the given number of failed attempts
+func OutboxBackoff(attempts int) time.Duration {
+	delay := outboxBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= outboxMaxDelay {
+			return outboxMaxDelay
+		}
+	}
+	return delay
+}
+
+// RecordSuccess marks the notification as delivered
+func (m *NotificationOutbox) RecordSuccess(now time.Time) {
+	m.Attempts++
+	m.Status = OutboxSent
+	m.SentAt = &now
+	m.LastError = ""
+}
+
+// RecordFailure schedules the next attempt, or dead-letters the notification once its attempts are used up
+func (m *NotificationOutbox) RecordFailure(err error, now time.Time) {
+	m.Attempts++
+	m.LastError = m.ErrorText(err)
+	if m.Attempts >= m.MaxAttempts {
+		m.Status = OutboxDead
+		return
+	}
+	m.NextAttemptAt = now.Add(OutboxBackoff(m.Attempts))
+}
+
+// Replay queues a dead-lettered notification again with a fresh set of attempts.
+// The last error is kept until the next attempt.
+func (m *NotificationOutbox) Replay(now time.Time) {
+	m.Status = OutboxPending
+	m.Attempts = 0
+	m.NextAttemptAt = now
+}
+
+// NotificationOutboxFilters narrows the outbox listing
+type NotificationOutboxFilters struct {
+	Status  OutboxStatus
+	Channel NotificationChannel
+}
+
+type NotificationOutboxRepository interface {
+	Create(ctx context.Context, message *NotificationOutbox) error
+	// ClaimDue returns up to limit pending notifications whose next attempt is due and defers them by
+	// lease, so that concurrent workers do not pick them up while they are being delivered
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*NotificationOutbox, error)
+	Update(ctx context.Context, message *NotificationOutbox) error
+	FindAll(ctx context.Context, filters NotificationOutboxFilters, pagination Pagination) ([]*NotificationOutbox, int64, error)
+	FindByID(ctx context.Context, id uint) (*NotificationOutbox, error)
+	// ReplayDead queues all dead-lettered notifications (of one channel, when given) again
+	ReplayDead(ctx context.Context, channel NotificationChannel, now time.Time) (int64, error)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limits of the per-user reminder settings
+const (
+	MaxActionItemDueSoonDays     = 30
+	MaxActionItemOverdueInterval = 30
+)
+
+// ValidateSchedule checks the reminder cadence, digest schedule, quiet hours and timezone
+func (s *NotificationSetting) ValidateSchedule() error {
+	if s.ActionItemDueSoonDays < 0 || s.ActionItemDueSoonDays > MaxActionItemDueSoonDays {
+		return ErrValidation(fmt.Sprintf("action_item_due_soon_days must be between 0 and %d", MaxActionItemDueSoonDays))
+	}
+	if s.ActionItemOverdueInterval < 0 || s.ActionItemOverdueInterval > MaxActionItemOverdueInterval {
+		return ErrValidation(fmt.Sprintf("action_item_overdue_interval must be between 0 and %d", MaxActionItemOverdueInterval))
+	}
+	if s.WeeklyDigestWeekday < 0 || s.WeeklyDigestWeekday > 6 {
+		return ErrValidation("weekly_digest_weekday must be between 0 (Sunday) and 6 (Saturday)")
+	}
+	if s.WeeklyDigestHour < 0 || s.WeeklyDigestHour > 23 {
+		return ErrValidation("weekly_digest_hour must be between 0 and 23")
+	}
+	if (s.QuietHoursStart == nil) != (s.QuietHoursEnd == nil) {
+		return ErrValidation("quiet_hours_start and quiet_hours_end must be set together")
+	}
+	for _, hour := range []*int{s.QuietHoursStart, s.QuietHoursEnd} {
+		if hour != nil && (*hour < 0 || *hour > 23) {
+			return ErrValidation("quiet hours must be between 0 and 23")
+		}
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return ErrValidation("invalid timezone")
+		}
+	}
+	return nil
+}
+
+// Location returns the user's timezone, or fallback when it is not set
+func (s *NotificationSetting) Location(fallback *time.Location) *time.Location {
+	if s.Timezone != "" {
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			return loc
+		}
+	}
+	return fallback
+}
+
+// InQuietHours reports whether t falls in the user's quiet period (in loc).
+// The period may span midnight (e.g. 22 to 7); equal start and end disable it.
+func (s *NotificationSetting) InQuietHours(t time.Time, loc *time.Location) bool {
+	if s.QuietHoursStart == nil || s.QuietHoursEnd == nil || *s.QuietHoursStart == *s.QuietHoursEnd {
+		return false
+	}
+	hour := t.In(loc).Hour()
+	start, end := *s.QuietHoursStart, *s.QuietHoursEnd
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// DigestDue reports whether this week's digest should be sent at now: the scheduled weekday and
+// hour have passed less than a day ago and no digest was sent since.
+func (s *NotificationSetting) DigestDue(now time.Time, loc *time.Location) bool {
+	if !s.WeeklyDigestEnabled {
+		return false
+	}
+	local := now.In(loc)
+	daysSince := (int(local.Weekday()) - s.WeeklyDigestWeekday + 7) % 7
+	scheduled := time.Date(local.Year(), local.Month(), local.Day()-daysSince, s.WeeklyDigestHour, 0, 0, 0, loc)
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -7)
+	}
+	if now.Sub(scheduled) >= 24*time.Hour {
+		return false
+	}
+	return s.LastDigestSentAt == nil || s.LastDigestSentAt.Before(scheduled)
+}
+
+// ActionItemReminderKind is the kind of an action item due-date notification
+type ActionItemReminderKind string
+
+const (
+	ActionItemDueSoon   ActionItemReminderKind = "due_soon"  // 期限が近い
+	ActionItemOverdue   ActionItemReminderKind = "overdue"   // 期限超過
+	ActionItemEscalated ActionItemReminderKind = "escalated" // 優先度高の期限超過をポストモーテム作成者へ
+)
@@ -0,0 +1,368 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// RotationType represents how often an on-call layer hands off to the next participant.
+type RotationType string
+
+const (
+	RotationDaily  RotationType = "daily"
+	RotationWeekly RotationType = "weekly"
+)
+
+// OnCallSource represents where an on-call assignment comes from.
+type OnCallSource string
+
+const (
+	OnCallSourceLayer    OnCallSource = "layer"
+	OnCallSourceOverride OnCallSource = "override"
+)
+
+// OnCallSchedule is a set of rotation layers plus temporary overrides.
+// Empty match fields act as wildcards when choosing a schedule for auto-assignment.
+type OnCallSchedule struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"size:200;not null" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	Timezone    string `gorm:"size:64;not null;default:'Asia/Tokyo'" json:"timezone"` // IANAタイムゾーン名
+
+	// Auto-assignment of new incidents
+	AutoAssign bool     `gorm:"default:false;index" json:"auto_assign"`
+	Severity   Severity `gorm:"size:20;index" json:"severity,omitempty"` // 対象の重要度
+	TagID      *uint    `gorm:"index" json:"tag_id,omitempty"`           // 対象のタグ
+	Service    string   `gorm:"size:100;index" json:"service,omitempty"` // 対象のサービス
+
+	CreatorID uint      `gorm:"not null;index" json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Tag     *Tag          `gorm:"foreignKey:TagID" json:"tag,omitempty"`
+	Creator *User         `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+	Layers  []OnCallLayer `gorm:"foreignKey:ScheduleID;constraint:OnDelete:CASCADE" json:"layers"`
+}
+
+// OnCallLayer is one rotation within a schedule. Layers with a higher LayerOrder take precedence.
+type OnCallLayer struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	ScheduleID   uint         `gorm:"not null;index" json:"schedule_id"`
+	Name         string       `gorm:"size:200" json:"name"`
+	LayerOrder   int          `gorm:"not null" json:"layer_order"`
+	RotationType RotationType `gorm:"size:20;not null" json:"rotation_type"`
+	StartDate    string       `gorm:"size:10;not null" json:"start_date"`  // ローテーション開始日 (YYYY-MM-DD, スケジュールのタイムゾーン)
+	HandoffTime  string       `gorm:"size:5;not null" json:"handoff_time"` // 交代時刻 (HH:MM, スケジュールのタイムゾーン)
+	CreatedAt    time.Time    `json:"created_at"`
+
+	// Relations
+	Participants []OnCallParticipant `gorm:"foreignKey:LayerID;constraint:OnDelete:CASCADE" json:"participants"`
+}
+
+// OnCallParticipant is a user in a layer's rotation order.
+type OnCallParticipant struct {
+	ID       uint `gorm:"primaryKey" json:"id"`
+	LayerID  uint `gorm:"not null;index" json:"layer_id"`
+	UserID   uint `gorm:"not null;index" json:"user_id"`
+	Position int  `gorm:"not null" json:"position"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// OnCallOverride temporarily replaces the on-call user of a schedule.
+type OnCallOverride struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ScheduleID uint      `gorm:"not null;index" json:"schedule_id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	StartAt    time.Time `gorm:"not null;index" json:"start_at"`
+	EndAt      time.Time `gorm:"not null;index" json:"end_at"`
+	Reason     string    `gorm:"size:500" json:"reason"`
+	CreatorID  uint      `gorm:"not null" json:"creator_id"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// OnCallShift is a resolved on-call assignment. It is computed, not stored.
+type OnCallShift struct {
+	ScheduleID uint         `json:"schedule_id"`
+	UserID     uint         `json:"user_id"`
+	Start      time.Time    `json:"start"`
+	End        time.Time    `json:"end"`
+	Source     OnCallSource `json:"source"`
+	LayerID    *uint        `json:"layer_id,omitempty"`
+	LayerOrder int          `json:"layer_order,omitempty"` // レイヤーの順序（スケジュールを更新してもレイヤーIDと違い変わらない）
+	OverrideID *uint        `json:"override_id,omitempty"`
+	User       *User        `json:"user,omitempty"`
+}
+
+// Location returns the schedule's timezone, falling back to UTC if it cannot be loaded.
+func (s *OnCallSchedule) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Matches reports whether the schedule applies to the incident.
+func (s *OnCallSchedule) Matches(incident *Incident) bool {
+	if s.Severity != "" && s.Severity != incident.Severity {
+		return false
+	}
+	if s.TagID != nil && !incident.HasTag(*s.TagID) {
+		return false
+	}
+	if s.Service != "" && s.Service != incident.Service {
+		return false
+	}
+	return true
+}
+
+// Specificity scores how narrowly the schedule is scoped, using the same weights as EscalationPolicy.
+func (s *OnCallSchedule) Specificity() int {
+	score := 0
+	if s.Service != "" {
+		score += 4
+	}
+	if s.TagID != nil {
+		score += 2
+	}
+	if s.Severity != "" {
+		score++
+	}
+	return score
+}
+
+// OnCallAt returns who is on call at the given time.
+// Overrides win over layers (the most recently created override wins among overlapping ones),
+// and among layers the one with the highest LayerOrder wins. Returns nil if nobody is on call.
+func (s *OnCallSchedule) OnCallAt(at time.Time, overrides []OnCallOverride) *OnCallShift {
+	var override *OnCallOverride
+	for i := range overrides {
+		o := &overrides[i]
+		if o.ScheduleID != s.ID || at.Before(o.StartAt) || !at.Before(o.EndAt) {
+			continue
+		}
+		if override == nil || o.CreatedAt.After(override.CreatedAt) ||
+			(o.CreatedAt.Equal(override.CreatedAt) && o.ID > override.ID) {
+			override = o
+		}
+	}
+	if override != nil {
+		id := override.ID
+		return &OnCallShift{
+			ScheduleID: s.ID,
+			UserID:     override.UserID,
+			Start:      override.StartAt,
+			End:        override.EndAt,
+			Source:     OnCallSourceOverride,
+			OverrideID: &id,
+			User:       override.User,
+		}
+	}
+
+	loc := s.Location()
+	var best *OnCallShift
+	bestOrder := 0
+	for i := range s.Layers {
+		layer := &s.Layers[i]
+		shift := layer.ShiftAt(at, loc)
+		if shift == nil {
+			continue
+		}
+		if best == nil || layer.LayerOrder > bestOrder {
+			shift.ScheduleID = s.ID
+			best = shift
+			bestOrder = layer.LayerOrder
+		}
+	}
+	return best
+}
+
+// ShiftsBetween expands the schedule into the effective on-call shifts overlapping [from, to).
+// Layer rotations and overrides are flattened so that each returned shift is exactly who is
+// on call for that period. The first and last shifts keep their full length rather than being
+// cut at the window edges, so the same shift is reported identically for sliding windows.
+func (s *OnCallSchedule) ShiftsBetween(from, to time.Time, overrides []OnCallOverride) []OnCallShift {
+	if !from.Before(to) {
+		return nil
+	}
+
+	// Every instant at which the effective on-call user may change
+	boundarySet := map[int64]time.Time{from.UnixNano(): from, to.UnixNano(): to}
+	addBoundary := func(t time.Time) {
+		if t.After(from) && t.Before(to) {
+			boundarySet[t.UnixNano()] = t
+		}
+	}
+
+	loc := s.Location()
+	for i := range s.Layers {
+		layer := &s.Layers[i]
+		shift := layer.ShiftAt(from, loc)
+		if shift == nil {
+			if anchor, ok := layer.anchor(loc); ok && anchor.Before(to) {
+				shift = layer.ShiftAt(anchor, loc)
+			}
+		}
+		for shift != nil && shift.Start.Before(to) {
+			addBoundary(shift.Start)
+			addBoundary(shift.End)
+			shift = layer.ShiftAt(shift.End, loc)
+		}
+	}
+	for _, o := range overrides {
+		if o.ScheduleID != s.ID {
+			continue
+		}
+		addBoundary(o.StartAt)
+		addBoundary(o.EndAt)
+	}
+
+	boundaries := make([]time.Time, 0, len(boundarySet))
+	for _, t := range boundarySet {
+		boundaries = append(boundaries, t)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var shifts []OnCallShift
+	for i := 0; i < len(boundaries)-1; i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		current := s.OnCallAt(start, overrides)
+		if current == nil {
+			continue
+		}
+
+		if n := len(shifts); n > 0 && shifts[n-1].End.Equal(start) && sameAssignment(&shifts[n-1], current) {
+			shifts[n-1].End = end
+			continue
+		}
+
+		segment := *current
+		segment.Start = start
+		segment.End = end
+		if start.Equal(from) && current.Start.Before(from) {
+			segment.Start = current.Start
+		}
+		shifts = append(shifts, segment)
+	}
+
+	if n := len(shifts); n > 0 && shifts[n-1].End.Equal(to) {
+		if last := s.OnCallAt(to.Add(-time.Nanosecond), overrides); last != nil && last.End.After(to) {
+			shifts[n-1].End = last.End
+		}
+	}
+	return shifts
+}
+
+// sameAssignment reports whether two shifts come from the same layer or override for the same user.
+func sameAssignment(a, b *OnCallShift) bool {
+	if a.UserID != b.UserID || a.Source != b.Source {
+		return false
+	}
+	return equalUintPtr(a.LayerID, b.LayerID) && equalUintPtr(a.OverrideID, b.OverrideID)
+}
+
+func equalUintPtr(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// ShiftAt returns the layer's shift covering the given time, or nil if the rotation
+// has not started yet or has no participants.
+func (l *OnCallLayer) ShiftAt(at time.Time, loc *time.Location) *OnCallShift {
+	if len(l.Participants) == 0 {
+		return nil
+	}
+	anchor, ok := l.anchor(loc)
+	if !ok || at.Before(anchor) {
+		return nil
+	}
+
+	// Most recent handoff at or before the given time, on the local calendar
+	local := at.In(loc)
+	handoff := time.Date(local.Year(), local.Month(), local.Day(), anchor.Hour(), anchor.Minute(), 0, 0, loc)
+	if handoff.After(at) {
+		handoff = handoff.AddDate(0, 0, -1)
+	}
+	days := civilDaysBetween(anchor, handoff)
+
+	var index, length int
+	switch l.RotationType {
+	case RotationWeekly:
+		index = days / 7
+		length = 7
+	default:
+		index = days
+		length = 1
+	}
+
+	start := anchor.AddDate(0, 0, index*length)
+	end := anchor.AddDate(0, 0, (index+1)*length)
+	participant := l.participantAt(index)
+	layerID := l.ID
+	return &OnCallShift{
+		UserID:     participant.UserID,
+		Start:      start,
+		End:        end,
+		Source:     OnCallSourceLayer,
+		LayerID:    &layerID,
+		LayerOrder: l.LayerOrder,
+		User:       participant.User,
+	}
+}
+
+// participantAt returns the participant for the n-th shift, ordered by Position.
+func (l *OnCallLayer) participantAt(index int) OnCallParticipant {
+	ordered := make([]OnCallParticipant, len(l.Participants))
+	copy(ordered, l.Participants)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+	return ordered[index%len(ordered)]
+}
+
+// anchor returns the start of the first shift in the schedule's timezone.
+func (l *OnCallLayer) anchor(loc *time.Location) (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", l.StartDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+	handoff, err := time.Parse("15:04", l.HandoffTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), handoff.Hour(), handoff.Minute(), 0, 0, loc), true
+}
+
+// civilDaysBetween counts calendar days between two local dates, ignoring DST shifts.
+func civilDaysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// OnCallScheduleRepository defines the interface for on-call schedule data access.
+type OnCallScheduleRepository interface {
+	Create(ctx context.Context, schedule *OnCallSchedule) error
+	FindAll(ctx context.Context) ([]*OnCallSchedule, error)
+	FindByID(ctx context.Context, id uint) (*OnCallSchedule, error)
+	FindAutoAssign(ctx context.Context) ([]*OnCallSchedule, error)
+	Update(ctx context.Context, schedule *OnCallSchedule) error // レイヤーは丸ごと置き換える
+	Delete(ctx context.Context, id uint) error
+}
+
+// OnCallOverrideRepository defines the interface for on-call override data access.
+type OnCallOverrideRepository interface {
+	Create(ctx context.Context, override *OnCallOverride) error
+	FindByID(ctx context.Context, id uint) (*OnCallOverride, error)
+	FindByScheduleID(ctx context.Context, scheduleID uint, from, to time.Time) ([]OnCallOverride, error) // 期間と重なるもの
+	Delete(ctx context.Context, id uint) error
+}
@@ -2,40 +2,60 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"time"
 )
 
 // PostMortem represents a post-mortem analysis for an incident.
 type PostMortem struct {
-	ID                    uint       `gorm:"primaryKey" json:"id"`
-	IncidentID            uint       `gorm:"uniqueIndex;not null" json:"incident_id"` // 1対1の関係
-	AuthorID              uint       `gorm:"not null;index" json:"author_id"`
-	RootCause             string     `gorm:"type:text" json:"root_cause"`
-	ImpactAnalysis        string     `gorm:"type:text" json:"impact_analysis"`
-	WhatWentWell          string     `gorm:"type:text" json:"what_went_well"`
-	WhatWentWrong         string     `gorm:"type:text" json:"what_went_wrong"`
-	LessonsLearned        string     `gorm:"type:text" json:"lessons_learned"`
-	FiveWhysAnalysis      string     `gorm:"type:json" json:"five_whys_analysis"`         // JSON形式で保存
-	AIRootCauseSuggestion string     `gorm:"type:text" json:"ai_root_cause_suggestion"`
-	Status                PMStatus   `gorm:"size:20;not null;default:'draft';index" json:"status"`
-	CreatedAt             time.Time  `gorm:"index" json:"created_at"`
-	UpdatedAt             time.Time  `json:"updated_at"`
-	PublishedAt           *time.Time `json:"published_at"`
+	ID                    uint                `gorm:"primaryKey" json:"id"`
+	IncidentID            uint                `gorm:"uniqueIndex;not null" json:"incident_id"` // 1対1の関係
+	AuthorID              uint                `gorm:"not null;index" json:"author_id"`
+	RootCause             string              `gorm:"type:text" json:"root_cause"`
+	ImpactAnalysis        string              `gorm:"type:text" json:"impact_analysis"`
+	WhatWentWell          string              `gorm:"type:text" json:"what_went_well"`
+	WhatWentWrong         string              `gorm:"type:text" json:"what_went_wrong"`
+	LessonsLearned        string              `gorm:"type:text" json:"lessons_learned"`
+	FiveWhysAnalysis      string              `gorm:"type:json" json:"five_whys_analysis"` // JSON形式で保存
+	AIRootCauseSuggestion string              `gorm:"type:text" json:"ai_root_cause_suggestion"`
+	TemplateID            *uint               `gorm:"index" json:"template_id"`                                // 使用したポストモーテムテンプレート
+	Sections              []PostMortemSection `gorm:"type:jsonb;serializer:json;default:'[]'" json:"sections"` // テンプレートのセクションごとの内容
+	Status                PMStatus            `gorm:"size:20;not null;default:'draft';index" json:"status"`
+	RequiredApprovals     int                 `gorm:"not null;default:0" json:"required_approvals"` // レビュー依頼時に確定する公開に必要な承認数
+	CurrentVersion        int                 `gorm:"not null;default:0" json:"current_version"`    // 最新の公開バージョン（未公開の場合は 0）
+	CreatedAt             time.Time           `gorm:"index" json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+	PublishedAt           *time.Time          `json:"published_at"`
 
 	// Relations
-	Incident    *Incident    `gorm:"foreignKey:IncidentID" json:"incident,omitempty"`
-	Author      *User        `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
-	ActionItems []ActionItem `gorm:"foreignKey:PostMortemID" json:"action_items,omitempty"`
+	Incident    *Incident            `gorm:"foreignKey:IncidentID" json:"incident,omitempty"`
+	Author      *User                `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	Template    *PostMortemTemplate  `gorm:"foreignKey:TemplateID" json:"template,omitempty"`
+	ActionItems []ActionItem         `gorm:"foreignKey:PostMortemID" json:"action_items,omitempty"`
+	Reviewers   []PostMortemReviewer `gorm:"foreignKey:PostMortemID" json:"reviewers,omitempty"`
 }
 
 // PMStatus represents the status of a post-mortem
 type PMStatus string
 
 const (
-	PMStatusDraft     PMStatus = "draft"
-	PMStatusPublished PMStatus = "published"
+	PMStatusDraft            PMStatus = "draft"
+	PMStatusInReview         PMStatus = "in_review"
+	PMStatusChangesRequested PMStatus = "changes_requested"
+	PMStatusApproved         PMStatus = "approved"
+	PMStatusPublished        PMStatus = "published"
 )
 
+// IsValid reports whether the status is known
+func (s PMStatus) IsValid() bool {
+	switch s {
+	case PMStatusDraft, PMStatusInReview, PMStatusChangesRequested, PMStatusApproved, PMStatusPublished:
+		return true
+	}
+	return false
+}
+
 // FiveWhysAnalysis represents the Five Whys structure
 type FiveWhysAnalysis struct {
 	Why1 string `json:"why1"`
@@ -45,6 +65,25 @@ type FiveWhysAnalysis struct {
 	Why5 string `json:"why5"`
 }
 
+// ParseFiveWhys returns the filled-in whys of a stored Five Whys analysis in order.
+// Content that is not the JSON structure is returned as a single line.
+func ParseFiveWhys(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var whys FiveWhysAnalysis
+	if err := json.Unmarshal([]byte(raw), &whys); err != nil {
+		return []string{raw}
+	}
+	var lines []string
+	for _, why := range []string{whys.Why1, whys.Why2, whys.Why3, whys.Why4, whys.Why5} {
+		if strings.TrimSpace(why) != "" {
+			lines = append(lines, why)
+		}
+	}
+	return lines
+}
+
 // PostMortemRepository defines the interface for post-mortem data access.
 type PostMortemRepository interface {
 	Create(ctx context.Context, pm *PostMortem) error
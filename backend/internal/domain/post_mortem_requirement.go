@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Limits of a post-mortem policy
+const (
+	DefaultPostMortemDueBusinessDays = 5
+	MaxPostMortemDueBusinessDays     = 60
+)
+
+// PostMortemPolicy makes a post-mortem mandatory for incidents that match it when they resolve.
+// Empty match fields act as wildcards; a policy without criteria applies to every incident.
+type PostMortemPolicy struct {
+	ID                   uint     `gorm:"primaryKey" json:"id"`
+	Name                 string   `gorm:"size:200;not null" json:"name"`
+	Description          string   `gorm:"type:text" json:"description"`
+	IsActive             bool     `gorm:"default:true;index" json:"is_active"`
+	Severity             Severity `gorm:"size:20;index" json:"severity,omitempty"` // 対象の重要度
+	TagID                *uint    `gorm:"index" json:"tag_id,omitempty"`           // 対象のタグ
+	DueBusinessDays      int      `gorm:"not null;default:5" json:"due_business_days"`
+	ReminderBusinessDays int      `gorm:"not null;default:1" json:"reminder_business_days"` // 期限の何営業日前にリマインドするか（0 は期限当日）
+
+	CreatorID uint      `gorm:"not null;index" json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Tag     *Tag  `gorm:"foreignKey:TagID" json:"tag,omitempty"`
+	Creator *User `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+}
+
+// Matches reports whether the policy applies to the incident
+func (p *PostMortemPolicy) Matches(incident *Incident) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.Severity != "" && p.Severity != incident.Severity {
+		return false
+	}
+	if p.TagID != nil {
+		for _, tag := range incident.Tags {
+			if tag.ID == *p.TagID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// SelectPostMortemPolicy returns the policy with the earliest due date among those matching the incident, or nil
+func SelectPostMortemPolicy(policies []*PostMortemPolicy, incident *Incident) *PostMortemPolicy {
+	var selected *PostMortemPolicy
+	for _, policy := range policies {
+		if !policy.Matches(incident) {
+			continue
+		}
+		if selected == nil || policy.DueBusinessDays < selected.DueBusinessDays {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// AddBusinessDays moves t by the given number of business days (Monday to Friday) in t's location.
+// Starting on a weekend counts from the next (or, going back, previous) business day.
+func AddBusinessDays(t time.Time, days int) time.Time {
+	step := 1
+	if days < 0 {
+		step = -1
+		days = -days
+	}
+	for days > 0 {
+		t = t.AddDate(0, 0, step)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return t
+}
+
+// PostMortemRequirementStatus represents whether a mandatory post-mortem has been delivered
+type PostMortemRequirementStatus string
+
+const (
+	RequirementStatusPending   PostMortemRequirementStatus = "pending"   // 公開待ち
+	RequirementStatusCompleted PostMortemRequirementStatus = "completed" // ポストモーテム公開済み
+	RequirementStatusWaived    PostMortemRequirementStatus = "waived"    // 管理者が免除
+)
+
+// PostMortemRequirement records that an incident needs a published post-mortem by DueAt
+type PostMortemRequirement struct {
+	ID           uint                        `gorm:"primaryKey" json:"id"`
+	IncidentID   uint                        `gorm:"not null;uniqueIndex" json:"incident_id"`
+	PolicyID     *uint                       `gorm:"index" json:"policy_id"`
+	Status       PostMortemRequirementStatus `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	ResolvedAt   time.Time                   `gorm:"not null;index" json:"resolved_at"` // 期限の起点となった解決日時
+	DueAt        time.Time                   `gorm:"not null;index" json:"due_at"`
+	RemindAt     time.Time                   `gorm:"not null" json:"remind_at"`
+	RemindedAt   *time.Time                  `json:"reminded_at"`
+	EscalatedAt  *time.Time                  `json:"escalated_at"` // 期限超過の通知日時
+	CompletedAt  *time.Time                  `json:"completed_at"`
+	WaivedByID   *uint                       `json:"waived_by_id"`
+	WaiverReason string                      `gorm:"type:text" json:"waiver_reason"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+
+	// Relations
+	Incident *Incident         `gorm:"foreignKey:IncidentID" json:"incident,omitempty"`
+	Policy   *PostMortemPolicy `gorm:"foreignKey:PolicyID" json:"policy,omitempty"`
+	WaivedBy *User             `gorm:"foreignKey:WaivedByID" json:"waived_by,omitempty"`
+}
+
+// IsOverdue reports whether the post-mortem is still missing after the due date
+func (r *PostMortemRequirement) IsOverdue(now time.Time) bool {
+	return r.Status == RequirementStatusPending && now.After(r.DueAt)
+}
+
+// CompletedOnTime reports whether the post-mortem was published by the due date
+func (r *PostMortemRequirement) CompletedOnTime() bool {
+	return r.Status == RequirementStatusCompleted && r.CompletedAt != nil && !r.CompletedAt.After(r.DueAt)
+}
+
+// PostMortemComplianceMonth counts mandatory post-mortems of incidents resolved in one month
+type PostMortemComplianceMonth struct {
+	Month          string  `json:"month"` // YYYY-MM
+	Required       int     `json:"required"`
+	OnTime         int     `json:"on_time"`         // 期限内に公開
+	Late           int     `json:"late"`            // 期限後に公開
+	Overdue        int     `json:"overdue"`         // 期限超過で未公開
+	Pending        int     `json:"pending"`         // 期限前で未公開
+	Waived         int     `json:"waived"`          // 免除
+	ComplianceRate float64 `json:"compliance_rate"` // 期限内公開 / (期限到来分 - 免除) (%)
+}
+
+// Add counts a requirement in the month
+func (m *PostMortemComplianceMonth) Add(r *PostMortemRequirement, now time.Time) {
+	m.Required++
+	switch {
+	case r.Status == RequirementStatusWaived:
+		m.Waived++
+	case r.CompletedOnTime():
+		m.OnTime++
+	case r.Status == RequirementStatusCompleted:
+		m.Late++
+	case r.IsOverdue(now):
+		m.Overdue++
+	default:
+		m.Pending++
+	}
+	if due := m.OnTime + m.Late + m.Overdue; due > 0 {
+		m.ComplianceRate = float64(m.OnTime) / float64(due) * 100
+	} else {
+		m.ComplianceRate = 0
+	}
+}
+
+// PostMortemComplianceReport is the monthly compliance with the mandatory post-mortem policies
+type PostMortemComplianceReport struct {
+	Period ReportPeriod                `json:"period"`
+	Months []PostMortemComplianceMonth `json:"months"`
+	Total  PostMortemComplianceMonth   `json:"total"`
+}
+
+// PostMortemPolicyRepository defines the interface for post-mortem policy data access
+type PostMortemPolicyRepository interface {
+	Create(ctx context.Context, policy *PostMortemPolicy) error
+	FindAll(ctx context.Context) ([]*PostMortemPolicy, error)
+	FindByID(ctx context.Context, id uint) (*PostMortemPolicy, error)
+	Update(ctx context.Context, policy *PostMortemPolicy) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// PostMortemRequirementRepository defines the interface for mandatory post-mortem data access
+type PostMortemRequirementRepository interface {
+	Create(ctx context.Context, requirement *PostMortemRequirement) error
+	FindByIncidentID(ctx context.Context, incidentID uint) (*PostMortemRequirement, error)
+	Update(ctx context.Context, requirement *PostMortemRequirement) error
+	// FindPending returns pending requirements with their incidents
+	FindPending(ctx context.Context) ([]*PostMortemRequirement, error)
+	// FindResolvedBetween returns requirements of incidents resolved in [start, end)
+	FindResolvedBetween(ctx context.Context, start, end time.Time) ([]*PostMortemRequirement, error)
+}
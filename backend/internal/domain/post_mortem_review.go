@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ReviewDecision is a reviewer's verdict on a post-mortem under review
+type ReviewDecision string
+
+const (
+	ReviewDecisionPending          ReviewDecision = "pending"
+	ReviewDecisionApproved         ReviewDecision = "approved"
+	ReviewDecisionChangesRequested ReviewDecision = "changes_requested"
+)
+
+// PostMortemReviewer is a reviewer assigned to a post-mortem.
+// Decisions are reset to pending every time the post-mortem is (re)submitted for review.
+type PostMortemReviewer struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	PostMortemID uint           `gorm:"not null;uniqueIndex:idx_post_mortem_reviewer" json:"post_mortem_id"`
+	ReviewerID   uint           `gorm:"not null;uniqueIndex:idx_post_mortem_reviewer;index" json:"reviewer_id"`
+	Decision     ReviewDecision `gorm:"size:20;not null;default:'pending'" json:"decision"`
+	Comment      string         `gorm:"type:text" json:"comment"` // 判定時のコメント
+	DecidedAt    *time.Time     `json:"decided_at"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+
+	// Relations
+	Reviewer *User `gorm:"foreignKey:ReviewerID" json:"reviewer,omitempty"`
+}
+
+// PostMortemReviewComment is a review comment anchored to a section of a post-mortem.
+// SectionKey is a template section key or one of ReviewAnchorFields; empty means the whole post-mortem.
+type PostMortemReviewComment struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	PostMortemID uint       `gorm:"not null;index" json:"post_mortem_id"`
+	AuthorID     uint       `gorm:"not null;index" json:"author_id"`
+	SectionKey   string     `gorm:"size:50;index" json:"section_key"`
+	Quote        string     `gorm:"type:text" json:"quote"` // コメント対象の引用箇所
+	Body         string     `gorm:"type:text;not null" json:"body"`
+	Resolved     bool       `gorm:"not null;default:false" json:"resolved"`
+	ResolvedByID *uint      `json:"resolved_by_id"`
+	ResolvedAt   *time.Time `json:"resolved_at"`
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Relations
+	Author     *User `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	ResolvedBy *User `gorm:"foreignKey:ResolvedByID" json:"resolved_by,omitempty"`
+}
+
+// ReviewAnchorFields are the fixed post-mortem fields review comments can be anchored to
+var ReviewAnchorFields = []string{
+	"root_cause",
+	"impact_analysis",
+	"what_went_well",
+	"what_went_wrong",
+	"lessons_learned",
+	"five_whys_analysis",
+	"causal_analysis",
+	"action_items",
+}
+
+// IsValidReviewAnchor reports whether a review comment can be anchored to the section key
+func (pm *PostMortem) IsValidReviewAnchor(sectionKey string) bool {
+	if sectionKey == "" {
+		return true
+	}
+	for _, field := range ReviewAnchorFields {
+		if sectionKey == field {
+			return true
+		}
+	}
+	for _, section := range pm.Sections {
+		if section.Key == sectionKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Approvals returns the number of reviewers who approved the post-mortem
+func (pm *PostMortem) Approvals() int {
+	approvals := 0
+	for _, reviewer := range pm.Reviewers {
+		if reviewer.Decision == ReviewDecisionApproved {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+// PostMortemReviewEvent is a review workflow transition that is notified to the author and reviewers
+type PostMortemReviewEvent string
+
+const (
+	ReviewEventSubmitted        PostMortemReviewEvent = "submitted"         // レビュー依頼
+	ReviewEventReviewerApproved PostMortemReviewEvent = "reviewer_approved" // レビュアー1名が承認
+	ReviewEventChangesRequested PostMortemReviewEvent = "changes_requested" // 修正依頼
+	ReviewEventApproved         PostMortemReviewEvent = "approved"          // 必要な承認数に到達
+	ReviewEventPublished        PostMortemReviewEvent = "published"
+	ReviewEventUnpublished      PostMortemReviewEvent = "unpublished"
+)
+
+// PostMortemReviewRepository defines the interface for post-mortem review data access
+type PostMortemReviewRepository interface {
+	FindReviewers(ctx context.Context, postMortemID uint) ([]PostMortemReviewer, error)
+	// ReplaceReviewers sets the reviewers, keeping the decisions of reviewers that stay assigned
+	ReplaceReviewers(ctx context.Context, postMortemID uint, reviewerIDs []uint) error
+	UpdateReviewer(ctx context.Context, reviewer *PostMortemReviewer) error
+	ResetDecisions(ctx context.Context, postMortemID uint) error
+	CreateComment(ctx context.Context, comment *PostMortemReviewComment) error
+	FindCommentByID(ctx context.Context, id uint) (*PostMortemReviewComment, error)
+	FindComments(ctx context.Context, postMortemID uint) ([]PostMortemReviewComment, error)
+	UpdateComment(ctx context.Context, comment *PostMortemReviewComment) error
+}
@@ -0,0 +1,381 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PostMortemSectionType is the kind of content a post-mortem section holds
+type PostMortemSectionType string
+
+const (
+	SectionTypeRichText  PostMortemSectionType = "rich_text" // 自由記述（Markdown）
+	SectionTypeChecklist PostMortemSectionType = "checklist" // チェックリスト
+	SectionTypeTable     PostMortemSectionType = "table"     // 列が定義された表
+	SectionTypeFiveWhys  PostMortemSectionType = "five_whys" // なぜなぜ分析
+)
+
+// Limits for templates and section content
+const (
+	maxTemplateSections = 50
+	maxFiveWhys         = 5
+	maxTableColumns     = 20
+)
+
+// MaxRequiredApprovals is the upper limit of approvals a post-mortem can require
+const MaxRequiredApprovals = 10
+
+var sectionKeyPattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// IsValid reports whether the section type is known
+func (t PostMortemSectionType) IsValid() bool {
+	switch t {
+	case SectionTypeRichText, SectionTypeChecklist, SectionTypeTable, SectionTypeFiveWhys:
+		return true
+	}
+	return false
+}
+
+// PostMortemTemplateSection defines one section of a post-mortem template
+type PostMortemTemplateSection struct {
+	Key         string                `json:"key"` // セクションの識別子（テンプレート内で一意）
+	Title       string                `json:"title"`
+	Type        PostMortemSectionType `json:"type"`
+	Description string                `json:"description,omitempty"` // 記入ガイド
+	Required    bool                  `json:"required"`              // 公開時に記入が必須か
+	Columns     []string              `json:"columns,omitempty"`     // table: 列名
+	Items       []string              `json:"items,omitempty"`       // checklist: 初期項目
+}
+
+// PostMortemTemplate defines the ordered sections of a post-mortem.
+// Severity and Tags decide which incidents the template is selected for.
+type PostMortemTemplate struct {
+	ID                uint                        `gorm:"primaryKey" json:"id"`
+	Name              string                      `gorm:"size:200;not null;index" json:"name"`
+	Description       string                      `gorm:"type:text" json:"description"`
+	Sections          []PostMortemTemplateSection `gorm:"type:jsonb;serializer:json;default:'[]'" json:"sections"`
+	Severity          Severity                    `gorm:"size:20;index" json:"severity"`         // 空の場合は全ての重要度が対象
+	IsDefault         bool                        `gorm:"default:false;index" json:"is_default"` // 条件に合うテンプレートがない場合に使用
+	RequiredApprovals *int                        `json:"required_approvals"`                    // 公開に必要な承認数（未設定の場合はシステム既定値）
+	CreatorID         uint                        `gorm:"not null;index" json:"creator_id"`
+	CreatedAt         time.Time                   `gorm:"index" json:"created_at"`
+	UpdatedAt         time.Time                   `json:"updated_at"`
+
+	// Relations
+	Creator *User `gorm:"foreignKey:CreatorID" json:"creator,omitempty"`
+	Tags    []Tag `gorm:"many2many:postmortem_template_tags" json:"tags,omitempty"` // いずれかのタグを持つインシデントが対象
+}
+
+// PostMortemChecklistItem is one item of a checklist section
+type PostMortemChecklistItem struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+}
+
+// PostMortemSection is the content of one section of a post-mortem.
+// The definition (title, type, columns) is copied from the template when the post-mortem is
+// written, so later template changes do not alter existing post-mortems.
+// Only the content field matching Type is used.
+type PostMortemSection struct {
+	Key       string                    `json:"key"`
+	Title     string                    `json:"title"`
+	Type      PostMortemSectionType     `json:"type"`
+	Required  bool                      `json:"required"`
+	Text      string                    `json:"text,omitempty"`      // rich_text
+	Checklist []PostMortemChecklistItem `json:"checklist,omitempty"` // checklist
+	Columns   []string                  `json:"columns,omitempty"`   // table
+	Rows      [][]string                `json:"rows,omitempty"`      // table
+	Whys      []string                  `json:"whys,omitempty"`      // five_whys
+}
+
+// IsEmpty reports whether nothing has been written in the section
+func (s *PostMortemSection) IsEmpty() bool {
+	switch s.Type {
+	case SectionTypeRichText:
+		return strings.TrimSpace(s.Text) == ""
+	case SectionTypeChecklist:
+		return len(s.Checklist) == 0
+	case SectionTypeTable:
+		return len(s.Rows) == 0
+	case SectionTypeFiveWhys:
+		for _, why := range s.Whys {
+			if strings.TrimSpace(why) != "" {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// PlainText renders the section content as plain text, one item per line
+func (s *PostMortemSection) PlainText() string {
+	var lines []string
+	switch s.Type {
+	case SectionTypeRichText:
+		return s.Text
+	case SectionTypeChecklist:
+		for _, item := range s.Checklist {
+			mark := "[ ]"
+			if item.Checked {
+				mark = "[x]"
+			}
+			lines = append(lines, fmt.Sprintf("%s %s", mark, item.Text))
+		}
+	case SectionTypeTable:
+		if len(s.Rows) > 0 {
+			lines = append(lines, strings.Join(s.Columns, " | "))
+		}
+		for _, row := range s.Rows {
+			lines = append(lines, strings.Join(row, " | "))
+		}
+	case SectionTypeFiveWhys:
+		for i, why := range s.Whys {
+			if strings.TrimSpace(why) != "" {
+				lines = append(lines, fmt.Sprintf("Why %d: %s", i+1, why))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate checks the template name and section definitions
+func (t *PostMortemTemplate) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrValidation("name is required")
+	}
+	if t.RequiredApprovals != nil && (*t.RequiredApprovals < 0 || *t.RequiredApprovals > MaxRequiredApprovals) {
+		return ErrValidation(fmt.Sprintf("required_approvals must be between 0 and %d", MaxRequiredApprovals))
+	}
+	if len(t.Sections) == 0 {
+		return ErrValidation("at least one section is required")
+	}
+	if len(t.Sections) > maxTemplateSections {
+		return ErrValidation(fmt.Sprintf("a template can have at most %d sections", maxTemplateSections))
+	}
+
+	keys := make(map[string]bool, len(t.Sections))
+	for _, section := range t.Sections {
+		if !sectionKeyPattern.MatchString(section.Key) {
+			return ErrValidation(fmt.Sprintf("invalid section key %q (lowercase letters, digits and _ only)", section.Key))
+		}
+		if keys[section.Key] {
+			return ErrValidation(fmt.Sprintf("duplicate section key %q", section.Key))
+		}
+		keys[section.Key] = true
+
+		if strings.TrimSpace(section.Title) == "" {
+			return ErrValidation(fmt.Sprintf("section %q: title is required", section.Key))
+		}
+		if !section.Type.IsValid() {
+			return ErrValidation(fmt.Sprintf("section %q: invalid type %q", section.Key, section.Type))
+		}
+		if section.Type == SectionTypeTable {
+			if len(section.Columns) == 0 || len(section.Columns) > maxTableColumns {
+				return ErrValidation(fmt.Sprintf("section %q: a table needs 1 to %d columns", section.Key, maxTableColumns))
+			}
+			for _, column := range section.Columns {
+				if strings.TrimSpace(column) == "" {
+					return ErrValidation(fmt.Sprintf("section %q: column names must not be empty", section.Key))
+				}
+			}
+		} else if len(section.Columns) > 0 {
+			return ErrValidation(fmt.Sprintf("section %q: columns are only allowed for table sections", section.Key))
+		}
+		if section.Type != SectionTypeChecklist && len(section.Items) > 0 {
+			return ErrValidation(fmt.Sprintf("section %q: items are only allowed for checklist sections", section.Key))
+		}
+	}
+	return nil
+}
+
+// NewSections returns empty sections in template order; checklists start with the template's items unchecked
+func (t *PostMortemTemplate) NewSections() []PostMortemSection {
+	sections := make([]PostMortemSection, 0, len(t.Sections))
+	for _, def := range t.Sections {
+		section := PostMortemSection{
+			Key:      def.Key,
+			Title:    def.Title,
+			Type:     def.Type,
+			Required: def.Required,
+			Columns:  def.Columns,
+		}
+		for _, item := range def.Items {
+			section.Checklist = append(section.Checklist, PostMortemChecklistItem{Text: item})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// MergeSections validates submitted section content against the current sections and returns
+// the updated sections. Sections that are not submitted keep their content.
+func MergeSections(current, submitted []PostMortemSection) ([]PostMortemSection, error) {
+	index := make(map[string]int, len(current))
+	for i, section := range current {
+		index[section.Key] = i
+	}
+
+	merged := make([]PostMortemSection, len(current))
+	copy(merged, current)
+	seen := make(map[string]bool, len(submitted))
+	for _, section := range submitted {
+		i, ok := index[section.Key]
+		if !ok {
+			return nil, ErrValidation(fmt.Sprintf("unknown section %q", section.Key))
+		}
+		if seen[section.Key] {
+			return nil, ErrValidation(fmt.Sprintf("section %q was given more than once", section.Key))
+		}
+		seen[section.Key] = true
+
+		def := current[i]
+		if section.Type != "" && section.Type != def.Type {
+			return nil, ErrValidation(fmt.Sprintf("section %q is of type %s", section.Key, def.Type))
+		}
+		content, err := sectionContent(def, section)
+		if err != nil {
+			return nil, err
+		}
+		merged[i] = content
+	}
+	return merged, nil
+}
+
+// sectionContent copies the content of the submitted section that matches the definition's type
+func sectionContent(def, submitted PostMortemSection) (PostMortemSection, error) {
+	section := PostMortemSection{
+		Key:      def.Key,
+		Title:    def.Title,
+		Type:     def.Type,
+		Required: def.Required,
+		Columns:  def.Columns,
+	}
+	invalid := func(format string, args ...interface{}) (PostMortemSection, error) {
+		return PostMortemSection{}, ErrValidation(fmt.Sprintf("section %q: ", def.Key) + fmt.Sprintf(format, args...))
+	}
+
+	switch def.Type {
+	case SectionTypeRichText:
+		if len(submitted.Checklist) > 0 || len(submitted.Rows) > 0 || len(submitted.Whys) > 0 {
+			return invalid("only text is allowed for a rich_text section")
+		}
+		section.Text = submitted.Text
+	case SectionTypeChecklist:
+		if submitted.Text != "" || len(submitted.Rows) > 0 || len(submitted.Whys) > 0 {
+			return invalid("only checklist is allowed for a checklist section")
+		}
+		for _, item := range submitted.Checklist {
+			if strings.TrimSpace(item.Text) == "" {
+				return invalid("checklist items must have text")
+			}
+		}
+		section.Checklist = submitted.Checklist
+	case SectionTypeTable:
+		if submitted.Text != "" || len(submitted.Checklist) > 0 || len(submitted.Whys) > 0 {
+			return invalid("only rows are allowed for a table section")
+		}
+		for i, row := range submitted.Rows {
+			if len(row) != len(def.Columns) {
+				return invalid("row %d has %d cells, expected %d", i+1, len(row), len(def.Columns))
+			}
+		}
+		section.Rows = submitted.Rows
+	case SectionTypeFiveWhys:
+		if submitted.Text != "" || len(submitted.Checklist) > 0 || len(submitted.Rows) > 0 {
+			return invalid("only whys are allowed for a five_whys section")
+		}
+		if len(submitted.Whys) > maxFiveWhys {
+			return invalid("at most %d whys are allowed", maxFiveWhys)
+		}
+		section.Whys = submitted.Whys
+	}
+	return section, nil
+}
+
+// MissingRequiredSections returns the titles of required sections that are still empty
+func (pm *PostMortem) MissingRequiredSections() []string {
+	var missing []string
+	for i := range pm.Sections {
+		if pm.Sections[i].Required && pm.Sections[i].IsEmpty() {
+			missing = append(missing, pm.Sections[i].Title)
+		}
+	}
+	return missing
+}
+
+// SelectPostMortemTemplate picks the template for an incident (Tags must be loaded).
+// A template matching one of the incident's tags is preferred over one matching only the
+// severity; templates with both conditions must match both. Ties go to the template with
+// more matching tags, then the oldest. The default template is used when nothing matches.
+func SelectPostMortemTemplate(templates []*PostMortemTemplate, incident *Incident) *PostMortemTemplate {
+	incidentTags := make(map[uint]bool, len(incident.Tags))
+	for _, tag := range incident.Tags {
+		incidentTags[tag.ID] = true
+	}
+
+	type candidate struct {
+		template    *PostMortemTemplate
+		score       int
+		matchedTags int
+	}
+	var candidates []candidate
+	var fallback *PostMortemTemplate
+
+	for _, template := range templates {
+		if template.IsDefault && (fallback == nil || template.ID < fallback.ID) {
+			fallback = template
+		}
+		if template.Severity == "" && len(template.Tags) == 0 {
+			continue
+		}
+		if template.Severity != "" && template.Severity != incident.Severity {
+			continue
+		}
+		matchedTags := 0
+		for _, tag := range template.Tags {
+			if incidentTags[tag.ID] {
+				matchedTags++
+			}
+		}
+		if len(template.Tags) > 0 && matchedTags == 0 {
+			continue
+		}
+
+		score := 0
+		if matchedTags > 0 {
+			score += 2
+		}
+		if template.Severity != "" {
+			score++
+		}
+		candidates = append(candidates, candidate{template: template, score: score, matchedTags: matchedTags})
+	}
+
+	if len(candidates) == 0 {
+		return fallback
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		if candidates[i].matchedTags != candidates[j].matchedTags {
+			return candidates[i].matchedTags > candidates[j].matchedTags
+		}
+		return candidates[i].template.ID < candidates[j].template.ID
+	})
+	return candidates[0].template
+}
+
+// PostMortemTemplateRepository defines the interface for post-mortem template data access
+type PostMortemTemplateRepository interface {
+	Create(ctx context.Context, template *PostMortemTemplate) error
+	FindAll(ctx context.Context) ([]*PostMortemTemplate, error)
+	FindByID(ctx context.Context, id uint) (*PostMortemTemplate, error)
+	Update(ctx context.Context, template *PostMortemTemplate) error
+	Delete(ctx context.Context, id uint) error
+}
@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostMortemVersion is an immutable snapshot of a post-mortem taken every time it is published.
+// Versions are numbered from 1 per post-mortem.
+type PostMortemVersion struct {
+	ID            uint               `gorm:"primaryKey" json:"id"`
+	PostMortemID  uint               `gorm:"not null;uniqueIndex:idx_post_mortem_version" json:"post_mortem_id"`
+	Version       int                `gorm:"not null;uniqueIndex:idx_post_mortem_version" json:"version"`
+	Content       *PostMortemContent `gorm:"type:jsonb;serializer:json;not null" json:"content,omitempty"` // 一覧では省略
+	PublishedByID uint               `gorm:"not null" json:"published_by_id"`
+	PublishedAt   time.Time          `gorm:"not null" json:"published_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+
+	// Relations
+	PublishedBy *User `gorm:"foreignKey:PublishedByID" json:"published_by,omitempty"`
+}
+
+// PostMortemContent is the content of a post-mortem as readers saw it when it was published
+type PostMortemContent struct {
+	RootCause        string               `json:"root_cause"`
+	ImpactAnalysis   string               `json:"impact_analysis"`
+	WhatWentWell     string               `json:"what_went_well"`
+	WhatWentWrong    string               `json:"what_went_wrong"`
+	LessonsLearned   string               `json:"lessons_learned"`
+	FiveWhysAnalysis string               `json:"five_whys_analysis"`
+	TemplateID       *uint                `json:"template_id"`
+	Sections         []PostMortemSection  `json:"sections"`
+	CausalNodes      []CausalNode         `json:"causal_nodes"`
+	ActionItems      []ActionItemSnapshot `json:"action_items"`
+	Approvers        []string             `json:"approvers"` // 公開時に承認済みだったレビュアー
+}
+
+// ActionItemSnapshot is an action item as it was when the post-mortem was published
+type ActionItemSnapshot struct {
+	ID           uint         `json:"id"`
+	Title        string       `json:"title"`
+	Priority     Priority     `json:"priority"`
+	Status       ActionStatus `json:"status"`
+	AssigneeID   *uint        `json:"assignee_id"`
+	AssigneeName string       `json:"assignee_name,omitempty"`
+	DueDate      *time.Time   `json:"due_date"`
+}
+
+// NewPostMortemContent captures the current content of a post-mortem.
+// pm must have ActionItems (with Assignee) and Reviewers loaded.
+func NewPostMortemContent(pm *PostMortem, causalNodes []CausalNode) *PostMortemContent {
+	content := &PostMortemContent{
+		RootCause:        pm.RootCause,
+		ImpactAnalysis:   pm.ImpactAnalysis,
+		WhatWentWell:     pm.WhatWentWell,
+		WhatWentWrong:    pm.WhatWentWrong,
+		LessonsLearned:   pm.LessonsLearned,
+		FiveWhysAnalysis: pm.FiveWhysAnalysis,
+		TemplateID:       pm.TemplateID,
+		Sections:         append([]PostMortemSection{}, pm.Sections...),
+		CausalNodes:      append([]CausalNode{}, causalNodes...),
+		ActionItems:      []ActionItemSnapshot{},
+		Approvers:        []string{},
+	}
+	for _, item := range pm.ActionItems {
+		snapshot := ActionItemSnapshot{
+			ID:         item.ID,
+			Title:      item.Title,
+			Priority:   item.Priority,
+			Status:     item.Status,
+			AssigneeID: item.AssigneeID,
+			DueDate:    item.DueDate,
+		}
+		if item.Assignee != nil {
+			snapshot.AssigneeName = item.Assignee.Name
+		}
+		content.ActionItems = append(content.ActionItems, snapshot)
+	}
+	for _, reviewer := range pm.Reviewers {
+		if reviewer.Decision == ReviewDecisionApproved && reviewer.Reviewer != nil {
+			content.Approvers = append(content.Approvers, reviewer.Reviewer.Name)
+		}
+	}
+	return content
+}
+
+// PostMortemContentField is one field of the content rendered as plain text, for comparison
+type PostMortemContentField struct {
+	Key   string
+	Title string
+	Text  string
+}
+
+// Fields renders the content field by field in display order. Template sections use their own keys.
+func (c *PostMortemContent) Fields() []PostMortemContentField {
+	fields := []PostMortemContentField{
+		{Key: "root_cause", Title: "Root Cause", Text: c.RootCause},
+		{Key: "impact_analysis", Title: "Impact Analysis", Text: c.ImpactAnalysis},
+	}
+	for _, section := range c.Sections {
+		fields = append(fields, PostMortemContentField{Key: section.Key, Title: section.Title, Text: section.PlainText()})
+	}
+	fields = append(fields,
+		PostMortemContentField{Key: "five_whys_analysis", Title: "Five Whys", Text: fiveWhysText(c.FiveWhysAnalysis)},
+		PostMortemContentField{Key: "causal_analysis", Title: "Causal Analysis", Text: causalTreeText(c.CausalNodes)},
+		PostMortemContentField{Key: "what_went_well", Title: "What Went Well", Text: c.WhatWentWell},
+		PostMortemContentField{Key: "what_went_wrong", Title: "What Went Wrong", Text: c.WhatWentWrong},
+		PostMortemContentField{Key: "lessons_learned", Title: "Lessons Learned", Text: c.LessonsLearned},
+		PostMortemContentField{Key: "action_items", Title: "Action Items", Text: actionItemsText(c.ActionItems)},
+	)
+	return fields
+}
+
+func fiveWhysText(raw string) string {
+	var lines []string
+	for i, why := range ParseFiveWhys(raw) {
+		lines = append(lines, fmt.Sprintf("Why %d: %s", i+1, why))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func causalTreeText(nodes []CausalNode) string {
+	var lines []string
+	BuildCausalTree(0, nodes).Walk(func(node *CausalTreeNode, depth int) {
+		kind := "contributing"
+		if node.IsRootCause {
+			kind = "root cause"
+		}
+		lines = append(lines, fmt.Sprintf("%s- [%s] [%s] %s", strings.Repeat("  ", depth), kind, node.Category, node.Description))
+	})
+	return strings.Join(lines, "\n")
+}
+
+func actionItemsText(items []ActionItemSnapshot) string {
+	var lines []string
+	for _, item := range items {
+		line := fmt.Sprintf("- [%s] %s (%s", item.Status, item.Title, item.Priority)
+		if item.AssigneeName != "" {
+			line += ", " + item.AssigneeName
+		}
+		if item.DueDate != nil {
+			line += ", due " + item.DueDate.Format("2006-01-02")
+		}
+		lines = append(lines, line+")")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// PostMortemFieldChange is how a field changed between two versions
+type PostMortemFieldChange string
+
+const (
+	FieldAdded    PostMortemFieldChange = "added"
+	FieldRemoved  PostMortemFieldChange = "removed"
+	FieldModified PostMortemFieldChange = "modified"
+)
+
+// PostMortemFieldDiff is the line diff of one changed field
+type PostMortemFieldDiff struct {
+	Key    string                `json:"key"`
+	Title  string                `json:"title"`
+	Change PostMortemFieldChange `json:"change"`
+	Lines  []DiffLine            `json:"lines"`
+}
+
+// PostMortemVersionDiff compares two versions of a post-mortem; version 0 is the current working copy
+type PostMortemVersionDiff struct {
+	PostMortemID uint                  `json:"post_mortem_id"`
+	FromVersion  int                   `json:"from_version"`
+	ToVersion    int                   `json:"to_version"`
+	Changes      []PostMortemFieldDiff `json:"changes"` // 変更のあったフィールドのみ
+}
+
+// DiffPostMortemContent returns the fields that differ between two contents,
+// in the display order of the newer content followed by fields that were removed
+func DiffPostMortemContent(from, to *PostMortemContent) []PostMortemFieldDiff {
+	fromFields := make(map[string]PostMortemContentField)
+	for _, field := range from.Fields() {
+		fromFields[field.Key] = field
+	}
+
+	changes := []PostMortemFieldDiff{}
+	seen := make(map[string]bool)
+	for _, field := range to.Fields() {
+		seen[field.Key] = true
+		old := fromFields[field.Key]
+		if old.Text == field.Text {
+			continue
+		}
+		change := FieldModified
+		if old.Text == "" {
+			change = FieldAdded
+		} else if field.Text == "" {
+			change = FieldRemoved
+		}
+		changes = append(changes, PostMortemFieldDiff{Key: field.Key, Title: field.Title, Change: change, Lines: DiffLines(old.Text, field.Text)})
+	}
+	for _, field := range from.Fields() {
+		if !seen[field.Key] && field.Text != "" {
+			changes = append(changes, PostMortemFieldDiff{Key: field.Key, Title: field.Title, Change: FieldRemoved, Lines: DiffLines(field.Text, "")})
+		}
+	}
+	return changes
+}
+
+// PostMortemDocument is a post-mortem prepared for export: either the current content or a published version
+type PostMortemDocument struct {
+	PostMortem    *PostMortem // バージョン指定時はスナップショットの内容を反映したコピー
+	CausalTree    *CausalTree
+	Version       int  // 出力した公開バージョン（未公開の作業中の内容は 0）
+	LatestVersion int  // 最新の公開バージョン（未公開の場合は 0）
+	IsSnapshot    bool // 過去の公開スナップショットから出力した場合 true
+
+	// Incident with Assignee and Tags loaded, and its timeline events ordered by time.
+	// Both are the current state of the incident, also for published versions.
+	Incident *Incident
+	Timeline []*IncidentActivity
+}
+
+// NewPostMortemDocument builds the export of the current content (version nil) or of a published version
+func NewPostMortemDocument(pm *PostMortem, causalNodes []CausalNode, version *PostMortemVersion) *PostMortemDocument {
+	doc := &PostMortemDocument{LatestVersion: pm.CurrentVersion}
+
+	if version == nil || version.Content == nil {
+		doc.PostMortem = pm
+		doc.CausalTree = BuildCausalTree(pm.ID, causalNodes)
+		// Published post-mortems cannot be edited, so the current content is the latest version
+		if pm.Status == PMStatusPublished {
+			doc.Version = pm.CurrentVersion
+		}
+		return doc
+	}
+
+	snapshot := *pm
+	content := version.Content
+	snapshot.RootCause = content.RootCause
+	snapshot.ImpactAnalysis = content.ImpactAnalysis
+	snapshot.WhatWentWell = content.WhatWentWell
+	snapshot.WhatWentWrong = content.WhatWentWrong
+	snapshot.LessonsLearned = content.LessonsLearned
+	snapshot.FiveWhysAnalysis = content.FiveWhysAnalysis
+	snapshot.TemplateID = content.TemplateID
+	snapshot.Sections = content.Sections
+	snapshot.Status = PMStatusPublished
+	publishedAt := version.PublishedAt
+	snapshot.PublishedAt = &publishedAt
+	snapshot.ActionItems = make([]ActionItem, 0, len(content.ActionItems))
+	for _, item := range content.ActionItems {
+		actionItem := ActionItem{
+			ID:           item.ID,
+			IncidentID:   &pm.IncidentID,
+			PostMortemID: &pm.ID,
+			Title:        item.Title,
+			Priority:     item.Priority,
+			Status:       item.Status,
+			AssigneeID:   item.AssigneeID,
+			DueDate:      item.DueDate,
+		}
+		if item.AssigneeID != nil {
+			actionItem.Assignee = &User{ID: *item.AssigneeID, Name: item.AssigneeName}
+		}
+		snapshot.ActionItems = append(snapshot.ActionItems, actionItem)
+	}
+
+	doc.PostMortem = &snapshot
+	doc.CausalTree = BuildCausalTree(pm.ID, content.CausalNodes)
+	doc.Version = version.Version
+	doc.IsSnapshot = true
+	return doc
+}
+
+// PostMortemVersionRepository defines the interface for post-mortem version data access
+type PostMortemVersionRepository interface {
+	// Publish saves the snapshot and the published post-mortem in one transaction
+	Publish(ctx context.Context, pm *PostMortem, version *PostMortemVersion) error
+	// FindByPostMortemID lists the versions, newest first, without their content
+	FindByPostMortemID(ctx context.Context, postMortemID uint) ([]PostMortemVersion, error)
+	FindByVersion(ctx context.Context, postMortemID uint, version int) (*PostMortemVersion, error)
+}
@@ -12,6 +12,8 @@ type MonthlyReport struct {
 	TopTags          []TagStatistic          `json:"top_tags"`
 	PerformanceMetrics PerformanceMetrics    `json:"performance_metrics"`
 	Comparison       *PeriodComparison       `json:"comparison,omitempty"`
+	Lifecycle        *LifecycleMetrics       `json:"lifecycle,omitempty"`
+	CustomerImpact   *CustomerImpactSummary  `json:"customer_impact,omitempty"`
 }
 
 // ReportPeriod defines the time period for the report
@@ -58,9 +60,120 @@ type PeriodComparison struct {
 	ResolvedIncidentsChangePercent float64 `json:"resolved_incidents_change_percent"`
 }
 
+// CustomerImpactSummary totals the customer-impact windows overlapping the report period.
+// Only the part of each window inside the period (or month) is counted.
+type CustomerImpactSummary struct {
+	TotalImpactMinutes   float64           `json:"total_impact_minutes"`
+	ImpactedIncidents    int               `json:"impacted_incidents"`
+	AffectedUsers        int64             `json:"affected_users"`
+	FailedRequests       int64             `json:"failed_requests"`
+	EstimatedRevenueLoss float64           `json:"estimated_revenue_loss"`
+	ByMonth              []ImpactByMonth   `json:"by_month"`
+	ByService            []ImpactByService `json:"by_service"`
+}
+
+// ImpactByMonth is the customer-impact total of a calendar month (UTC)
+type ImpactByMonth struct {
+	Month             string  `json:"month"` // YYYY-MM
+	ImpactMinutes     float64 `json:"impact_minutes"`
+	ImpactedIncidents int     `json:"impacted_incidents"`
+}
+
+// ImpactByService is the customer-impact total of a service
+type ImpactByService struct {
+	Service              string   `json:"service"`
+	ImpactMinutes        float64  `json:"impact_minutes"`
+	ImpactedIncidents    int      `json:"impacted_incidents"`
+	AffectedUsers        int64    `json:"affected_users"`
+	FailedRequests       int64    `json:"failed_requests"`
+	EstimatedRevenueLoss float64  `json:"estimated_revenue_loss"`
+	AffectedRegions      []string `json:"affected_regions"`
+}
+
+// ResponderLoadReport summarizes the on-call burden per responder and per department
+type ResponderLoadReport struct {
+	Period        ReportPeriod     `json:"period"`
+	Timezone      string           `json:"timezone"`
+	BusinessHours string           `json:"business_hours"` // 平日の業務時間 (HH:MM-HH:MM)
+	NightHours    string           `json:"night_hours"`    // 深夜帯 (HH:MM-HH:MM)
+	Users         []ResponderLoad  `json:"users"`
+	Departments   []DepartmentLoad `json:"departments"`
+}
+
+// ResponderLoad holds the burden indicators of a single responder.
+// An interruption is a page, an assignment or an acknowledgement; events for the same
+// incident within a short window are counted once.
+type ResponderLoad struct {
+	UserID                  uint    `json:"user_id"`
+	UserName                string  `json:"user_name"`
+	Department              string  `json:"department"`
+	IncidentsHandled        int     `json:"incidents_handled"`
+	Interruptions           int     `json:"interruptions"`
+	AfterHoursInterruptions int     `json:"after_hours_interruptions"` // 平日の業務時間外
+	WeekendInterruptions    int     `json:"weekend_interruptions"`
+	NightInterruptions      int     `json:"night_interruptions"` // 深夜帯
+	AssigneeHours           float64 `json:"assignee_hours"`
+	MaxConsecutiveNights    int     `json:"max_consecutive_nights"` // 深夜の呼び出しが連続した最大日数
+}
+
+// DepartmentLoad aggregates ResponderLoad over the members of a department.
+type DepartmentLoad struct {
+	Department              string  `json:"department"`
+	Responders              int     `json:"responders"`
+	IncidentsHandled        int     `json:"incidents_handled"` // 部署内で重複を除いた件数
+	Interruptions           int     `json:"interruptions"`
+	AfterHoursInterruptions int     `json:"after_hours_interruptions"`
+	WeekendInterruptions    int     `json:"weekend_interruptions"`
+	NightInterruptions      int     `json:"night_interruptions"`
+	AssigneeHours           float64 `json:"assignee_hours"`
+	MaxConsecutiveNights    int     `json:"max_consecutive_nights"` // メンバーの最大値
+}
+
+// HandoffReport summarizes what happened during an on-call shift for the next responder
+type HandoffReport struct {
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to"`
+	GeneratedAt   time.Time         `json:"generated_at"`
+	Opened        []HandoffIncident `json:"opened"`         // 期間中に検知された
+	Changed       []HandoffIncident `json:"changed"`        // 期間中に更新された（検知・解決を除く）
+	Resolved      []HandoffIncident `json:"resolved"`       // 期間中に解決された
+	StillOpen     []HandoffIncident `json:"still_open"`     // 期間終了時点で未解決
+	UpcomingSLA   []HandoffIncident `json:"upcoming_sla"`   // 引き継ぎ後まもなくSLA期限を迎える（超過を含む）
+	NotableEvents []HandoffEvent    `json:"notable_events"` // コメントとタイムラインイベント
+}
+
+// HandoffIncident is an incident as listed in a handoff report.
+type HandoffIncident struct {
+	ID           uint       `json:"id"`
+	Title        string     `json:"title"`
+	Severity     Severity   `json:"severity"`
+	Status       Status     `json:"status"`
+	Service      string     `json:"service,omitempty"`
+	AssigneeName string     `json:"assignee_name,omitempty"`
+	DetectedAt   time.Time  `json:"detected_at"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+	SLADeadline  *time.Time `json:"sla_deadline,omitempty"`
+	SLAViolated  bool       `json:"sla_violated"`
+	Changes      []string   `json:"changes,omitempty"` // 期間中の変更内容
+}
+
+// HandoffEvent is a comment or timeline event in a handoff report.
+type HandoffEvent struct {
+	IncidentID    uint         `json:"incident_id"`
+	IncidentTitle string       `json:"incident_title"`
+	ActivityType  ActivityType `json:"activity_type"`
+	UserName      string       `json:"user_name"`
+	Comment       string       `json:"comment"`
+	At            time.Time    `json:"at"`
+}
+
 // ReportRepository defines operations for generating reports
 type ReportRepository interface {
 	GetMonthlyReport(startDate, endDate time.Time) (*MonthlyReport, error)
 	GetIncidentCountByDay(startDate, endDate time.Time) ([]DailyIncidentCount, error)
 	GetTopTags(startDate, endDate time.Time, limit int) ([]TagStatistic, error)
+	GetAssignmentsBetween(startDate, endDate time.Time) ([]IncidentAssignment, error) // 期間と重なる担当期間
+	GetActivitiesBetween(startDate, endDate time.Time, types []ActivityType) ([]IncidentActivity, error)
+	GetHandoffIncidents(startDate, endDate time.Time) ([]*Incident, error)       // 期間中に検知・解決・更新されたもの、および未解決のもの
+	GetLifecycleMetrics(startDate, endDate time.Time) (*LifecycleMetrics, error) // 期間中に検知されたもの
 }
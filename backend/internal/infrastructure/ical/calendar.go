@@ -0,0 +1,307 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	productID      = "-//Incidex//Incident Management System//JA"
+	dateTimeFormat = "20060102T150405"
+	dateFormat     = "20060102"
+	maxLineOctets  = 75
+)
+
+// Event is a single VEVENT.
+// Timed events are written with a TZID when Location is set, otherwise in UTC.
+// All-day events use the date of Start in Location (or UTC) and end the next day.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Location     *time.Location
+	LastModified time.Time
+	Categories   []string
+}
+
+// Calendar accumulates events and renders them as an RFC 5545 VCALENDAR.
+type Calendar struct {
+	name   string
+	events []Event
+}
+
+// NewCalendar creates an empty calendar with the given display name.
+func NewCalendar(name string) *Calendar {
+	return &Calendar{name: name}
+}
+
+// AddEvent appends an event to the calendar.
+func (c *Calendar) AddEvent(event Event) {
+	c.events = append(c.events, event)
+}
+
+// Bytes renders the calendar, including a VTIMEZONE for every timezone referenced by an event.
+func (c *Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	w := &writer{buf: &buf}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + productID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.line("X-WR-CALNAME:" + escapeText(c.name))
+	w.line("X-PUBLISHED-TTL:PT1H")
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+
+	for _, tz := range c.timezones() {
+		writeTimezone(w, tz.loc, tz.from, tz.to)
+	}
+
+	for _, event := range c.events {
+		writeEvent(w, event)
+	}
+
+	w.line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+type timezoneRange struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// timezones collects the non-UTC timezones used by timed events along with the period they span.
+func (c *Calendar) timezones() []timezoneRange {
+	ranges := make(map[string]*timezoneRange)
+	for _, event := range c.events {
+		if event.AllDay || event.Location == nil || event.Location == time.UTC {
+			continue
+		}
+		name := event.Location.String()
+		r, ok := ranges[name]
+		if !ok {
+			ranges[name] = &timezoneRange{loc: event.Location, from: event.Start, to: event.End}
+			continue
+		}
+		if event.Start.Before(r.from) {
+			r.from = event.Start
+		}
+		if event.End.After(r.to) {
+			r.to = event.End
+		}
+	}
+
+	names := make([]string, 0, len(ranges))
+	for name := range ranges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]timezoneRange, 0, len(names))
+	for _, name := range names {
+		result = append(result, *ranges[name])
+	}
+	return result
+}
+
+func writeEvent(w *writer, event Event) {
+	stamp := event.LastModified
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + event.UID)
+	w.line("DTSTAMP:" + stamp.UTC().Format(dateTimeFormat) + "Z")
+	w.line("LAST-MODIFIED:" + stamp.UTC().Format(dateTimeFormat) + "Z")
+
+	switch {
+	case event.AllDay:
+		loc := event.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		day := event.Start.In(loc)
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		w.line("DTSTART;VALUE=DATE:" + start.Format(dateFormat))
+		w.line("DTEND;VALUE=DATE:" + start.AddDate(0, 0, 1).Format(dateFormat))
+	case event.Location != nil && event.Location != time.UTC:
+		tzid := event.Location.String()
+		w.line(fmt.Sprintf("DTSTART;TZID=%s:%s", tzid, event.Start.In(event.Location).Format(dateTimeFormat)))
+		w.line(fmt.Sprintf("DTEND;TZID=%s:%s", tzid, event.End.In(event.Location).Format(dateTimeFormat)))
+	default:
+		w.line("DTSTART:" + event.Start.UTC().Format(dateTimeFormat) + "Z")
+		w.line("DTEND:" + event.End.UTC().Format(dateTimeFormat) + "Z")
+	}
+
+	w.line("SUMMARY:" + escapeText(event.Summary))
+	if event.Description != "" {
+		w.line("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.URL != "" {
+		w.line("URL:" + event.URL)
+	}
+	if len(event.Categories) > 0 {
+		escaped := make([]string, len(event.Categories))
+		for i, category := range event.Categories {
+			escaped[i] = escapeText(category)
+		}
+		w.line("CATEGORIES:" + strings.Join(escaped, ","))
+	}
+	w.line("TRANSP:TRANSPARENT")
+	w.line("END:VEVENT")
+}
+
+// writeTimezone renders a VTIMEZONE from Go's timezone database.
+// Each offset transition between from and to becomes its own STANDARD or DAYLIGHT
+// observance, which keeps the output exact without having to reverse-engineer RRULEs.
+func writeTimezone(w *writer, loc *time.Location, from, to time.Time) {
+	// Cover a margin around the events so recurring client-side lookups stay correct
+	from = time.Date(from.Year()-1, 1, 1, 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year()+2, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + loc.String())
+
+	transitions := findTransitions(loc, from, to)
+	if len(transitions) == 0 {
+		// Fixed-offset zone: a single standard observance describes it fully
+		name, offset := from.In(loc).Zone()
+		writeObservance(w, "STANDARD", name, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), offset, offset)
+	} else {
+		// Observance in effect at the start of the range, so earlier times are still defined
+		name, offset := from.In(loc).Zone()
+		component := "STANDARD"
+		if from.In(loc).IsDST() {
+			component = "DAYLIGHT"
+		}
+		writeObservance(w, component, name, from.Add(time.Duration(offset)*time.Second).UTC(), offset, offset)
+
+		for _, t := range transitions {
+			component := "STANDARD"
+			if t.at.In(loc).IsDST() {
+				component = "DAYLIGHT"
+			}
+			// DTSTART of an observance is expressed in the local time before the transition
+			local := t.at.Add(time.Duration(t.offsetFrom) * time.Second).UTC()
+			writeObservance(w, component, t.name, local, t.offsetFrom, t.offsetTo)
+		}
+	}
+
+	w.line("END:VTIMEZONE")
+}
+
+func writeObservance(w *writer, component, name string, localStart time.Time, offsetFrom, offsetTo int) {
+	w.line("BEGIN:" + component)
+	w.line("DTSTART:" + localStart.Format(dateTimeFormat))
+	w.line("TZOFFSETFROM:" + formatOffset(offsetFrom))
+	w.line("TZOFFSETTO:" + formatOffset(offsetTo))
+	if name != "" {
+		w.line("TZNAME:" + escapeText(name))
+	}
+	w.line("END:" + component)
+}
+
+type transition struct {
+	at         time.Time
+	name       string
+	offsetFrom int
+	offsetTo   int
+}
+
+// findTransitions returns the UTC offset changes of loc between from and to, to the second.
+func findTransitions(loc *time.Location, from, to time.Time) []transition {
+	var transitions []transition
+	_, prevOffset := from.In(loc).Zone()
+	prev := from
+	for t := from.Add(24 * time.Hour); !t.After(to); t = t.Add(24 * time.Hour) {
+		_, offset := t.In(loc).Zone()
+		if offset == prevOffset {
+			prev = t
+			continue
+		}
+
+		// Binary search for the first instant with the new offset
+		lo, hi := prev, t
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.In(loc).Zone(); o == prevOffset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		name, _ := hi.In(loc).Zone()
+		transitions = append(transitions, transition{at: hi.Truncate(time.Second), name: name, offsetFrom: prevOffset, offsetTo: offset})
+
+		prevOffset = offset
+		prev = t
+	}
+	return transitions
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+// escapeText escapes a TEXT property value per RFC 5545 section 3.3.11.
+func escapeText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(s)
+}
+
+// writer emits CRLF-terminated content lines folded at 75 octets without splitting UTF-8 characters.
+type writer struct {
+	buf *bytes.Buffer
+}
+
+func (w *writer) line(s string) {
+	first := true
+	for len(s) > 0 {
+		limit := maxLineOctets
+		if !first {
+			// Continuation lines start with a space, which counts toward the limit
+			limit--
+		}
+		if len(s) <= limit {
+			if !first {
+				w.buf.WriteByte(' ')
+			}
+			w.buf.WriteString(s)
+			break
+		}
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if !first {
+			w.buf.WriteByte(' ')
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n")
+		s = s[cut:]
+		first = false
+	}
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,105 @@
+package ical
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"strings"
+	"time"
+)
+
+const uidDomain = "incidex"
+
+type FeedService struct{}
+
+func NewFeedService() *FeedService {
+	return &FeedService{}
+}
+
+// GenerateOnCallFeed renders on-call shifts as timed events in each schedule's timezone.
+// When teamFeed is true the summary names the on-call user instead of the schedule.
+func (s *FeedService) GenerateOnCallFeed(name string, shifts []domain.OnCallShift, schedules map[uint]*domain.OnCallSchedule, teamFeed bool) []byte {
+	cal := NewCalendar(name)
+
+	for _, shift := range shifts {
+		schedule, ok := schedules[shift.ScheduleID]
+		if !ok {
+			continue
+		}
+
+		userName := fmt.Sprintf("ユーザー #%d", shift.UserID)
+		if shift.User != nil {
+			userName = shift.User.Name
+		}
+
+		summary := fmt.Sprintf("オンコール: %s", schedule.Name)
+		if teamFeed {
+			summary = fmt.Sprintf("オンコール: %s", userName)
+		}
+
+		var description strings.Builder
+		fmt.Fprintf(&description, "スケジュール: %s\n担当者: %s\n", schedule.Name, userName)
+		if shift.Source == domain.OnCallSourceOverride {
+			description.WriteString("種別: オーバーライド")
+		} else {
+			description.WriteString("種別: ローテーション")
+		}
+
+		cal.AddEvent(Event{
+			UID:          shiftUID(shift),
+			Summary:      summary,
+			Description:  description.String(),
+			Start:        shift.Start,
+			End:          shift.End,
+			Location:     schedule.Location(),
+			LastModified: schedule.UpdatedAt,
+			Categories:   []string{"On-call"},
+		})
+	}
+
+	return cal.Bytes()
+}
+
+// GenerateActionItemFeed renders action item due dates as all-day events.
+// Due dates are interpreted as calendar dates in loc.
+func (s *FeedService) GenerateActionItemFeed(name string, items []*domain.ActionItem, loc *time.Location) []byte {
+	cal := NewCalendar(name)
+
+	for _, item := range items {
+		if item.DueDate == nil {
+			continue
+		}
+
+		var description strings.Builder
+		fmt.Fprintf(&description, "優先度: %s\nステータス: %s", item.Priority, item.Status)
+		if item.IncidentID != nil {
+			fmt.Fprintf(&description, "\nインシデント: #%d", *item.IncidentID)
+		}
+		if item.Description != "" {
+			description.WriteString("\n\n")
+			description.WriteString(item.Description)
+		}
+
+		cal.AddEvent(Event{
+			UID:          fmt.Sprintf("action-item-%d@%s", item.ID, uidDomain),
+			Summary:      fmt.Sprintf("[期限] %s", item.Title),
+			Description:  description.String(),
+			Start:        *item.DueDate,
+			AllDay:       true,
+			Location:     loc,
+			LastModified: item.UpdatedAt,
+			Categories:   []string{"Action item", string(item.Priority)},
+		})
+	}
+
+	return cal.Bytes()
+}
+
+// shiftUID identifies a shift by its schedule, origin and start, so the same shift keeps
+// its UID across feed refreshes and across the personal and team feeds. Layer shifts use the
+// layer's order rather than its ID, because updating a schedule recreates its layers.
+func shiftUID(shift domain.OnCallShift) string {
+	if shift.Source == domain.OnCallSourceOverride && shift.OverrideID != nil {
+		return fmt.Sprintf("oncall-%d-override-%d-%d@%s", shift.ScheduleID, *shift.OverrideID, shift.Start.Unix(), uidDomain)
+	}
+	return fmt.Sprintf("oncall-%d-layer-%d-%d@%s", shift.ScheduleID, shift.LayerOrder, shift.Start.Unix(), uidDomain)
+}
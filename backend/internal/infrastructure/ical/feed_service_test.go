@@ -0,0 +1,105 @@
+package ical
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// onCallSchedule returns a schedule with a weekly layer and a later daily layer over it, whose layer and participant IDs start at firstID,
+// as the repository recreates them on every update
+func onCallSchedule(firstID uint) *domain.OnCallSchedule {
+	layer := func(id uint, order int, rotation domain.RotationType, startDate string, userIDs ...uint) domain.OnCallLayer {
+		participants := make([]domain.OnCallParticipant, len(userIDs))
+		for i, userID := range userIDs {
+			participants[i] = domain.OnCallParticipant{ID: id*10 + uint(i), LayerID: id, UserID: userID, Position: i}
+		}
+		return domain.OnCallLayer{
+			ID:           id,
+			ScheduleID:   1,
+			LayerOrder:   order,
+			RotationType: rotation,
+			StartDate:    startDate,
+			HandoffTime:  "10:00",
+			Participants: participants,
+		}
+	}
+	return &domain.OnCallSchedule{
+		ID:       1,
+		Name:     "Platform",
+		Timezone: "Asia/Tokyo",
+		Layers: []domain.OnCallLayer{
+			layer(firstID, 1, domain.RotationWeekly, "2026-01-05", 5, 6),
+			layer(firstID+1, 2, domain.RotationDaily, "2026-01-20", 7),
+		},
+	}
+}
+
+// eventUIDs returns the UIDs of the events in a rendered feed
+func eventUIDs(feed []byte) []string {
+	var uids []string
+	for _, line := range strings.Split(string(feed), "\r\n") {
+		if strings.HasPrefix(line, "UID:") {
+			uids = append(uids, strings.TrimPrefix(line, "UID:"))
+		}
+	}
+	return uids
+}
+
+func TestOnCallFeedUIDsSurviveScheduleUpdate(t *testing.T) {
+	from := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	overrides := []domain.OnCallOverride{{
+		ID:         3,
+		ScheduleID: 1,
+		UserID:     8,
+		StartAt:    from.AddDate(0, 0, 3),
+		EndAt:      from.AddDate(0, 0, 4),
+	}}
+	feed := NewFeedService()
+
+	render := func(schedule *domain.OnCallSchedule) []string {
+		shifts := schedule.ShiftsBetween(from, to, overrides)
+		schedules := map[uint]*domain.OnCallSchedule{schedule.ID: schedule}
+		return eventUIDs(feed.GenerateOnCallFeed("Platform", shifts, schedules, true))
+	}
+
+	before := render(onCallSchedule(1))
+	// Updating the schedule deletes its layers and creates them again with new IDs
+	after := render(onCallSchedule(41))
+
+	if len(before) == 0 {
+		t.Fatal("feed has no events")
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("UIDs changed across a schedule update:\nbefore %v\nafter  %v", before, after)
+	}
+
+	seen := make(map[string]bool, len(before))
+	for _, uid := range before {
+		if seen[uid] {
+			t.Errorf("duplicate UID %s", uid)
+		}
+		seen[uid] = true
+	}
+	for _, prefix := range []string{"oncall-1-layer-1-", "oncall-1-layer-2-"} {
+		if !containsPrefix(before, prefix) {
+			t.Errorf("no shift of layer %s in UIDs %v", prefix, before)
+		}
+	}
+	if overrideUID := fmt.Sprintf("oncall-1-override-3-%d@incidex", overrides[0].StartAt.Unix()); !seen[overrideUID] {
+		t.Errorf("override shift missing from UIDs %v", before)
+	}
+}
+
+func containsPrefix(values []string, prefix string) bool {
+	for _, value := range values {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
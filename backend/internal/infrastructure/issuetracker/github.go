@@ -0,0 +1,126 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GitHubConfig configures the GitHub Issues adapter
+type GitHubConfig struct {
+	BaseURL       string // REST API root, e.g. https://api.github.com
+	Token         string
+	Repository    string // owner/repo
+	WebhookSecret string
+}
+
+// GitHubTracker opens action items as GitHub issues through the REST API
+type GitHubTracker struct {
+	cfg    GitHubConfig
+	client *http.Client
+}
+
+// NewGitHubTracker creates the GitHub adapter. It returns nil when no token or repository is configured.
+// A nil client uses a default client with a timeout.
+func NewGitHubTracker(cfg GitHubConfig, client *http.Client) *GitHubTracker {
+	if cfg.Token == "" || cfg.Repository == "" {
+		return nil
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.github.com"
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	return &GitHubTracker{cfg: cfg, client: client}
+}
+
+func (t *GitHubTracker) Kind() domain.IssueTrackerKind {
+	return domain.IssueTrackerGitHub
+}
+
+type gitHubIssueRequest struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+type gitHubIssue struct {
+	Number   int        `json:"number"`
+	HTMLURL  string     `json:"html_url"`
+	State    string     `json:"state"`
+	ClosedAt *time.Time `json:"closed_at"`
+}
+
+// CreateIssue opens an issue in the configured repository
+func (t *GitHubTracker) CreateIssue(ctx context.Context, item *domain.ActionItem) (*domain.ExternalIssue, error) {
+	payload := gitHubIssueRequest{
+		Title:  item.Title,
+		Body:   issueDescription(item),
+		Labels: []string{"incidex", "priority:" + string(item.Priority)},
+	}
+
+	var issue gitHubIssue
+	url := fmt.Sprintf("%s/repos/%s/issues", t.cfg.BaseURL, t.cfg.Repository)
+	if err := doJSON(ctx, t.client, http.MethodPost, url, payload, &issue, t.setHeaders); err != nil {
+		return nil, fmt.Errorf("github: %w", err)
+	}
+	if issue.Number == 0 {
+		return nil, fmt.Errorf("github: response has no issue number")
+	}
+
+	return &domain.ExternalIssue{
+		Key: gitHubIssueKey(t.cfg.Repository, issue.Number),
+		URL: issue.HTMLURL,
+	}, nil
+}
+
+func (t *GitHubTracker) setHeaders(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+t.cfg.Token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+}
+
+type gitHubWebhook struct {
+	Action     string      `json:"action"`
+	Issue      gitHubIssue `json:"issue"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParseWebhook reads "issues" events signed with X-Hub-Signature-256; only closed and reopened change the state
+func (t *GitHubTracker) ParseWebhook(header http.Header, body []byte) (*domain.IssueEvent, error) {
+	if err := verifySignature(t.cfg.WebhookSecret, header.Get("X-Hub-Signature-256"), body); err != nil {
+		return nil, err
+	}
+	if header.Get("X-GitHub-Event") != "issues" {
+		return nil, nil
+	}
+
+	var payload gitHubWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("github: invalid webhook payload: %w", err)
+	}
+	if payload.Action != "closed" && payload.Action != "reopened" {
+		return nil, nil
+	}
+
+	event := &domain.IssueEvent{
+		Key:    gitHubIssueKey(payload.Repository.FullName, payload.Issue.Number),
+		Closed: payload.Action == "closed",
+	}
+	if event.Closed {
+		event.ClosedAt = payload.Issue.ClosedAt
+	}
+	return event, nil
+}
+
+func gitHubIssueKey(repository string, number int) string {
+	return fmt.Sprintf("%s#%d", repository, number)
+}
@@ -0,0 +1,139 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"incidex/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitHubTrackerCreateIssue(t *testing.T) {
+	var received gitHubIssueRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/ops/issues" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"number": 42, "html_url": "https://github.com/acme/ops/issues/42", "state": "open"}`))
+	}))
+	defer server.Close()
+
+	tracker := NewGitHubTracker(GitHubConfig{BaseURL: server.URL + "/", Token: "secret-token", Repository: "acme/ops"}, server.Client())
+	issue, err := tracker.CreateIssue(context.Background(), &domain.ActionItem{
+		ID:       7,
+		Title:    "Add a connection pool limit",
+		Priority: domain.PriorityHigh,
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue returned error: %v", err)
+	}
+
+	if issue.Key != "acme/ops#42" || issue.URL != "https://github.com/acme/ops/issues/42" {
+		t.Errorf("issue = %+v", issue)
+	}
+	if received.Title != "Add a connection pool limit" {
+		t.Errorf("title = %q", received.Title)
+	}
+	if !strings.Contains(received.Body, "incidex action item #7") {
+		t.Errorf("body does not reference the action item: %q", received.Body)
+	}
+	if len(received.Labels) != 2 || received.Labels[1] != "priority:high" {
+		t.Errorf("labels = %v", received.Labels)
+	}
+}
+
+func TestGitHubTrackerCreateIssueError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Bad credentials"}`))
+	}))
+	defer server.Close()
+
+	tracker := NewGitHubTracker(GitHubConfig{BaseURL: server.URL, Token: "wrong", Repository: "acme/ops"}, server.Client())
+	if _, err := tracker.CreateIssue(context.Background(), &domain.ActionItem{ID: 1, Title: "x"}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("CreateIssue error = %v, want the 401 status", err)
+	}
+}
+
+func TestGitHubTrackerParseWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	tracker := NewGitHubTracker(GitHubConfig{Token: "token", Repository: "acme/ops", WebhookSecret: secret}, nil)
+
+	closed := []byte(`{"action": "closed", "issue": {"number": 42, "closed_at": "2025-03-01T10:00:00Z"}, "repository": {"full_name": "acme/ops"}}`)
+	reopened := []byte(`{"action": "reopened", "issue": {"number": 42}, "repository": {"full_name": "acme/ops"}}`)
+	labeled := []byte(`{"action": "labeled", "issue": {"number": 42}, "repository": {"full_name": "acme/ops"}}`)
+
+	tests := []struct {
+		name    string
+		event   string
+		body    []byte
+		sig     string
+		want    *domain.IssueEvent
+		wantErr error
+	}{
+		{
+			name:  "closed",
+			event: "issues",
+			body:  closed,
+			sig:   sign(secret, closed),
+			want:  &domain.IssueEvent{Key: "acme/ops#42", Closed: true, ClosedAt: timePtr(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:  "reopened",
+			event: "issues",
+			body:  reopened,
+			sig:   sign(secret, reopened),
+			want:  &domain.IssueEvent{Key: "acme/ops#42"},
+		},
+		{name: "other action", event: "issues", body: labeled, sig: sign(secret, labeled)},
+		{name: "other event", event: "push", body: closed, sig: sign(secret, closed)},
+		{name: "wrong signature", event: "issues", body: closed, sig: sign("other", closed), wantErr: ErrInvalidSignature},
+		{name: "unsigned", event: "issues", body: closed, wantErr: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := map[string]string{"X-GitHub-Event": tt.event}
+			if tt.sig != "" {
+				header["X-Hub-Signature-256"] = tt.sig
+			}
+			event, err := deliverWebhook(t, tracker, header, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			assertIssueEvent(t, event, tt.want)
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func assertIssueEvent(t *testing.T, got, want *domain.IssueEvent) {
+	t.Helper()
+	if got == nil || want == nil {
+		if got != want {
+			t.Fatalf("event = %+v, want %+v", got, want)
+		}
+		return
+	}
+	if got.Key != want.Key || got.Closed != want.Closed {
+		t.Fatalf("event = %+v, want %+v", got, want)
+	}
+	if (got.ClosedAt == nil) != (want.ClosedAt == nil) || (got.ClosedAt != nil && !got.ClosedAt.Equal(*want.ClosedAt)) {
+		t.Fatalf("closed at = %v, want %v", got.ClosedAt, want.ClosedAt)
+	}
+}
@@ -0,0 +1,147 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// JiraConfig configures the Jira adapter
+type JiraConfig struct {
+	BaseURL       string // site root, e.g. https://example.atlassian.net
+	Email         string
+	APIToken      string
+	ProjectKey    string
+	IssueType     string // default "Task"
+	WebhookSecret string
+}
+
+// JiraTracker opens action items as Jira issues through the REST API (v2)
+type JiraTracker struct {
+	cfg    JiraConfig
+	client *http.Client
+}
+
+// NewJiraTracker creates the Jira adapter. It returns nil when the site, credentials or project are not configured.
+// A nil client uses a default client with a timeout.
+func NewJiraTracker(cfg JiraConfig, client *http.Client) *JiraTracker {
+	if cfg.BaseURL == "" || cfg.Email == "" || cfg.APIToken == "" || cfg.ProjectKey == "" {
+		return nil
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.IssueType == "" {
+		cfg.IssueType = "Task"
+	}
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	return &JiraTracker{cfg: cfg, client: client}
+}
+
+func (t *JiraTracker) Kind() domain.IssueTrackerKind {
+	return domain.IssueTrackerJira
+}
+
+type jiraKeyRef struct {
+	Key string `json:"key"`
+}
+
+type jiraNameRef struct {
+	Name string `json:"name"`
+}
+
+type jiraIssueFields struct {
+	Project     jiraKeyRef  `json:"project"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	IssueType   jiraNameRef `json:"issuetype"`
+	DueDate     string      `json:"duedate,omitempty"`
+	Labels      []string    `json:"labels,omitempty"`
+}
+
+type jiraCreatedIssue struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// CreateIssue opens an issue in the configured project
+func (t *JiraTracker) CreateIssue(ctx context.Context, item *domain.ActionItem) (*domain.ExternalIssue, error) {
+	fields := jiraIssueFields{
+		Project:     jiraKeyRef{Key: t.cfg.ProjectKey},
+		Summary:     item.Title,
+		Description: issueDescription(item),
+		IssueType:   jiraNameRef{Name: t.cfg.IssueType},
+		Labels:      []string{"incidex"},
+	}
+	if item.DueDate != nil {
+		fields.DueDate = item.DueDate.Format("2006-01-02")
+	}
+
+	var issue jiraCreatedIssue
+	url := t.cfg.BaseURL + "/rest/api/2/issue"
+	payload := map[string]interface{}{"fields": fields}
+	if err := doJSON(ctx, t.client, http.MethodPost, url, payload, &issue, t.setHeaders); err != nil {
+		return nil, fmt.Errorf("jira: %w", err)
+	}
+	if issue.Key == "" {
+		return nil, fmt.Errorf("jira: response has no issue key")
+	}
+
+	return &domain.ExternalIssue{
+		Key: issue.Key,
+		URL: t.cfg.BaseURL + "/browse/" + issue.Key,
+	}, nil
+}
+
+func (t *JiraTracker) setHeaders(req *http.Request) {
+	req.SetBasicAuth(t.cfg.Email, t.cfg.APIToken)
+}
+
+type jiraWebhook struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Status struct {
+				StatusCategory struct {
+					Key string `json:"key"` // "new", "indeterminate", "done"
+				} `json:"statusCategory"`
+			} `json:"status"`
+			ResolutionDate string `json:"resolutiondate"`
+		} `json:"fields"`
+	} `json:"issue"`
+}
+
+// jiraTimeLayout is the timestamp format of Jira REST payloads
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// ParseWebhook reads issue update events signed with X-Hub-Signature.
+// The issue is closed when its status is in the "done" category.
+func (t *JiraTracker) ParseWebhook(header http.Header, body []byte) (*domain.IssueEvent, error) {
+	if err := verifySignature(t.cfg.WebhookSecret, header.Get("X-Hub-Signature"), body); err != nil {
+		return nil, err
+	}
+
+	var payload jiraWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("jira: invalid webhook payload: %w", err)
+	}
+	if payload.WebhookEvent != "jira:issue_updated" || payload.Issue.Key == "" {
+		return nil, nil
+	}
+
+	event := &domain.IssueEvent{
+		Key:    payload.Issue.Key,
+		Closed: payload.Issue.Fields.Status.StatusCategory.Key == "done",
+	}
+	if event.Closed && payload.Issue.Fields.ResolutionDate != "" {
+		if resolvedAt, err := time.Parse(jiraTimeLayout, payload.Issue.Fields.ResolutionDate); err == nil {
+			event.ClosedAt = &resolvedAt
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,115 @@
+package issuetracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"incidex/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJiraTrackerCreateIssue(t *testing.T) {
+	var received struct {
+		Fields jiraIssueFields `json:"fields"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if user, token, ok := r.BasicAuth(); !ok || user != "ops@example.com" || token != "api-token" {
+			t.Errorf("basic auth = %q, %q, %v", user, token, ok)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "10042", "key": "OPS-42"}`))
+	}))
+	defer server.Close()
+
+	tracker := NewJiraTracker(JiraConfig{BaseURL: server.URL, Email: "ops@example.com", APIToken: "api-token", ProjectKey: "OPS"}, server.Client())
+	dueDate := time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC)
+	issue, err := tracker.CreateIssue(context.Background(), &domain.ActionItem{
+		ID:       7,
+		Title:    "Add a connection pool limit",
+		Priority: domain.PriorityHigh,
+		DueDate:  &dueDate,
+	})
+	if err != nil {
+		t.Fatalf("CreateIssue returned error: %v", err)
+	}
+
+	if issue.Key != "OPS-42" || issue.URL != server.URL+"/browse/OPS-42" {
+		t.Errorf("issue = %+v", issue)
+	}
+	fields := received.Fields
+	if fields.Project.Key != "OPS" || fields.IssueType.Name != "Task" || fields.Summary != "Add a connection pool limit" {
+		t.Errorf("fields = %+v", fields)
+	}
+	if fields.DueDate != "2025-04-30" {
+		t.Errorf("due date = %q", fields.DueDate)
+	}
+	if !strings.Contains(fields.Description, "incidex action item #7") {
+		t.Errorf("description does not reference the action item: %q", fields.Description)
+	}
+}
+
+func TestJiraTrackerCreateIssueWithoutKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tracker := NewJiraTracker(JiraConfig{BaseURL: server.URL, Email: "ops@example.com", APIToken: "api-token", ProjectKey: "OPS"}, server.Client())
+	if _, err := tracker.CreateIssue(context.Background(), &domain.ActionItem{ID: 1, Title: "x"}); err == nil {
+		t.Fatal("CreateIssue succeeded without an issue key in the response")
+	}
+}
+
+func TestJiraTrackerParseWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	tracker := NewJiraTracker(JiraConfig{BaseURL: "https://example.atlassian.net", Email: "ops@example.com", APIToken: "api-token", ProjectKey: "OPS", WebhookSecret: secret}, nil)
+
+	done := []byte(`{"webhookEvent": "jira:issue_updated", "issue": {"key": "OPS-42", "fields": {"status": {"statusCategory": {"key": "done"}}, "resolutiondate": "2025-03-01T19:00:00.000+0900"}}}`)
+	inProgress := []byte(`{"webhookEvent": "jira:issue_updated", "issue": {"key": "OPS-42", "fields": {"status": {"statusCategory": {"key": "indeterminate"}}}}}`)
+	created := []byte(`{"webhookEvent": "jira:issue_created", "issue": {"key": "OPS-43", "fields": {"status": {"statusCategory": {"key": "new"}}}}}`)
+
+	tests := []struct {
+		name    string
+		body    []byte
+		sig     string
+		want    *domain.IssueEvent
+		wantErr error
+	}{
+		{
+			name: "done",
+			body: done,
+			sig:  sign(secret, done),
+			want: &domain.IssueEvent{Key: "OPS-42", Closed: true, ClosedAt: timePtr(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))},
+		},
+		{name: "reopened", body: inProgress, sig: sign(secret, inProgress), want: &domain.IssueEvent{Key: "OPS-42"}},
+		{name: "other event", body: created, sig: sign(secret, created)},
+		{name: "wrong signature", body: done, sig: sign("other", done), wantErr: ErrInvalidSignature},
+		{name: "unsigned", body: done, wantErr: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := map[string]string{}
+			if tt.sig != "" {
+				header["X-Hub-Signature"] = tt.sig
+			}
+			event, err := deliverWebhook(t, tracker, header, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			assertIssueEvent(t, event, tt.want)
+		})
+	}
+}
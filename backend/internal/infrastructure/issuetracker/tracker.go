@@ -0,0 +1,102 @@
+package issuetracker
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSignature is returned by ParseWebhook when the webhook is not signed with the configured secret
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// defaultHTTPClient is used by adapters created without their own client
+var defaultHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// Registry holds the configured issue trackers by kind
+type Registry map[domain.IssueTrackerKind]domain.IssueTracker
+
+// Register adds a tracker, replacing one of the same kind
+func (r Registry) Register(tracker domain.IssueTracker) {
+	r[tracker.Kind()] = tracker
+}
+
+// doJSON sends payload as JSON and decodes a 2xx JSON response into out
+func doJSON(ctx context.Context, client *http.Client, method, url string, payload, out interface{}, setHeaders func(*http.Request)) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	setHeaders(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// verifySignature checks a "sha256=<hex HMAC-SHA256 of the body>" signature header.
+// Webhooks are refused while no secret is configured.
+func verifySignature(secret, signature string, body []byte) error {
+	if secret == "" {
+		return fmt.Errorf("%w: no webhook secret is configured", ErrInvalidSignature)
+	}
+	sent, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil || !strings.HasPrefix(signature, "sha256=") {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(sent, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// issueDescription renders the issue body of an action item
+func issueDescription(item *domain.ActionItem) string {
+	var sb strings.Builder
+	if item.Description != "" {
+		sb.WriteString(item.Description)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("incidex action item #%d\n", item.ID))
+	sb.WriteString(fmt.Sprintf("Priority: %s\n", item.Priority))
+	if item.DueDate != nil {
+		sb.WriteString(fmt.Sprintf("Due: %s\n", item.DueDate.Format("2006-01-02")))
+	}
+	if item.IncidentID != nil {
+		sb.WriteString(fmt.Sprintf("Incident: #%d\n", *item.IncidentID))
+	}
+	return sb.String()
+}
@@ -0,0 +1,55 @@
+package issuetracker
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"incidex/internal/domain"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sign returns the "sha256=<hex>" signature of body for secret
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverWebhook posts body with the given headers to a test server that hands the request to tracker.ParseWebhook
+func deliverWebhook(t *testing.T, tracker domain.IssueTracker, header map[string]string, body []byte) (*domain.IssueEvent, error) {
+	t.Helper()
+
+	var (
+		event    *domain.IssueEvent
+		parseErr error
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read webhook body: %v", err)
+			return
+		}
+		event, parseErr = tracker.ParseWebhook(r.Header, received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to deliver webhook: %v", err)
+	}
+	resp.Body.Close()
+	return event, parseErr
+}
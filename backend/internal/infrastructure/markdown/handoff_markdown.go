@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"strings"
+	"time"
+)
+
+const timeLayout = "2006-01-02 15:04"
+
+type DocumentService struct{}
+
+func NewDocumentService() *DocumentService {
+	return &DocumentService{}
+}
+
+// GenerateHandoffReport renders a shift handoff report as Markdown, with times shown in loc
+func (s *DocumentService) GenerateHandoffReport(report *domain.HandoffReport, loc *time.Location) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# 引き継ぎレポート\n\n")
+	fmt.Fprintf(&b, "- 対象期間: %s 〜 %s (%s)\n", report.From.In(loc).Format(timeLayout), report.To.In(loc).Format(timeLayout), loc.String())
+	fmt.Fprintf(&b, "- 作成日時: %s\n\n", report.GeneratedAt.In(loc).Format(timeLayout))
+
+	fmt.Fprintf(&b, "| 新規 | 更新 | 解決 | 未解決 | SLA期限間近 |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d | %d |\n\n",
+		len(report.Opened), len(report.Changed), len(report.Resolved), len(report.StillOpen), len(report.UpcomingSLA))
+
+	writeSLASection(&b, report.UpcomingSLA, report.To, loc)
+	writeIncidentSection(&b, "未解決のインシデント", report.StillOpen, loc)
+	writeIncidentSection(&b, "新規インシデント", report.Opened, loc)
+	writeIncidentSection(&b, "更新されたインシデント", report.Changed, loc)
+	writeIncidentSection(&b, "解決したインシデント", report.Resolved, loc)
+	writeEventSection(&b, report.NotableEvents, loc)
+
+	return b.String()
+}
+
+func writeSLASection(b *strings.Builder, incidents []domain.HandoffIncident, handoffAt time.Time, loc *time.Location) {
+	fmt.Fprintf(b, "## SLA期限が近いインシデント\n\n")
+	if len(incidents) == 0 {
+		fmt.Fprintf(b, "なし\n\n")
+		return
+	}
+	fmt.Fprintf(b, "| ID | タイトル | 重要度 | 担当者 | SLA期限 | 残り時間 |\n")
+	fmt.Fprintf(b, "|---|---|---|---|---|---|\n")
+	for _, incident := range incidents {
+		remaining := incident.SLADeadline.Sub(handoffAt)
+		remainingText := fmt.Sprintf("%.1f時間", remaining.Hours())
+		if remaining < 0 {
+			remainingText = fmt.Sprintf("**超過 %.1f時間**", -remaining.Hours())
+		}
+		fmt.Fprintf(b, "| #%d | %s | %s | %s | %s | %s |\n",
+			incident.ID, escapeCell(incident.Title), incident.Severity, escapeCell(valueOrDash(incident.AssigneeName)),
+			incident.SLADeadline.In(loc).Format(timeLayout), remainingText)
+	}
+	b.WriteString("\n")
+}
+
+func writeIncidentSection(b *strings.Builder, title string, incidents []domain.HandoffIncident, loc *time.Location) {
+	fmt.Fprintf(b, "## %s\n\n", title)
+	if len(incidents) == 0 {
+		fmt.Fprintf(b, "なし\n\n")
+		return
+	}
+	for _, incident := range incidents {
+		fmt.Fprintf(b, "### #%d %s\n\n", incident.ID, incident.Title)
+		fmt.Fprintf(b, "- 重要度: %s / ステータス: %s\n", incident.Severity, incident.Status)
+		if incident.Service != "" {
+			fmt.Fprintf(b, "- サービス: %s\n", incident.Service)
+		}
+		fmt.Fprintf(b, "- 担当者: %s\n", valueOrDash(incident.AssigneeName))
+		fmt.Fprintf(b, "- 検知: %s\n", incident.DetectedAt.In(loc).Format(timeLayout))
+		if incident.ResolvedAt != nil {
+			fmt.Fprintf(b, "- 解決: %s\n", incident.ResolvedAt.In(loc).Format(timeLayout))
+		}
+		if incident.SLADeadline != nil {
+			violated := ""
+			if incident.SLAViolated {
+				violated = "（違反）"
+			}
+			fmt.Fprintf(b, "- SLA期限: %s%s\n", incident.SLADeadline.In(loc).Format(timeLayout), violated)
+		}
+		if len(incident.Changes) > 0 {
+			fmt.Fprintf(b, "- 期間中の変更:\n")
+			for _, change := range incident.Changes {
+				fmt.Fprintf(b, "  - %s\n", change)
+			}
+		}
+		b.WriteString("\n")
+	}
+}
+
+func writeEventSection(b *strings.Builder, events []domain.HandoffEvent, loc *time.Location) {
+	fmt.Fprintf(b, "## コメント・タイムライン\n\n")
+	if len(events) == 0 {
+		fmt.Fprintf(b, "なし\n")
+		return
+	}
+	for _, event := range events {
+		comment := strings.ReplaceAll(strings.TrimSpace(event.Comment), "\n", " ")
+		fmt.Fprintf(b, "- %s [#%d %s] %s (%s): %s\n",
+			event.At.In(loc).Format(timeLayout), event.IncidentID, event.IncidentTitle,
+			event.ActivityType, valueOrDash(event.UserName), comment)
+	}
+}
+
+// escapeCell keeps table cells on one line and prevents pipes from splitting columns
+func escapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
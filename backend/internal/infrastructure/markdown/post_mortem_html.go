@@ -0,0 +1,254 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"incidex/internal/domain"
+	"strings"
+	"time"
+)
+
+// postMortemHTMLView is the data of the standalone HTML post-mortem
+type postMortemHTMLView struct {
+	Title        string
+	VersionLabel string
+	Status       domain.PMStatus
+	Author       string
+	CreatedAt    string
+	PublishedAt  string
+	Summary      [][2]string
+	Description  string
+	Timeline     []htmlTimelineEvent
+	Sections     []htmlSection
+	ActionItems  []htmlActionItem
+	GeneratedAt  string
+}
+
+type htmlTimelineEvent struct {
+	At      string
+	Type    domain.ActivityType
+	Comment string
+	User    string
+}
+
+// htmlSection is one section of the document; exactly one of the content fields is set
+type htmlSection struct {
+	Title     string
+	Text      string
+	Checklist []domain.PostMortemChecklistItem
+	Columns   []string
+	Rows      [][]string
+	Whys      []string
+
+	// Causal analysis
+	CausalNodes  []htmlCausalNode
+	RootCauses   int
+	Contributing int
+}
+
+type htmlCausalNode struct {
+	Indent      int
+	Category    string
+	Description string
+	IsRootCause bool
+}
+
+type htmlActionItem struct {
+	Title    string
+	Owner    string
+	Status   domain.ActionStatus
+	Priority domain.Priority
+	DueDate  string
+}
+
+var postMortemHTMLTemplate = template.Must(template.New("post-mortem").Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", "Hiragino Sans", "Noto Sans JP", sans-serif; color: #1f2937; max-width: 960px; margin: 2rem auto; padding: 0 1rem; line-height: 1.6; }
+h1 { color: #1e3a8a; border-bottom: 2px solid #1e3a8a; padding-bottom: .3rem; }
+h2 { color: #1e3a8a; border-bottom: 1px solid #e5e7eb; padding-bottom: .2rem; margin-top: 2rem; }
+.meta { color: #6b7280; font-size: .9rem; }
+.version { font-weight: bold; color: #1e3a8a; }
+table { border-collapse: collapse; width: 100%; margin: .5rem 0; }
+th, td { border: 1px solid #d1d5db; padding: .35rem .6rem; text-align: left; vertical-align: top; }
+th { background: #f3f4f6; }
+.text { white-space: pre-wrap; }
+ul.checklist { list-style: none; padding-left: 0; }
+.root-cause { color: #b91c1c; font-weight: bold; }
+footer { margin-top: 3rem; color: #9ca3af; font-size: .8rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="version">バージョン: {{.VersionLabel}}</p>
+<p class="meta">ステータス: {{.Status}} / 作成者: {{.Author}} / 作成日: {{.CreatedAt}}{{if .PublishedAt}} / 公開日: {{.PublishedAt}}{{end}}</p>
+{{if .Summary}}
+<h2>インシデント概要</h2>
+<table>
+{{range .Summary}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{if .Description}}<h2>インシデントの説明</h2>
+<div class="text">{{.Description}}</div>{{end}}
+{{end}}
+{{if .Timeline}}
+<h2>タイムライン</h2>
+<table>
+<tr><th>日時</th><th>種別</th><th>内容</th><th>記録者</th></tr>
+{{range .Timeline}}<tr><td>{{.At}}</td><td>{{.Type}}</td><td class="text">{{.Comment}}</td><td>{{.User}}</td></tr>
+{{end}}</table>
+{{end}}
+{{range .Sections}}
+<h2>{{.Title}}</h2>
+{{if .Text}}<div class="text">{{.Text}}</div>{{end}}
+{{if .Checklist}}<ul class="checklist">
+{{range .Checklist}}<li>{{if .Checked}}&#9745;{{else}}&#9744;{{end}} {{.Text}}</li>
+{{end}}</ul>{{end}}
+{{if .Columns}}<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td class="text">{{.}}</td>{{end}}</tr>
+{{end}}</table>{{end}}
+{{if .Whys}}<ol>
+{{range .Whys}}<li>なぜ: {{.}}</li>
+{{end}}</ol>{{end}}
+{{if .CausalNodes}}<p class="meta">根本原因: {{.RootCauses}}件 / 寄与要因: {{.Contributing}}件</p>
+<ul>
+{{range .CausalNodes}}<li style="margin-left: {{.Indent}}rem"{{if .IsRootCause}} class="root-cause"{{end}}>[{{.Category}}] {{.Description}}{{if .IsRootCause}}（根本原因）{{end}}</li>
+{{end}}</ul>{{end}}
+{{end}}
+{{if .ActionItems}}
+<h2>アクションアイテム</h2>
+<table>
+<tr><th>タイトル</th><th>担当者</th><th>ステータス</th><th>優先度</th><th>期限</th></tr>
+{{range .ActionItems}}<tr><td>{{.Title}}</td><td>{{.Owner}}</td><td>{{.Status}}</td><td>{{.Priority}}</td><td>{{.DueDate}}</td></tr>
+{{end}}</table>
+{{end}}
+<footer>Incidex で {{.GeneratedAt}} に出力</footer>
+</body>
+</html>
+`))
+
+// GeneratePostMortemHTML renders a post-mortem as a standalone HTML page (no external assets),
+// with the same content as the Markdown report and times shown in loc.
+func (s *DocumentService) GeneratePostMortemHTML(doc *domain.PostMortemDocument, loc *time.Location) ([]byte, error) {
+	pm := doc.PostMortem
+	incident := postMortemIncident(doc)
+
+	view := postMortemHTMLView{
+		Title:        "ポストモーテム",
+		VersionLabel: postMortemVersionLabel(doc),
+		Status:       pm.Status,
+		Author:       "-",
+		CreatedAt:    pm.CreatedAt.In(loc).Format(dateLayout),
+		GeneratedAt:  time.Now().In(loc).Format(timeLayout),
+	}
+	if pm.Author != nil {
+		view.Author = pm.Author.Name
+	}
+	if pm.PublishedAt != nil {
+		view.PublishedAt = pm.PublishedAt.In(loc).Format(dateLayout)
+	}
+	if incident != nil {
+		view.Title = fmt.Sprintf("ポストモーテム: #%d %s", incident.ID, incident.Title)
+		view.Summary = incidentSummaryRows(incident, loc)
+		view.Description = strings.TrimSpace(incident.Description)
+	}
+
+	for _, event := range doc.Timeline {
+		user := "-"
+		if event.User != nil {
+			user = event.User.Name
+		}
+		view.Timeline = append(view.Timeline, htmlTimelineEvent{
+			At:      event.CreatedAt.In(loc).Format(timeLayout),
+			Type:    event.ActivityType,
+			Comment: event.Comment,
+			User:    user,
+		})
+	}
+
+	view.Sections = postMortemHTMLSections(pm, doc.CausalTree)
+
+	for _, item := range pm.ActionItems {
+		view.ActionItems = append(view.ActionItems, htmlActionItem{
+			Title:    item.Title,
+			Owner:    actionItemOwner(item),
+			Status:   item.Status,
+			Priority: item.Priority,
+			DueDate:  actionItemDueDate(item, loc),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := postMortemHTMLTemplate.Execute(&buf, view); err != nil {
+		return nil, fmt.Errorf("failed to render post-mortem HTML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// postMortemHTMLSections lists the written sections in the same order as the Markdown report.
+// Action items are rendered separately.
+func postMortemHTMLSections(pm *domain.PostMortem, tree *domain.CausalTree) []htmlSection {
+	var sections []htmlSection
+	addText := func(title, body string) {
+		if strings.TrimSpace(body) != "" {
+			sections = append(sections, htmlSection{Title: title, Text: strings.TrimSpace(body)})
+		}
+	}
+
+	addText("根本原因", pm.RootCause)
+	addText("影響分析", pm.ImpactAnalysis)
+	for _, section := range pm.Sections {
+		if section.IsEmpty() {
+			continue
+		}
+		switch section.Type {
+		case domain.SectionTypeChecklist:
+			sections = append(sections, htmlSection{Title: section.Title, Checklist: section.Checklist})
+		case domain.SectionTypeTable:
+			rows := make([][]string, len(section.Rows))
+			for i, row := range section.Rows {
+				rows[i] = make([]string, len(section.Columns))
+				copy(rows[i], row)
+			}
+			sections = append(sections, htmlSection{Title: section.Title, Columns: section.Columns, Rows: rows})
+		case domain.SectionTypeFiveWhys:
+			sections = append(sections, htmlSection{Title: section.Title, Whys: nonEmpty(section.Whys)})
+		default:
+			addText(section.Title, section.Text)
+		}
+	}
+	if whys := domain.ParseFiveWhys(pm.FiveWhysAnalysis); len(whys) > 0 {
+		sections = append(sections, htmlSection{Title: "なぜなぜ分析", Whys: whys})
+	}
+	if tree != nil && len(tree.Nodes) > 0 {
+		causal := htmlSection{Title: "原因分析", RootCauses: tree.RootCauses, Contributing: tree.Contributing}
+		tree.Walk(func(node *domain.CausalTreeNode, depth int) {
+			causal.CausalNodes = append(causal.CausalNodes, htmlCausalNode{
+				Indent:      depth * 2,
+				Category:    causalCategoryLabels[node.Category],
+				Description: node.Description,
+				IsRootCause: node.IsRootCause,
+			})
+		})
+		sections = append(sections, causal)
+	}
+	addText("うまくいったこと", pm.WhatWentWell)
+	addText("うまくいかなかったこと", pm.WhatWentWrong)
+	addText("学んだこと", pm.LessonsLearned)
+	return sections
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,266 @@
+package markdown
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Labels of the causal categories in the Markdown report
+var causalCategoryLabels = map[domain.CausalCategory]string{
+	domain.CausalCategoryPeople:     "人",
+	domain.CausalCategoryProcess:    "プロセス",
+	domain.CausalCategoryTechnology: "技術",
+	domain.CausalCategoryExternal:   "外部要因",
+}
+
+// GeneratePostMortemReport renders a post-mortem (current content or a published version) as Markdown,
+// with times shown in loc. The post-mortem must have Author loaded.
+func (s *DocumentService) GeneratePostMortemReport(doc *domain.PostMortemDocument, loc *time.Location) string {
+	var b strings.Builder
+	pm := doc.PostMortem
+	incident := postMortemIncident(doc)
+
+	fmt.Fprintf(&b, "# ポストモーテム")
+	if incident != nil {
+		fmt.Fprintf(&b, ": #%d %s", incident.ID, incident.Title)
+	}
+	b.WriteString("\n\n")
+
+	author := "-"
+	if pm.Author != nil {
+		author = pm.Author.Name
+	}
+	fmt.Fprintf(&b, "- バージョン: %s\n", postMortemVersionLabel(doc))
+	fmt.Fprintf(&b, "- ステータス: %s\n", pm.Status)
+	fmt.Fprintf(&b, "- 作成者: %s\n", author)
+	fmt.Fprintf(&b, "- 作成日: %s\n", pm.CreatedAt.In(loc).Format(dateLayout))
+	if pm.PublishedAt != nil {
+		fmt.Fprintf(&b, "- 公開日: %s\n", pm.PublishedAt.In(loc).Format(dateLayout))
+	}
+	b.WriteString("\n")
+
+	writeIncidentSummary(&b, incident, loc)
+	writeTimeline(&b, doc.Timeline, loc)
+	writeTextSection(&b, "根本原因", pm.RootCause)
+	writeTextSection(&b, "影響分析", pm.ImpactAnalysis)
+	for _, section := range pm.Sections {
+		writeTemplateSection(&b, section)
+	}
+	writeFiveWhys(&b, domain.ParseFiveWhys(pm.FiveWhysAnalysis))
+	writeCausalTree(&b, doc.CausalTree)
+	writeTextSection(&b, "うまくいったこと", pm.WhatWentWell)
+	writeTextSection(&b, "うまくいかなかったこと", pm.WhatWentWrong)
+	writeTextSection(&b, "学んだこと", pm.LessonsLearned)
+	writeActionItems(&b, pm.ActionItems, loc)
+
+	return b.String()
+}
+
+// postMortemIncident returns the fully loaded incident of the document, falling back to the post-mortem's relation
+func postMortemIncident(doc *domain.PostMortemDocument) *domain.Incident {
+	if doc.Incident != nil {
+		return doc.Incident
+	}
+	return doc.PostMortem.Incident
+}
+
+// postMortemVersionLabel states which version of the post-mortem the document shows
+func postMortemVersionLabel(doc *domain.PostMortemDocument) string {
+	switch {
+	case doc.IsSnapshot && doc.Version < doc.LatestVersion:
+		return fmt.Sprintf("%d（最新はバージョン %d）", doc.Version, doc.LatestVersion)
+	case doc.Version > 0:
+		return fmt.Sprintf("%d（最新の公開版）", doc.Version)
+	case doc.LatestVersion > 0:
+		return fmt.Sprintf("作業中（バージョン %d 以降の未公開の変更を含む）", doc.LatestVersion)
+	}
+	return "下書き（未公開）"
+}
+
+func writeIncidentSummary(b *strings.Builder, incident *domain.Incident, loc *time.Location) {
+	if incident == nil {
+		return
+	}
+	fmt.Fprintf(b, "## インシデント概要\n\n")
+	fmt.Fprintf(b, "| 項目 | 内容 |\n")
+	fmt.Fprintf(b, "|---|---|\n")
+	for _, row := range incidentSummaryRows(incident, loc) {
+		fmt.Fprintf(b, "| %s | %s |\n", row[0], escapeCell(row[1]))
+	}
+	b.WriteString("\n")
+	writeTextSection(b, "インシデントの説明", incident.Description)
+}
+
+// incidentSummaryRows lists the incident header as label/value pairs
+func incidentSummaryRows(incident *domain.Incident, loc *time.Location) [][2]string {
+	assignee := "-"
+	if incident.Assignee != nil {
+		assignee = incident.Assignee.Name
+	}
+	tags := make([]string, 0, len(incident.Tags))
+	for _, tag := range incident.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	rows := [][2]string{
+		{"重要度", string(incident.Severity)},
+		{"ステータス", string(incident.Status)},
+		{"サービス", valueOrDash(incident.Service)},
+		{"影響範囲", valueOrDash(incident.ImpactScope)},
+		{"担当者", assignee},
+		{"タグ", valueOrDash(strings.Join(tags, ", "))},
+		{"検知", incident.DetectedAt.In(loc).Format(timeLayout)},
+	}
+	if incident.ResolvedAt != nil {
+		rows = append(rows,
+			[2]string{"解決", incident.ResolvedAt.In(loc).Format(timeLayout)},
+			[2]string{"解決までの時間", formatDuration(incident.ResolvedAt.Sub(incident.DetectedAt))},
+		)
+	}
+	return rows
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%d分", minutes)
+	}
+	return fmt.Sprintf("%d時間%d分", hours, minutes)
+}
+
+func writeTimeline(b *strings.Builder, timeline []*domain.IncidentActivity, loc *time.Location) {
+	if len(timeline) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## タイムライン\n\n")
+	fmt.Fprintf(b, "| 日時 | 種別 | 内容 | 記録者 |\n")
+	fmt.Fprintf(b, "|---|---|---|---|\n")
+	for _, event := range timeline {
+		user := "-"
+		if event.User != nil {
+			user = event.User.Name
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n",
+			event.CreatedAt.In(loc).Format(timeLayout), event.ActivityType, escapeCell(valueOrDash(event.Comment)), escapeCell(user))
+	}
+	b.WriteString("\n")
+}
+
+func writeTextSection(b *strings.Builder, title, body string) {
+	if strings.TrimSpace(body) == "" {
+		return
+	}
+	fmt.Fprintf(b, "## %s\n\n%s\n\n", title, strings.TrimSpace(body))
+}
+
+// writeTemplateSection renders a template section according to its type
+func writeTemplateSection(b *strings.Builder, section domain.PostMortemSection) {
+	if section.IsEmpty() {
+		return
+	}
+	switch section.Type {
+	case domain.SectionTypeChecklist:
+		fmt.Fprintf(b, "## %s\n\n", section.Title)
+		for _, item := range section.Checklist {
+			mark := " "
+			if item.Checked {
+				mark = "x"
+			}
+			fmt.Fprintf(b, "- [%s] %s\n", mark, item.Text)
+		}
+		b.WriteString("\n")
+	case domain.SectionTypeTable:
+		fmt.Fprintf(b, "## %s\n\n", section.Title)
+		columns := make([]string, len(section.Columns))
+		for i, column := range section.Columns {
+			columns[i] = escapeCell(column)
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(columns, " | "))
+		fmt.Fprintf(b, "|%s\n", strings.Repeat("---|", len(columns)))
+		for _, row := range section.Rows {
+			cells := make([]string, len(columns))
+			for i := range cells {
+				if i < len(row) {
+					cells[i] = escapeCell(row[i])
+				}
+			}
+			fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+		}
+		b.WriteString("\n")
+	case domain.SectionTypeFiveWhys:
+		fmt.Fprintf(b, "## %s\n\n", section.Title)
+		writeWhys(b, section.Whys)
+	default:
+		writeTextSection(b, section.Title, section.Text)
+	}
+}
+
+func writeFiveWhys(b *strings.Builder, whys []string) {
+	if len(whys) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## なぜなぜ分析\n\n")
+	writeWhys(b, whys)
+}
+
+func writeWhys(b *strings.Builder, whys []string) {
+	n := 0
+	for _, why := range whys {
+		if strings.TrimSpace(why) == "" {
+			continue
+		}
+		n++
+		fmt.Fprintf(b, "%d. なぜ: %s\n", n, strings.ReplaceAll(strings.TrimSpace(why), "\n", " "))
+	}
+	b.WriteString("\n")
+}
+
+func writeCausalTree(b *strings.Builder, tree *domain.CausalTree) {
+	if tree == nil || len(tree.Nodes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## 原因分析\n\n")
+	fmt.Fprintf(b, "根本原因: %d件 / 寄与要因: %d件\n\n", tree.RootCauses, tree.Contributing)
+	tree.Walk(func(node *domain.CausalTreeNode, depth int) {
+		description := strings.ReplaceAll(node.Description, "\n", " ")
+		if node.IsRootCause {
+			description = fmt.Sprintf("**%s**（根本原因）", description)
+		}
+		fmt.Fprintf(b, "%s- [%s] %s\n", strings.Repeat("  ", depth), causalCategoryLabels[node.Category], description)
+	})
+	b.WriteString("\n")
+}
+
+func writeActionItems(b *strings.Builder, items []domain.ActionItem, loc *time.Location) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "## アクションアイテム\n\n")
+	fmt.Fprintf(b, "| タイトル | 担当者 | ステータス | 優先度 | 期限 |\n")
+	fmt.Fprintf(b, "|---|---|---|---|---|\n")
+	for _, item := range items {
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s |\n",
+			escapeCell(item.Title), escapeCell(actionItemOwner(item)), item.Status, item.Priority, actionItemDueDate(item, loc))
+	}
+	b.WriteString("\n")
+}
+
+func actionItemOwner(item domain.ActionItem) string {
+	if item.Assignee != nil && item.Assignee.Name != "" {
+		return item.Assignee.Name
+	}
+	return "-"
+}
+
+func actionItemDueDate(item domain.ActionItem, loc *time.Location) string {
+	if item.DueDate == nil {
+		return "-"
+	}
+	return item.DueDate.In(loc).Format(dateLayout)
+}
@@ -0,0 +1,84 @@
+package notification
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// chatCard は SlackMessage をSlack以外のチャット向けに分解した内容です
+type chatCard struct {
+	Title    string   // plain text summary
+	Sections []string // Markdown
+	Fields   []chatField
+	Color    string // "#RRGGBB" or empty
+	Footer   string
+}
+
+type chatField struct {
+	Name  string
+	Value string // Markdown
+}
+
+func newChatCard(message SlackMessage) chatCard {
+	card := chatCard{Title: message.Text}
+	for _, block := range message.Blocks {
+		if block.Text != nil {
+			card.Sections = append(card.Sections, slackToMarkdown(block.Text.Text))
+		}
+		for _, field := range block.Fields {
+			card.Fields = append(card.Fields, splitSlackField(field.Text))
+		}
+	}
+	for _, attachment := range message.Attachments {
+		if card.Color == "" {
+			card.Color = attachment.Color
+		}
+		if card.Footer == "" {
+			card.Footer = attachment.Footer
+		}
+		if attachment.Text != "" {
+			card.Sections = append(card.Sections, slackToMarkdown(attachment.Text))
+		}
+	}
+	return card
+}
+
+var (
+	slackLinkPattern = regexp.MustCompile(`<([^|>]+)\|([^>]+)>`)
+	slackBoldPattern = regexp.MustCompile(`\*([^*\n]+)\*`)
+)
+
+// slackToMarkdown converts the links (<url|text>) and bold text (*text*) of Slack mrkdwn to Markdown
+func slackToMarkdown(text string) string {
+	text = slackLinkPattern.ReplaceAllString(text, "[$2]($1)")
+	return slackBoldPattern.ReplaceAllString(text, "**$1**")
+}
+
+// splitSlackField splits a "*label:*\nvalue" field into its label and value
+func splitSlackField(text string) chatField {
+	label, value, found := strings.Cut(text, "\n")
+	if !found {
+		return chatField{Value: slackToMarkdown(text)}
+	}
+	label = strings.TrimSuffix(strings.Trim(label, "*"), ":")
+	return chatField{Name: label, Value: slackToMarkdown(value)}
+}
+
+// colorValue returns a "#RRGGBB" color as an integer (0 when it is not one)
+func colorValue(color string) int {
+	value, err := strconv.ParseUint(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(value)
+}
+
+// truncate shortens text to at most max characters
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-1]) + "…"
+}
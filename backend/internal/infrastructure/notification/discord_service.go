@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"strings"
+)
+
+// Limits of Discord embeds
+const (
+	discordTitleMax       = 256
+	discordDescriptionMax = 4096
+	discordFieldsMax      = 25
+	discordFieldNameMax   = 256
+	discordFieldValueMax  = 1024
+)
+
+// DiscordService はDiscordのWebhookに埋め込み（embed）で通知する Notifier です
+type DiscordService struct {
+	client *http.Client
+}
+
+// NewDiscordService は新しいDiscordサービスを作成します
+func NewDiscordService() *DiscordService {
+	return &DiscordService{client: &http.Client{Timeout: webhookTimeout}}
+}
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color,omitempty"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+func (s *DiscordService) Channel() domain.NotificationChannel {
+	return domain.ChannelDiscord
+}
+
+// Render はチャットメッセージをDiscordの埋め込みに変換します
+func (s *DiscordService) Render(message *Message) (string, string, error) {
+	if message.Chat.Text == "" {
+		return "", "", errNoContent
+	}
+	card := newChatCard(message.Chat)
+
+	embed := discordEmbed{
+		Title:       truncate(card.Title, discordTitleMax),
+		Description: truncate(strings.Join(card.Sections, "\n\n"), discordDescriptionMax),
+		Color:       colorValue(card.Color),
+	}
+	for i, field := range card.Fields {
+		if i == discordFieldsMax {
+			break
+		}
+		name := field.Name
+		if name == "" {
+			// Discord requires a name
+			name = "​"
+		}
+		embed.Fields = append(embed.Fields, discordField{
+			Name:   truncate(name, discordFieldNameMax),
+			Value:  truncate(field.Value, discordFieldValueMax),
+			Inline: true,
+		})
+	}
+	if card.Footer != "" {
+		embed.Footer = &discordFooter{Text: card.Footer}
+	}
+
+	payload, err := json.Marshal(discordPayload{Embeds: []discordEmbed{embed}})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal discord message: %w", err)
+	}
+	return card.Title, string(payload), nil
+}
+
+// Deliver はDiscordのWebhookにメッセージを送信します
+func (s *DiscordService) Deliver(ctx context.Context, webhookURL, subject, payload string) error {
+	return postWebhook(ctx, s.client, domain.ChannelDiscord, webhookURL, payload)
+}
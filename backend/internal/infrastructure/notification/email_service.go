@@ -1,12 +1,17 @@
 package notification
 
 import (
+	"context"
 	"fmt"
+	"html"
+	"incidex/internal/domain"
 	"net/smtp"
 	"os"
+	"strings"
+	"time"
 )
 
-// EmailService はEmail通知を送信するサービス
+// EmailService はSMTPでメール通知を送信する Notifier です
 type EmailService struct {
 	smtpHost     string
 	smtpPort     string
@@ -26,8 +31,20 @@ func NewEmailService() *EmailService {
 	}
 }
 
-// SendEmail はメールを送信します
-func (s *EmailService) SendEmail(to, subject, body string) error {
+func (s *EmailService) Channel() domain.NotificationChannel {
+	return domain.ChannelEmail
+}
+
+// Render はメールの件名とHTML本文を返します
+func (s *EmailService) Render(message *Message) (string, string, error) {
+	if message.Email.Subject == "" {
+		return "", "", errNoContent
+	}
+	return message.Email.Subject, message.Email.HTML, nil
+}
+
+// Deliver はSMTPでメールを送信します
+func (s *EmailService) Deliver(ctx context.Context, to, subject, body string) error {
 	if s.smtpUsername == "" || s.smtpPassword == "" {
 		// SMTP設定がない場合はログのみ出力（開発環境用）
 		fmt.Printf("[EMAIL] To: %s, Subject: %s\n%s\n", to, subject, body)
@@ -51,8 +68,8 @@ func (s *EmailService) SendEmail(to, subject, body string) error {
 	return smtp.SendMail(addr, auth, s.fromAddress, []string{to}, msg)
 }
 
-// SendIncidentCreatedEmail はインシデント作成通知を送信します
-func (s *EmailService) SendIncidentCreatedEmail(to, incidentTitle string, incidentID uint, severity string) error {
+// incidentCreatedEmail はインシデント作成通知のメールを作成します
+func incidentCreatedEmail(incidentTitle string, incidentID uint, severity string) EmailContent {
 	subject := fmt.Sprintf("[Incidex] 新しいインシデントが作成されました: %s", incidentTitle)
 
 	body := fmt.Sprintf(`
@@ -67,11 +84,11 @@ func (s *EmailService) SendIncidentCreatedEmail(to, incidentTitle string, incide
 		</html>
 	`, incidentTitle, severity, incidentID, incidentID)
 
-	return s.SendEmail(to, subject, body)
+	return EmailContent{Subject: subject, HTML: body}
 }
 
-// SendAssignedEmail は担当者割り当て通知を送信します
-func (s *EmailService) SendAssignedEmail(to, incidentTitle string, incidentID uint, assignedBy string) error {
+// assignedEmail は担当者割り当て通知のメールを作成します
+func assignedEmail(incidentTitle string, incidentID uint, assignedBy string) EmailContent {
 	subject := fmt.Sprintf("[Incidex] インシデントが割り当てられました: %s", incidentTitle)
 
 	body := fmt.Sprintf(`
@@ -86,11 +103,11 @@ func (s *EmailService) SendAssignedEmail(to, incidentTitle string, incidentID ui
 		</html>
 	`, incidentTitle, assignedBy, incidentID, incidentID)
 
-	return s.SendEmail(to, subject, body)
+	return EmailContent{Subject: subject, HTML: body}
 }
 
-// SendCommentEmail はコメント追加通知を送信します
-func (s *EmailService) SendCommentEmail(to, incidentTitle string, incidentID uint, commenterName, comment string) error {
+// commentEmail はコメント追加通知のメールを作成します
+func commentEmail(incidentTitle string, incidentID uint, commenterName, comment string) EmailContent {
 	subject := fmt.Sprintf("[Incidex] 新しいコメント: %s", incidentTitle)
 
 	body := fmt.Sprintf(`
@@ -106,11 +123,11 @@ func (s *EmailService) SendCommentEmail(to, incidentTitle string, incidentID uin
 		</html>
 	`, incidentTitle, commenterName, comment, incidentID)
 
-	return s.SendEmail(to, subject, body)
+	return EmailContent{Subject: subject, HTML: body}
 }
 
-// SendStatusChangeEmail はステータス変更通知を送信します
-func (s *EmailService) SendStatusChangeEmail(to, incidentTitle string, incidentID uint, oldStatus, newStatus string) error {
+// statusChangeEmail はステータス変更通知のメールを作成します
+func statusChangeEmail(incidentTitle string, incidentID uint, oldStatus, newStatus string) EmailContent {
 	subject := fmt.Sprintf("[Incidex] ステータス変更: %s", incidentTitle)
 
 	body := fmt.Sprintf(`
@@ -124,11 +141,11 @@ func (s *EmailService) SendStatusChangeEmail(to, incidentTitle string, incidentI
 		</html>
 	`, incidentTitle, oldStatus, newStatus, incidentID)
 
-	return s.SendEmail(to, subject, body)
+	return EmailContent{Subject: subject, HTML: body}
 }
 
-// SendResolvedEmail はインシデント解決通知を送信します
-func (s *EmailService) SendResolvedEmail(to, incidentTitle string, incidentID uint, resolvedBy string) error {
+// resolvedEmail はインシデント解決通知のメールを作成します
+func resolvedEmail(incidentTitle string, incidentID uint, resolvedBy string) EmailContent {
 	subject := fmt.Sprintf("[Incidex] インシデントが解決されました: %s", incidentTitle)
 
 	body := fmt.Sprintf(`
@@ -142,7 +159,149 @@ func (s *EmailService) SendResolvedEmail(to, incidentTitle string, incidentID ui
 		</html>
 	`, incidentTitle, resolvedBy, incidentID)
 
-	return s.SendEmail(to, subject, body)
+	return EmailContent{Subject: subject, HTML: body}
+}
+
+// escalationEmail はエスカレーション通知のメールを作成します
+func escalationEmail(incidentTitle string, incidentID uint, severity string, level int) EmailContent {
+	subject := fmt.Sprintf("[Incidex] エスカレーション (レベル%d): %s", level, incidentTitle)
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>インシデントがエスカレーションされました</h2>
+			<p><strong>タイトル:</strong> %s</p>
+			<p><strong>重要度:</strong> %s</p>
+			<p><strong>エスカレーションレベル:</strong> %d</p>
+			<p><strong>インシデントID:</strong> #%d</p>
+			<p>対応可能な場合はインシデントを受諾してください。</p>
+			<p><a href="http://localhost:3000/incidents/%d">詳細を見る</a></p>
+		</body>
+		</html>
+	`, incidentTitle, severity, level, incidentID, incidentID)
+
+	return EmailContent{Subject: subject, HTML: body}
+}
+
+// handoffReportEmail は引き継ぎレポートのメールを作成します
+func handoffReportEmail(from, until time.Time, markdownBody string) EmailContent {
+	subject := fmt.Sprintf("[Incidex] 引き継ぎレポート: %s 〜 %s", from.Format("01/02 15:04"), until.Format("01/02 15:04"))
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<pre style="white-space: pre-wrap; font-family: inherit;">%s</pre>
+			<p><a href="http://localhost:3000/incidents">インシデント一覧を見る</a></p>
+		</body>
+		</html>
+	`, html.EscapeString(markdownBody))
+
+	return EmailContent{Subject: subject, HTML: body}
+}
+
+// postMortemReviewEmail はポストモーテムのレビュー状況の変化のメールを作成します
+func postMortemReviewEmail(incidentTitle string, incidentID uint, event, actorName, note string) EmailContent {
+	subject := fmt.Sprintf("[Incidex] ポストモーテム%s: %s", event, incidentTitle)
+
+	noteHTML := ""
+	if note != "" {
+		noteHTML = fmt.Sprintf(`<p><strong>コメント:</strong></p><p style="white-space: pre-wrap;">%s</p>`, html.EscapeString(note))
+	}
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>ポストモーテム%s</h2>
+			<p><strong>インシデント:</strong> #%d %s</p>
+			<p><strong>操作者:</strong> %s</p>
+			%s
+			<p><a href="http://localhost:3000/incidents/%d/postmortem">ポストモーテムを見る</a></p>
+		</body>
+		</html>
+	`, event, incidentID, html.EscapeString(incidentTitle), html.EscapeString(actorName), noteHTML, incidentID)
+
+	return EmailContent{Subject: subject, HTML: body}
+}
+
+// postMortemDueEmail は必須ポストモーテムの期限リマインド・期限超過のメールを作成します
+func postMortemDueEmail(incidentTitle string, incidentID uint, severity string, dueAt time.Time, overdue bool) EmailContent {
+	heading := "ポストモーテムの期限が近づいています"
+	if overdue {
+		heading = "ポストモーテムの期限を過ぎています"
+	}
+	subject := fmt.Sprintf("[Incidex] %s: %s", heading, incidentTitle)
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>%s</h2>
+			<p><strong>インシデント:</strong> #%d %s</p>
+			<p><strong>重要度:</strong> %s</p>
+			<p><strong>公開期限:</strong> %s</p>
+			<p>このインシデントはポストモーテムの作成が必須です。期限までに公開してください。</p>
+			<p><a href="http://localhost:3000/incidents/%d/postmortem">ポストモーテムを開く</a></p>
+		</body>
+		</html>
+	`, heading, incidentID, html.EscapeString(incidentTitle), severity, dueAt.Format("2006-01-02 15:04 MST"), incidentID)
+
+	return EmailContent{Subject: subject, HTML: body}
+}
+
+// actionItemDueEmail はアクションアイテムの期限リマインド・期限超過・エスカレーションのメールを作成します
+func actionItemDueEmail(item ActionItemDigestLine, heading, note string) EmailContent {
+	subject := fmt.Sprintf("[Incidex] %s: %s", heading, item.Title)
+
+	incidentHTML := ""
+	if item.IncidentID != nil {
+		incidentHTML = fmt.Sprintf("<p><strong>インシデント:</strong> #%d</p>", *item.IncidentID)
+	}
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>%s</h2>
+			<p><strong>アクションアイテム:</strong> #%d %s</p>
+			%s
+			<p><strong>担当者:</strong> %s</p>
+			<p><strong>優先度:</strong> %s</p>
+			<p><strong>期限:</strong> %s</p>
+			<p>%s</p>
+			<p><a href="http://localhost:3000/action-items/%d">アクションアイテムを開く</a></p>
+		</body>
+		</html>
+	`, heading, item.ID, html.EscapeString(item.Title), incidentHTML, html.EscapeString(item.Assignee), item.Priority, item.DueDate, html.EscapeString(note), item.ID)
+
+	return EmailContent{Subject: subject, HTML: body}
+}
+
+// actionItemDigestEmail は担当中の未完了アクションアイテムの週次ダイジェストのメールを作成します
+func actionItemDigestEmail(userName string, items []ActionItemDigestLine) EmailContent {
+	overdue := 0
+	var rows strings.Builder
+	for _, item := range items {
+		dueDate := html.EscapeString(item.DueDate)
+		if item.Overdue {
+			overdue++
+			dueDate = fmt.Sprintf(`<span style="color:#dc2626">%s（期限超過）</span>`, dueDate)
+		}
+		fmt.Fprintf(&rows, `<tr><td><a href="http://localhost:3000/action-items/%d">#%d %s</a></td><td>%s</td><td>%s</td><td>%s</td></tr>`,
+			item.ID, item.ID, html.EscapeString(item.Title), item.Priority, item.Status, dueDate)
+	}
+	subject := fmt.Sprintf("[Incidex] 今週のアクションアイテム: 未完了 %d 件（期限超過 %d 件）", len(items), overdue)
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h2>%sさんの未完了アクションアイテム</h2>
+			<p>未完了 %d 件、うち期限超過 %d 件</p>
+			<table border="1" cellpadding="4" cellspacing="0">
+				<tr><th>タイトル</th><th>優先度</th><th>ステータス</th><th>期限</th></tr>
+				%s
+			</table>
+		</body>
+		</html>
+	`, html.EscapeString(userName), len(items), overdue, rows.String())
+
+	return EmailContent{Subject: subject, HTML: body}
 }
 
 func getEnv(key, fallback string) string {
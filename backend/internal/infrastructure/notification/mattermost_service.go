@@ -0,0 +1,73 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"strings"
+)
+
+// MattermostService はMattermostのIncoming Webhookに通知する Notifier です
+// Mattermost はSlack互換の添付（attachments）を表示できますが、Block Kit は表示できないため変換します
+type MattermostService struct {
+	client *http.Client
+}
+
+// NewMattermostService は新しいMattermostサービスを作成します
+func NewMattermostService() *MattermostService {
+	return &MattermostService{client: &http.Client{Timeout: webhookTimeout}}
+}
+
+type mattermostPayload struct {
+	Text        string                 `json:"text"`
+	Attachments []mattermostAttachment `json:"attachments,omitempty"`
+}
+
+type mattermostAttachment struct {
+	Fallback string            `json:"fallback"`
+	Color    string            `json:"color,omitempty"`
+	Text     string            `json:"text,omitempty"`
+	Fields   []mattermostField `json:"fields,omitempty"`
+	Footer   string            `json:"footer,omitempty"`
+}
+
+type mattermostField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func (s *MattermostService) Channel() domain.NotificationChannel {
+	return domain.ChannelMattermost
+}
+
+// Render はチャットメッセージをMattermostの添付に変換します
+func (s *MattermostService) Render(message *Message) (string, string, error) {
+	if message.Chat.Text == "" {
+		return "", "", errNoContent
+	}
+	card := newChatCard(message.Chat)
+
+	attachment := mattermostAttachment{
+		Fallback: card.Title,
+		Color:    card.Color,
+		Text:     strings.Join(card.Sections, "\n\n"),
+		Footer:   card.Footer,
+	}
+	for _, field := range card.Fields {
+		attachment.Fields = append(attachment.Fields, mattermostField{Title: field.Name, Value: field.Value, Short: true})
+	}
+
+	payload, err := json.Marshal(mattermostPayload{Text: card.Title, Attachments: []mattermostAttachment{attachment}})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal mattermost message: %w", err)
+	}
+	return card.Title, string(payload), nil
+}
+
+// Deliver はMattermostのIncoming Webhookにメッセージを送信します
+func (s *MattermostService) Deliver(ctx context.Context, webhookURL, subject, payload string) error {
+	return postWebhook(ctx, s.client, domain.ChannelMattermost, webhookURL, payload)
+}
@@ -1,28 +1,51 @@
 package notification
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"incidex/internal/domain"
+	"incidex/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 // NotificationService は通知を統合管理するサービス
 type NotificationService struct {
-	emailService *EmailService
-	slackService *SlackService
+	notifiers    Registry
 	settingRepo  domain.NotificationSettingRepository
 	userRepo     domain.UserRepository
+	endpointRepo domain.NotificationEndpointRepository
+	outbox       *outboxWriter
+	deliveryRepo domain.NotificationDeliveryRepository
 }
 
 // NewNotificationService は新しい通知サービスを作成します
+// 通知はアカウントのメールアドレスとユーザーが登録した通知先に、notifiers のチャネルで送信します
+// 通知は outboxRepo のキューに登録され、DeliverPending で最大 maxAttempts 回まで送信を試みます
+// outboxRepo が nil の場合はその場で送信します。送信の試行は deliveryRepo に記録されます
 func NewNotificationService(
 	settingRepo domain.NotificationSettingRepository,
 	userRepo domain.UserRepository,
+	endpointRepo domain.NotificationEndpointRepository,
+	notifiers Registry,
+	outboxRepo domain.NotificationOutboxRepository,
+	deliveryRepo domain.NotificationDeliveryRepository,
+	maxAttempts int,
 ) *NotificationService {
+	var outbox *outboxWriter
+	if outboxRepo != nil {
+		outbox = &outboxWriter{repo: outboxRepo, maxAttempts: maxAttempts}
+	}
+
 	return &NotificationService{
-		emailService: NewEmailService(),
-		slackService: NewSlackService(),
+		notifiers:    notifiers,
 		settingRepo:  settingRepo,
 		userRepo:     userRepo,
+		endpointRepo: endpointRepo,
+		outbox:       outbox,
+		deliveryRepo: deliveryRepo,
 	}
 }
 
@@ -30,40 +53,15 @@ func NewNotificationService(
 func (s *NotificationService) NotifyIncidentCreated(incident *domain.Incident, creator *domain.User) error {
 	// 担当者に通知
 	if incident.AssigneeID != nil && *incident.AssigneeID != creator.ID {
-		if err := s.notifyUser(*incident.AssigneeID, func(setting *domain.NotificationSetting, user *domain.User) error {
+		return s.notifyUser(*incident.AssigneeID, domain.NotificationEventIncidentCreated, &incident.ID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
 			if !setting.NotifyOnIncidentCreated {
 				return nil
 			}
-
-			// Email通知
-			if setting.EmailEnabled {
-				if err := s.emailService.SendIncidentCreatedEmail(
-					user.Email,
-					incident.Title,
-					incident.ID,
-					string(incident.Severity),
-				); err != nil {
-					fmt.Printf("Failed to send email: %v\n", err)
-				}
-			}
-
-			// Slack通知
-			if setting.SlackEnabled && setting.SlackWebhook != "" {
-				if err := s.slackService.SendIncidentCreatedMessage(
-					setting.SlackWebhook,
-					incident.Title,
-					incident.ID,
-					string(incident.Severity),
-					creator.Name,
-				); err != nil {
-					fmt.Printf("Failed to send slack message: %v\n", err)
-				}
+			return &Message{
+				Email: incidentCreatedEmail(incident.Title, incident.ID, string(incident.Severity)),
+				Chat:  incidentCreatedMessage(incident.Title, incident.ID, string(incident.Severity), creator.Name),
 			}
-
-			return nil
-		}); err != nil {
-			return err
-		}
+		})
 	}
 
 	return nil
@@ -71,116 +69,39 @@ func (s *NotificationService) NotifyIncidentCreated(incident *domain.Incident, c
 
 // NotifyAssigned は担当者割り当て通知を送信します
 func (s *NotificationService) NotifyAssigned(incident *domain.Incident, assignee *domain.User, assignedBy *domain.User) error {
-	return s.notifyUser(assignee.ID, func(setting *domain.NotificationSetting, user *domain.User) error {
+	return s.notifyUser(assignee.ID, domain.NotificationEventAssigned, &incident.ID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
 		if !setting.NotifyOnAssigned {
 			return nil
 		}
-
-		// Email通知
-		if setting.EmailEnabled {
-			if err := s.emailService.SendAssignedEmail(
-				user.Email,
-				incident.Title,
-				incident.ID,
-				assignedBy.Name,
-			); err != nil {
-				fmt.Printf("Failed to send email: %v\n", err)
-			}
-		}
-
-		// Slack通知
-		if setting.SlackEnabled && setting.SlackWebhook != "" {
-			if err := s.slackService.SendAssignedMessage(
-				setting.SlackWebhook,
-				incident.Title,
-				incident.ID,
-				assignee.Name,
-				assignedBy.Name,
-			); err != nil {
-				fmt.Printf("Failed to send slack message: %v\n", err)
-			}
+		return &Message{
+			Email: assignedEmail(incident.Title, incident.ID, assignedBy.Name),
+			Chat:  assignedMessage(incident.Title, incident.ID, assignee.Name, assignedBy.Name),
 		}
-
-		return nil
 	})
 }
 
 // NotifyComment はコメント追加通知を送信します
 func (s *NotificationService) NotifyComment(incident *domain.Incident, commenter *domain.User, comment string) error {
+	build := func(setting *domain.NotificationSetting, user *domain.User) *Message {
+		if !setting.NotifyOnComment {
+			return nil
+		}
+		return &Message{
+			Email: commentEmail(incident.Title, incident.ID, commenter.Name, comment),
+			Chat:  commentMessage(incident.Title, incident.ID, commenter.Name, comment),
+		}
+	}
+
 	// 担当者に通知（コメント者本人以外）
 	if incident.AssigneeID != nil && *incident.AssigneeID != commenter.ID {
-		if err := s.notifyUser(*incident.AssigneeID, func(setting *domain.NotificationSetting, user *domain.User) error {
-			if !setting.NotifyOnComment {
-				return nil
-			}
-
-			// Email通知
-			if setting.EmailEnabled {
-				if err := s.emailService.SendCommentEmail(
-					user.Email,
-					incident.Title,
-					incident.ID,
-					commenter.Name,
-					comment,
-				); err != nil {
-					fmt.Printf("Failed to send email: %v\n", err)
-				}
-			}
-
-			// Slack通知
-			if setting.SlackEnabled && setting.SlackWebhook != "" {
-				if err := s.slackService.SendCommentMessage(
-					setting.SlackWebhook,
-					incident.Title,
-					incident.ID,
-					commenter.Name,
-					comment,
-				); err != nil {
-					fmt.Printf("Failed to send slack message: %v\n", err)
-				}
-			}
-
-			return nil
-		}); err != nil {
+		if err := s.notifyUser(*incident.AssigneeID, domain.NotificationEventComment, &incident.ID, build); err != nil {
 			return err
 		}
 	}
 
 	// 作成者に通知（コメント者本人と担当者以外）
 	if incident.CreatorID != commenter.ID && (incident.AssigneeID == nil || incident.CreatorID != *incident.AssigneeID) {
-		if err := s.notifyUser(incident.CreatorID, func(setting *domain.NotificationSetting, user *domain.User) error {
-			if !setting.NotifyOnComment {
-				return nil
-			}
-
-			// Email通知
-			if setting.EmailEnabled {
-				if err := s.emailService.SendCommentEmail(
-					user.Email,
-					incident.Title,
-					incident.ID,
-					commenter.Name,
-					comment,
-				); err != nil {
-					fmt.Printf("Failed to send email: %v\n", err)
-				}
-			}
-
-			// Slack通知
-			if setting.SlackEnabled && setting.SlackWebhook != "" {
-				if err := s.slackService.SendCommentMessage(
-					setting.SlackWebhook,
-					incident.Title,
-					incident.ID,
-					commenter.Name,
-					comment,
-				); err != nil {
-					fmt.Printf("Failed to send slack message: %v\n", err)
-				}
-			}
-
-			return nil
-		}); err != nil {
+		if err := s.notifyUser(incident.CreatorID, domain.NotificationEventComment, &incident.ID, build); err != nil {
 			return err
 		}
 	}
@@ -190,41 +111,15 @@ func (s *NotificationService) NotifyComment(incident *domain.Incident, commenter
 
 // NotifyStatusChange はステータス変更通知を送信します
 func (s *NotificationService) NotifyStatusChange(incident *domain.Incident, oldStatus, newStatus string) error {
-	userIDs := s.getInterestedUsers(incident)
-
-	for _, userID := range userIDs {
-		if err := s.notifyUser(userID, func(setting *domain.NotificationSetting, user *domain.User) error {
+	for _, userID := range s.getInterestedUsers(incident) {
+		if err := s.notifyUser(userID, domain.NotificationEventStatusChange, &incident.ID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
 			if !setting.NotifyOnStatusChange {
 				return nil
 			}
-
-			// Email通知
-			if setting.EmailEnabled {
-				if err := s.emailService.SendStatusChangeEmail(
-					user.Email,
-					incident.Title,
-					incident.ID,
-					oldStatus,
-					newStatus,
-				); err != nil {
-					fmt.Printf("Failed to send email: %v\n", err)
-				}
-			}
-
-			// Slack通知
-			if setting.SlackEnabled && setting.SlackWebhook != "" {
-				if err := s.slackService.SendStatusChangeMessage(
-					setting.SlackWebhook,
-					incident.Title,
-					incident.ID,
-					oldStatus,
-					newStatus,
-				); err != nil {
-					fmt.Printf("Failed to send slack message: %v\n", err)
-				}
+			return &Message{
+				Email: statusChangeEmail(incident.Title, incident.ID, oldStatus, newStatus),
+				Chat:  statusChangeMessage(incident.Title, incident.ID, oldStatus, newStatus),
 			}
-
-			return nil
 		}); err != nil {
 			return err
 		}
@@ -235,49 +130,188 @@ func (s *NotificationService) NotifyStatusChange(incident *domain.Incident, oldS
 
 // NotifyResolved はインシデント解決通知を送信します
 func (s *NotificationService) NotifyResolved(incident *domain.Incident, resolver *domain.User) error {
-	userIDs := s.getInterestedUsers(incident)
-
-	for _, userID := range userIDs {
-		if err := s.notifyUser(userID, func(setting *domain.NotificationSetting, user *domain.User) error {
+	for _, userID := range s.getInterestedUsers(incident) {
+		if err := s.notifyUser(userID, domain.NotificationEventResolved, &incident.ID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
 			if !setting.NotifyOnResolved {
 				return nil
 			}
-
-			// Email通知
-			if setting.EmailEnabled {
-				if err := s.emailService.SendResolvedEmail(
-					user.Email,
-					incident.Title,
-					incident.ID,
-					resolver.Name,
-				); err != nil {
-					fmt.Printf("Failed to send email: %v\n", err)
-				}
+			return &Message{
+				Email: resolvedEmail(incident.Title, incident.ID, resolver.Name),
+				Chat:  resolvedMessage(incident.Title, incident.ID, resolver.Name),
 			}
+		}); err != nil {
+			return err
+		}
+	}
 
-			// Slack通知
-			if setting.SlackEnabled && setting.SlackWebhook != "" {
-				if err := s.slackService.SendResolvedMessage(
-					setting.SlackWebhook,
-					incident.Title,
-					incident.ID,
-					resolver.Name,
-				); err != nil {
-					fmt.Printf("Failed to send slack message: %v\n", err)
-				}
-			}
+	return nil
+}
 
+// SendHandoffReport は引き継ぎレポートを次のオンコール担当者へメールで送信します
+// 明示的な送信操作のため、通知設定に関わらず送信します
+func (s *NotificationService) SendHandoffReport(recipient *domain.User, from, until time.Time, markdownBody string) error {
+	origin := domain.NotificationOrigin{UserID: &recipient.ID, EventType: domain.NotificationEventHandoffReport}
+	return s.send(origin, domain.ChannelEmail, recipient.Email, &Message{Email: handoffReportEmail(from, until, markdownBody)})
+}
+
+// NotifyEscalation はエスカレーション通知を送信します
+func (s *NotificationService) NotifyEscalation(incident *domain.Incident, target *domain.User, level int) error {
+	return s.notifyUser(target.ID, domain.NotificationEventEscalation, &incident.ID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
+		if !setting.NotifyOnEscalation {
 			return nil
+		}
+		return &Message{
+			Email: escalationEmail(incident.Title, incident.ID, string(incident.Severity), level),
+			Chat:  escalationMessage(incident.Title, incident.ID, string(incident.Severity), user.Name, level),
+		}
+	})
+}
+
+// Labels of post-mortem review events used in notification titles
+var postMortemReviewEventLabels = map[domain.PostMortemReviewEvent]string{
+	domain.ReviewEventSubmitted:        "のレビュー依頼",
+	domain.ReviewEventReviewerApproved: "がレビュアーに承認されました",
+	domain.ReviewEventChangesRequested: "に修正依頼がありました",
+	domain.ReviewEventApproved:         "が承認されました",
+	domain.ReviewEventPublished:        "が公開されました",
+	domain.ReviewEventUnpublished:      "が非公開に戻されました",
+}
+
+// NotifyPostMortemReview はポストモーテムのレビューワークフローの遷移を作成者・レビュアーに通知します
+// 操作者本人には通知しません
+func (s *NotificationService) NotifyPostMortemReview(pm *domain.PostMortem, recipientIDs []uint, event domain.PostMortemReviewEvent, actor *domain.User, note string) error {
+	label, ok := postMortemReviewEventLabels[event]
+	if !ok {
+		label = string(event)
+	}
+	incidentTitle := ""
+	if pm.Incident != nil {
+		incidentTitle = pm.Incident.Title
+	}
+	actorName := "-"
+	if actor != nil {
+		actorName = actor.Name
+	}
+
+	seen := make(map[uint]bool)
+	for _, userID := range recipientIDs {
+		if seen[userID] || (actor != nil && userID == actor.ID) {
+			continue
+		}
+		seen[userID] = true
+
+		if err := s.notifyUser(userID, domain.NotificationEventPostMortemReview, &pm.IncidentID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
+			if !setting.NotifyOnPostMortemReview {
+				return nil
+			}
+			return &Message{
+				Email: postMortemReviewEmail(incidentTitle, pm.IncidentID, label, actorName, note),
+				Chat:  postMortemReviewMessage(incidentTitle, pm.IncidentID, label, actorName, note),
+			}
 		}); err != nil {
-			return err
+			logger.Log.Error("Failed to notify user", zap.Uint("user_id", userID), zap.Error(err))
 		}
 	}
 
 	return nil
 }
 
+// NotifyPostMortemDue は必須ポストモーテムの期限リマインド・期限超過を通知します
+func (s *NotificationService) NotifyPostMortemDue(incident *domain.Incident, recipient *domain.User, dueAt time.Time, overdue bool) error {
+	return s.notifyUser(recipient.ID, domain.NotificationEventPostMortemDue, &incident.ID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
+		if !setting.NotifyOnPostMortemDue {
+			return nil
+		}
+		return &Message{
+			Email: postMortemDueEmail(incident.Title, incident.ID, string(incident.Severity), dueAt, overdue),
+			Chat:  postMortemDueMessage(incident.Title, incident.ID, string(incident.Severity), dueAt, overdue),
+		}
+	})
+}
+
+// ActionItemDigestLine はアクションアイテム通知の1件分の表示内容です
+type ActionItemDigestLine struct {
+	ID         uint
+	Title      string
+	IncidentID *uint
+	Assignee   string
+	Priority   domain.Priority
+	Status     domain.ActionStatus
+	DueDate    string // 期限なしは "-"
+	Overdue    bool
+}
+
+// NewActionItemDigestLine は期限を loc で表示するアクションアイテムの表示内容を作成します
+func NewActionItemDigestLine(item *domain.ActionItem, now time.Time, loc *time.Location) ActionItemDigestLine {
+	line := ActionItemDigestLine{
+		ID:         item.ID,
+		Title:      item.Title,
+		IncidentID: item.IncidentID,
+		Assignee:   "-",
+		Priority:   item.Priority,
+		Status:     item.Status,
+		DueDate:    "-",
+	}
+	if item.Assignee != nil {
+		line.Assignee = item.Assignee.Name
+	}
+	if item.DueDate != nil {
+		line.DueDate = item.DueDate.In(loc).Format("2006-01-02")
+		line.Overdue = now.After(*item.DueDate)
+	}
+	return line
+}
+
+// Headings of action item due-date notifications (email / Slack)
+var actionItemDueHeadings = map[domain.ActionItemReminderKind][2]string{
+	domain.ActionItemDueSoon:   {"アクションアイテムの期限が近づいています", "⏰ アクションアイテムの期限が近づいています"},
+	domain.ActionItemOverdue:   {"アクションアイテムの期限を過ぎています", "🚨 アクションアイテムの期限を過ぎています"},
+	domain.ActionItemEscalated: {"優先度の高いアクションアイテムが期限を過ぎています", "🚨 優先度の高いアクションアイテムが期限を過ぎています"},
+}
+
+// NotifyActionItemDue はアクションアイテムの期限リマインド・期限超過・エスカレーションを通知します
+func (s *NotificationService) NotifyActionItemDue(item ActionItemDigestLine, recipient *domain.User, kind domain.ActionItemReminderKind) error {
+	headings := actionItemDueHeadings[kind]
+	color := "#FFA500"
+	note := "期限までに対応してください。"
+	switch kind {
+	case domain.ActionItemOverdue:
+		color = "#FF0000"
+		note = "期限を過ぎています。対応するか期限を見直してください。"
+	case domain.ActionItemEscalated:
+		color = "#FF0000"
+		note = "ポストモーテムの作成者としてお知らせしています。担当者の状況を確認してください。"
+	}
+
+	return s.notifyUser(recipient.ID, domain.NotificationEventActionItemDue, item.IncidentID, func(setting *domain.NotificationSetting, user *domain.User) *Message {
+		if !setting.NotifyOnActionItemDue {
+			return nil
+		}
+		return &Message{
+			Email: actionItemDueEmail(item, headings[0], note),
+			Chat:  actionItemDueMessage(item, headings[1], color, note),
+		}
+	})
+}
+
+// SendActionItemDigest は担当中の未完了アクションアイテムの週次ダイジェストを送信します
+func (s *NotificationService) SendActionItemDigest(recipient *domain.User, items []ActionItemDigestLine) error {
+	return s.notifyUser(recipient.ID, domain.NotificationEventActionItemDigest, nil, func(setting *domain.NotificationSetting, user *domain.User) *Message {
+		if !setting.WeeklyDigestEnabled {
+			return nil
+		}
+		return &Message{
+			Email: actionItemDigestEmail(user.Name, items),
+			Chat:  actionItemDigestMessage(user.Name, items),
+		}
+	})
+}
+
 // notifyUser は指定ユーザーに通知を送信します
-func (s *NotificationService) notifyUser(userID uint, fn func(*domain.NotificationSetting, *domain.User) error) error {
+// build はユーザーの通知設定から通知内容を作成し、通知しない場合は nil を返します
+// 通知はメールが有効ならアカウントのメールアドレスに、加えて有効な通知先すべてに送信します
+// event と incidentID は配信ログに記録されます
+func (s *NotificationService) notifyUser(userID uint, event domain.NotificationEvent, incidentID *uint, build func(*domain.NotificationSetting, *domain.User) *Message) error {
 	// ユーザー取得
 	user, err := s.userRepo.FindByID(nil, userID)
 	if err != nil {
@@ -288,21 +322,64 @@ func (s *NotificationService) notifyUser(userID uint, fn func(*domain.Notificati
 	setting, err := s.settingRepo.GetByUserID(userID)
 	if err != nil {
 		// 設定がない場合はデフォルト設定を使用
-		setting = &domain.NotificationSetting{
-			UserID:                        userID,
-			EmailEnabled:                  true,
-			SlackEnabled:                  false,
-			NotifyOnIncidentCreated:       true,
-			NotifyOnAssigned:              true,
-			NotifyOnComment:               true,
-			NotifyOnStatusChange:          true,
-			NotifyOnSeverityChange:        true,
-			NotifyOnResolved:              true,
-			NotifyOnEscalation:            true,
+		setting = domain.DefaultNotificationSetting(userID)
+	}
+
+	message := build(setting, user)
+	if message == nil {
+		return nil
+	}
+
+	origin := domain.NotificationOrigin{UserID: &user.ID, EventType: event, IncidentID: incidentID}
+	if setting.EmailEnabled {
+		if err := s.send(origin, domain.ChannelEmail, user.Email, message); err != nil {
+			logger.Log.Error("Failed to queue notification", zap.Uint("user_id", user.ID), zap.String("channel", string(domain.ChannelEmail)), zap.Error(err))
+		}
+	}
+
+	if s.endpointRepo == nil {
+		return nil
+	}
+	endpoints, err := s.endpointRepo.FindByUserID(context.Background(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification endpoints: %w", err)
+	}
+	for _, endpoint := range endpoints {
+		if !endpoint.Enabled {
+			continue
 		}
+		if err := s.send(origin, endpoint.Channel, endpoint.Target, message); err != nil {
+			logger.Log.Error("Failed to queue notification",
+				zap.Uint("user_id", user.ID),
+				zap.Uint("endpoint_id", endpoint.ID),
+				zap.String("channel", string(endpoint.Channel)),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// send は通知をチャネルの Notifier で変換し、キューに登録します（キューがない場合は直接送信します）
+func (s *NotificationService) send(origin domain.NotificationOrigin, channel domain.NotificationChannel, target string, message *Message) error {
+	notifier, ok := s.notifiers[channel]
+	if !ok {
+		return fmt.Errorf("no notifier registered for channel: %s", channel)
+	}
+
+	subject, payload, err := notifier.Render(message)
+	if errors.Is(err, errNoContent) {
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
-	return fn(setting, user)
+	if s.outbox != nil {
+		return s.outbox.enqueue(origin, channel, target, subject, payload)
+	}
+	return notifier.Deliver(context.Background(), target, subject, payload)
 }
 
 // getInterestedUsers はインシデントに関係するユーザーIDのリストを取得します
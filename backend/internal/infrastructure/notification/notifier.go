@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Timeout of requests to chat webhooks
+const webhookTimeout = 10 * time.Second
+
+// errNoContent is returned by Render when the message has no content for the notifier's channel
+var errNoContent = errors.New("message has no content for this channel")
+
+// Notifier は1つのチャネル（メール、Slack、Teams など）で通知を送信します
+// 通知は Render した件名とペイロードで通知キューに登録され、ワーカーが Deliver で送信します
+type Notifier interface {
+	Channel() domain.NotificationChannel
+	// Render returns the subject and the payload delivered for the message
+	Render(message *Message) (subject, payload string, err error)
+	// Deliver sends a rendered notification to target (an email address or webhook URL)
+	Deliver(ctx context.Context, target, subject, payload string) error
+}
+
+// Registry は利用できる Notifier をチャネルごとに保持します
+type Registry map[domain.NotificationChannel]Notifier
+
+// Register adds a notifier, replacing any notifier of the same channel
+func (r Registry) Register(notifier Notifier) {
+	r[notifier.Channel()] = notifier
+}
+
+// Message は1件の通知の内容です
+// メールはHTML、チャットは SlackMessage の形式で作成し、各 Notifier が自分のチャネルの形式に変換します
+type Message struct {
+	Email EmailContent
+	Chat  SlackMessage
+}
+
+// EmailContent はメールの件名と本文です
+type EmailContent struct {
+	Subject string
+	HTML    string
+}
+
+// postWebhook posts a JSON payload to a chat webhook and fails on non-2xx responses.
+// Errors name the webhook only by its masked URL, since the path carries the webhook's secret.
+func postWebhook(ctx context.Context, client *http.Client, channel domain.NotificationChannel, webhookURL, payload string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, strings.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request for %s: %w", channel, domain.MaskRecipient(channel, webhookURL), unwrapURLError(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s message to %s: %w", channel, domain.MaskRecipient(channel, webhookURL), unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned non-OK status: %d %s", channel, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// unwrapURLError drops the *url.Error around a request error, whose text contains the full request URL
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const webhookSecret = "SECRETTOKEN"
+
+// unreachableWebhook returns the URL of a webhook whose server is already closed, so requests to it fail
+func unreachableWebhook() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL + "/services/T000/B000/" + webhookSecret
+}
+
+func TestFailedDeliveryErrorHidesWebhookSecret(t *testing.T) {
+	webhookURL := unreachableWebhook()
+	now := time.Now()
+
+	notifiers := []Notifier{NewSlackService(), NewTeamsService(), NewDiscordService(), NewMattermostService()}
+	for _, notifier := range notifiers {
+		t.Run(string(notifier.Channel()), func(t *testing.T) {
+			err := notifier.Deliver(context.Background(), webhookURL, "subject", `{"text": "test"}`)
+			if err == nil {
+				t.Fatal("Deliver to a closed server succeeded")
+			}
+			if strings.Contains(err.Error(), webhookSecret) {
+				t.Errorf("delivery error contains the webhook secret: %q", err)
+			}
+
+			message := domain.NewNotificationOutbox(domain.NotificationOrigin{}, notifier.Channel(), webhookURL, "subject", "{}", 3, now)
+			delivery := domain.NewNotificationDelivery(message, 1, err, time.Millisecond, now)
+			message.RecordFailure(err, now)
+			for name, text := range map[string]string{"delivery error": delivery.Error, "outbox last error": message.LastError} {
+				if text == "" {
+					t.Errorf("%s is empty", name)
+				}
+				if strings.Contains(text, webhookSecret) {
+					t.Errorf("%s contains the webhook secret: %q", name, text)
+				}
+			}
+		})
+	}
+}
+
+func TestStoredErrorMasksWebhookURL(t *testing.T) {
+	webhookURL := "https://hooks.slack.com/services/T000/B000/" + webhookSecret
+	now := time.Now()
+	err := fmt.Errorf("wrapped: %w", errors.New(`Post "`+webhookURL+`": dial tcp: i/o timeout`))
+
+	message := domain.NewNotificationOutbox(domain.NotificationOrigin{}, domain.ChannelSlack, webhookURL, "subject", "{}", 1, now)
+	delivery := domain.NewNotificationDelivery(message, 1, err, time.Millisecond, now)
+	message.RecordFailure(err, now)
+
+	want := `wrapped: Post "https://hooks.slack.com/***": dial tcp: i/o timeout`
+	if delivery.Error != want {
+		t.Errorf("delivery error = %q, want %q", delivery.Error, want)
+	}
+	if message.LastError != want {
+		t.Errorf("outbox last error = %q, want %q", message.LastError, want)
+	}
+
+	body, marshalErr := message.MarshalJSON()
+	if marshalErr != nil {
+		t.Fatalf("MarshalJSON returned error: %v", marshalErr)
+	}
+	if strings.Contains(string(body), webhookSecret) {
+		t.Errorf("outbox JSON contains the webhook secret: %s", body)
+	}
+}
@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// Number of queued notifications claimed per database round trip
+	outboxBatchSize = 50
+	// How long a claimed notification is hidden from other workers while it is delivered
+	outboxLease = 5 * time.Minute
+)
+
+// outboxWriter は送信する通知を通知キューに登録します
+type outboxWriter struct {
+	repo        domain.NotificationOutboxRepository
+	maxAttempts int
+}
+
+func (w *outboxWriter) enqueue(origin domain.NotificationOrigin, channel domain.NotificationChannel, recipient, subject, payload string) error {
+	message := domain.NewNotificationOutbox(origin, channel, recipient, subject, payload, w.maxAttempts, time.Now())
+	if err := w.repo.Create(context.Background(), message); err != nil {
+		return fmt.Errorf("failed to queue %s notification: %w", channel, err)
+	}
+	return nil
+}
+
+// DeliverPending は送信時刻を迎えたキュー内の通知を送信します（スケジューラーから定期実行されます）
+// 失敗した通知は指数バックオフで再送し、最大試行回数に達したらデッドレターにします
+// 送信の試行はすべて配信ログに記録します
+func (s *NotificationService) DeliverPending(ctx context.Context) error {
+	if s.outbox == nil {
+		return nil
+	}
+	for {
+		messages, err := s.outbox.repo.ClaimDue(ctx, time.Now(), outboxLease, outboxBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim queued notifications: %w", err)
+		}
+		for _, message := range messages {
+			s.deliverQueued(ctx, message)
+		}
+		if len(messages) < outboxBatchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (s *NotificationService) deliverQueued(ctx context.Context, message *domain.NotificationOutbox) {
+	start := time.Now()
+	var err error
+	if notifier, ok := s.notifiers[message.Channel]; ok {
+		err = notifier.Deliver(ctx, message.Recipient, message.Subject, message.Payload)
+	} else {
+		err = fmt.Errorf("no notifier registered for channel: %s", message.Channel)
+	}
+
+	now := time.Now()
+	s.recordDelivery(ctx, domain.NewNotificationDelivery(message, message.Attempts+1, err, now.Sub(start), start))
+	if err != nil {
+		message.RecordFailure(err, now)
+		fields := []zap.Field{
+			zap.Uint("notification_id", message.ID),
+			zap.String("channel", string(message.Channel)),
+			zap.Int("attempts", message.Attempts),
+			zap.Error(err),
+		}
+		if message.Status == domain.OutboxDead {
+			logger.Log.Error("Notification delivery failed permanently", fields...)
+		} else {
+			logger.Log.Warn("Notification delivery failed, will retry", append(fields, zap.Time("next_attempt_at", message.NextAttemptAt))...)
+		}
+	} else {
+		message.RecordSuccess(now)
+	}
+
+	if err := s.outbox.repo.Update(ctx, message); err != nil {
+		logger.Log.Error("Failed to update queued notification", zap.Uint("notification_id", message.ID), zap.Error(err))
+	}
+}
+
+func (s *NotificationService) recordDelivery(ctx context.Context, delivery *domain.NotificationDelivery) {
+	if s.deliveryRepo == nil {
+		return
+	}
+	if err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+		logger.Log.Error("Failed to record notification delivery", zap.Uintp("notification_id", delivery.NotificationID), zap.Error(err))
+	}
+}
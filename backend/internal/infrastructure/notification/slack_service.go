@@ -1,21 +1,27 @@
 package notification
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"incidex/internal/domain"
 	"net/http"
+	"strings"
+	"time"
 )
 
-// SlackService はSlack通知を送信するサービス
-type SlackService struct{}
+// SlackService はSlackのIncoming Webhookに通知を送信する Notifier です
+type SlackService struct {
+	client *http.Client
+}
 
 // NewSlackService は新しいSlackサービスを作成します
 func NewSlackService() *SlackService {
-	return &SlackService{}
+	return &SlackService{client: &http.Client{Timeout: webhookTimeout}}
 }
 
-// SlackMessage はSlackメッセージの構造体
+// SlackMessage はSlackメッセージの構造体です
+// チャット向けの通知はこの形式（Block Kit、mrkdwn）で作成し、Slack以外のチャットにはアダプターが変換します
 type SlackMessage struct {
 	Text        string       `json:"text,omitempty"`
 	Blocks      []SlackBlock `json:"blocks,omitempty"`
@@ -42,34 +48,29 @@ type Attachment struct {
 	Footer string `json:"footer,omitempty"`
 }
 
-// SendMessage はSlackにメッセージを送信します
-func (s *SlackService) SendMessage(webhookURL string, message SlackMessage) error {
-	if webhookURL == "" {
-		// Webhook URLがない場合はログのみ出力（開発環境用）
-		fmt.Printf("[SLACK] %s\n", message.Text)
-		return nil
-	}
+func (s *SlackService) Channel() domain.NotificationChannel {
+	return domain.ChannelSlack
+}
 
-	jsonData, err := json.Marshal(message)
-	if err != nil {
-		return fmt.Errorf("failed to marshal slack message: %w", err)
+// Render はチャットメッセージをそのままSlackのJSONにします
+func (s *SlackService) Render(message *Message) (string, string, error) {
+	if message.Chat.Text == "" {
+		return "", "", errNoContent
 	}
-
-	resp, err := http.Post(webhookURL, "application/json", bytes.NewBuffer(jsonData))
+	payload, err := json.Marshal(message.Chat)
 	if err != nil {
-		return fmt.Errorf("failed to send slack message: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("slack returned non-OK status: %d", resp.StatusCode)
+		return "", "", fmt.Errorf("failed to marshal slack message: %w", err)
 	}
+	return message.Chat.Text, string(payload), nil
+}
 
-	return nil
+// Deliver はSlackのIncoming Webhookにメッセージを送信します
+func (s *SlackService) Deliver(ctx context.Context, webhookURL, subject, payload string) error {
+	return postWebhook(ctx, s.client, domain.ChannelSlack, webhookURL, payload)
 }
 
-// SendIncidentCreatedMessage はインシデント作成通知を送信します
-func (s *SlackService) SendIncidentCreatedMessage(webhookURL, incidentTitle string, incidentID uint, severity, creatorName string) error {
+// incidentCreatedMessage はインシデント作成通知のチャットメッセージを作成します
+func incidentCreatedMessage(incidentTitle string, incidentID uint, severity, creatorName string) SlackMessage {
 	color := getSeverityColor(severity)
 
 	message := SlackMessage{
@@ -101,11 +102,11 @@ func (s *SlackService) SendIncidentCreatedMessage(webhookURL, incidentTitle stri
 		},
 	}
 
-	return s.SendMessage(webhookURL, message)
+	return message
 }
 
-// SendAssignedMessage は担当者割り当て通知を送信します
-func (s *SlackService) SendAssignedMessage(webhookURL, incidentTitle string, incidentID uint, assigneeName, assignedBy string) error {
+// assignedMessage は担当者割り当て通知のチャットメッセージを作成します
+func assignedMessage(incidentTitle string, incidentID uint, assigneeName, assignedBy string) SlackMessage {
 	message := SlackMessage{
 		Text: fmt.Sprintf("👤 インシデントが割り当てられました: %s", incidentTitle),
 		Blocks: []SlackBlock{
@@ -135,11 +136,11 @@ func (s *SlackService) SendAssignedMessage(webhookURL, incidentTitle string, inc
 		},
 	}
 
-	return s.SendMessage(webhookURL, message)
+	return message
 }
 
-// SendCommentMessage はコメント追加通知を送信します
-func (s *SlackService) SendCommentMessage(webhookURL, incidentTitle string, incidentID uint, commenterName, comment string) error {
+// commentMessage はコメント追加通知のチャットメッセージを作成します
+func commentMessage(incidentTitle string, incidentID uint, commenterName, comment string) SlackMessage {
 	message := SlackMessage{
 		Text: fmt.Sprintf("💬 新しいコメント: %s", incidentTitle),
 		Blocks: []SlackBlock{
@@ -169,11 +170,11 @@ func (s *SlackService) SendCommentMessage(webhookURL, incidentTitle string, inci
 		},
 	}
 
-	return s.SendMessage(webhookURL, message)
+	return message
 }
 
-// SendStatusChangeMessage はステータス変更通知を送信します
-func (s *SlackService) SendStatusChangeMessage(webhookURL, incidentTitle string, incidentID uint, oldStatus, newStatus string) error {
+// statusChangeMessage はステータス変更通知のチャットメッセージを作成します
+func statusChangeMessage(incidentTitle string, incidentID uint, oldStatus, newStatus string) SlackMessage {
 	message := SlackMessage{
 		Text: fmt.Sprintf("🔄 ステータス変更: %s", incidentTitle),
 		Blocks: []SlackBlock{
@@ -203,11 +204,11 @@ func (s *SlackService) SendStatusChangeMessage(webhookURL, incidentTitle string,
 		},
 	}
 
-	return s.SendMessage(webhookURL, message)
+	return message
 }
 
-// SendResolvedMessage はインシデント解決通知を送信します
-func (s *SlackService) SendResolvedMessage(webhookURL, incidentTitle string, incidentID uint, resolvedBy string) error {
+// resolvedMessage はインシデント解決通知のチャットメッセージを作成します
+func resolvedMessage(incidentTitle string, incidentID uint, resolvedBy string) SlackMessage {
 	message := SlackMessage{
 		Text: fmt.Sprintf("✅ インシデントが解決されました: %s", incidentTitle),
 		Blocks: []SlackBlock{
@@ -236,7 +237,179 @@ func (s *SlackService) SendResolvedMessage(webhookURL, incidentTitle string, inc
 		},
 	}
 
-	return s.SendMessage(webhookURL, message)
+	return message
+}
+
+// escalationMessage はエスカレーション通知のチャットメッセージを作成します
+func escalationMessage(incidentTitle string, incidentID uint, severity, targetName string, level int) SlackMessage {
+	color := getSeverityColor(severity)
+
+	message := SlackMessage{
+		Text: fmt.Sprintf("📣 エスカレーション (レベル%d): %s", level, incidentTitle),
+		Blocks: []SlackBlock{
+			{
+				Type: "section",
+				Text: &SlackText{
+					Type: "mrkdwn",
+					Text: fmt.Sprintf("*📣 インシデントがエスカレーションされました*\n*<%s|#%d %s>*",
+						fmt.Sprintf("http://localhost:3000/incidents/%d", incidentID),
+						incidentID,
+						incidentTitle),
+				},
+			},
+			{
+				Type: "section",
+				Fields: []SlackText{
+					{Type: "mrkdwn", Text: fmt.Sprintf("*重要度:*\n%s", getSeverityEmoji(severity))},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*レベル:*\n%d", level)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*通知先:*\n%s", targetName)},
+				},
+			},
+		},
+		Attachments: []Attachment{
+			{
+				Color:  color,
+				Footer: "Incidex - Incident Management System",
+			},
+		},
+	}
+
+	return message
+}
+
+// postMortemReviewMessage はポストモーテムのレビュー状況の変化のチャットメッセージを作成します
+func postMortemReviewMessage(incidentTitle string, incidentID uint, event, actorName, note string) SlackMessage {
+	text := fmt.Sprintf("*📝 ポストモーテム%s*\n*<%s|#%d %s>*\n操作者: %s",
+		event,
+		fmt.Sprintf("http://localhost:3000/incidents/%d/postmortem", incidentID),
+		incidentID,
+		incidentTitle,
+		actorName)
+	if note != "" {
+		text += "\n> " + note
+	}
+
+	message := SlackMessage{
+		Text: fmt.Sprintf("📝 ポストモーテム%s: %s", event, incidentTitle),
+		Blocks: []SlackBlock{
+			{
+				Type: "section",
+				Text: &SlackText{
+					Type: "mrkdwn",
+					Text: text,
+				},
+			},
+		},
+		Attachments: []Attachment{
+			{
+				Color:  "#6366f1",
+				Footer: "Incidex - Incident Management System",
+			},
+		},
+	}
+
+	return message
+}
+
+// postMortemDueMessage は必須ポストモーテムの期限リマインド・期限超過のチャットメッセージを作成します
+func postMortemDueMessage(incidentTitle string, incidentID uint, severity string, dueAt time.Time, overdue bool) SlackMessage {
+	heading := "⏰ ポストモーテムの期限が近づいています"
+	color := "#FFA500"
+	if overdue {
+		heading = "🚨 ポストモーテムの期限を過ぎています"
+		color = "#FF0000"
+	}
+
+	message := SlackMessage{
+		Text: fmt.Sprintf("%s: %s", heading, incidentTitle),
+		Blocks: []SlackBlock{
+			{
+				Type: "section",
+				Text: &SlackText{
+					Type: "mrkdwn",
+					Text: fmt.Sprintf("*%s*\n*<%s|#%d %s>*\n重要度: %s\n公開期限: %s",
+						heading,
+						fmt.Sprintf("http://localhost:3000/incidents/%d/postmortem", incidentID),
+						incidentID,
+						incidentTitle,
+						severity,
+						dueAt.Format("2006-01-02 15:04 MST")),
+				},
+			},
+		},
+		Attachments: []Attachment{
+			{
+				Color:  color,
+				Footer: "Incidex - Incident Management System",
+			},
+		},
+	}
+
+	return message
+}
+
+// actionItemDueMessage はアクションアイテムの期限リマインド・期限超過・エスカレーションのチャットメッセージを作成します
+func actionItemDueMessage(item ActionItemDigestLine, heading, color, note string) SlackMessage {
+	text := fmt.Sprintf("*%s*\n*<%s|#%d %s>*\n担当者: %s\n優先度: %s\n期限: %s",
+		heading,
+		fmt.Sprintf("http://localhost:3000/action-items/%d", item.ID),
+		item.ID,
+		item.Title,
+		item.Assignee,
+		item.Priority,
+		item.DueDate)
+	if item.IncidentID != nil {
+		text += fmt.Sprintf("\nインシデント: #%d", *item.IncidentID)
+	}
+	if note != "" {
+		text += "\n" + note
+	}
+
+	message := SlackMessage{
+		Text: fmt.Sprintf("%s: %s", heading, item.Title),
+		Blocks: []SlackBlock{
+			{
+				Type: "section",
+				Text: &SlackText{Type: "mrkdwn", Text: text},
+			},
+		},
+		Attachments: []Attachment{
+			{
+				Color:  color,
+				Footer: "Incidex - Incident Management System",
+			},
+		},
+	}
+
+	return message
+}
+
+// actionItemDigestMessage は担当中の未完了アクションアイテムの週次ダイジェストのチャットメッセージを作成します
+func actionItemDigestMessage(userName string, items []ActionItemDigestLine) SlackMessage {
+	overdue := 0
+	var lines []string
+	for _, item := range items {
+		mark := "•"
+		if item.Overdue {
+			overdue++
+			mark = "🚨"
+		}
+		lines = append(lines, fmt.Sprintf("%s <%s|#%d %s>（%s / 期限: %s）",
+			mark, fmt.Sprintf("http://localhost:3000/action-items/%d", item.ID), item.ID, item.Title, item.Priority, item.DueDate))
+	}
+	heading := fmt.Sprintf("📋 %sさんの今週のアクションアイテム: 未完了 %d 件（期限超過 %d 件）", userName, len(items), overdue)
+
+	message := SlackMessage{
+		Text: heading,
+		Blocks: []SlackBlock{
+			{
+				Type: "section",
+				Text: &SlackText{Type: "mrkdwn", Text: "*" + heading + "*\n" + strings.Join(lines, "\n")},
+			},
+		},
+	}
+
+	return message
 }
 
 func getSeverityColor(severity string) string {
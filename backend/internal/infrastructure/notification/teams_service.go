@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"strings"
+)
+
+// TeamsService はMicrosoft TeamsのIncoming Webhookにカード（MessageCard）で通知する Notifier です
+type TeamsService struct {
+	client *http.Client
+}
+
+// NewTeamsService は新しいTeamsサービスを作成します
+func NewTeamsService() *TeamsService {
+	return &TeamsService{client: &http.Client{Timeout: webhookTimeout}}
+}
+
+type teamsCard struct {
+	Type       string         `json:"@type"`
+	Context    string         `json:"@context"`
+	Summary    string         `json:"summary"`
+	ThemeColor string         `json:"themeColor,omitempty"`
+	Title      string         `json:"title"`
+	Sections   []teamsSection `json:"sections,omitempty"`
+}
+
+type teamsSection struct {
+	Text     string      `json:"text,omitempty"`
+	Facts    []teamsFact `json:"facts,omitempty"`
+	Markdown bool        `json:"markdown"`
+}
+
+type teamsFact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (s *TeamsService) Channel() domain.NotificationChannel {
+	return domain.ChannelTeams
+}
+
+// Render はチャットメッセージをMessageCardに変換します
+func (s *TeamsService) Render(message *Message) (string, string, error) {
+	if message.Chat.Text == "" {
+		return "", "", errNoContent
+	}
+	card := newChatCard(message.Chat)
+
+	teams := teamsCard{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    card.Title,
+		ThemeColor: strings.TrimPrefix(card.Color, "#"),
+		Title:      card.Title,
+	}
+	for _, text := range card.Sections {
+		// Teams only breaks lines at paragraph breaks
+		teams.Sections = append(teams.Sections, teamsSection{Text: strings.ReplaceAll(text, "\n", "\n\n"), Markdown: true})
+	}
+	if len(card.Fields) > 0 {
+		facts := make([]teamsFact, len(card.Fields))
+		for i, field := range card.Fields {
+			facts[i] = teamsFact{Name: field.Name, Value: field.Value}
+		}
+		teams.Sections = append(teams.Sections, teamsSection{Facts: facts, Markdown: true})
+	}
+	if card.Footer != "" {
+		teams.Sections = append(teams.Sections, teamsSection{Text: card.Footer})
+	}
+
+	payload, err := json.Marshal(teams)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal teams card: %w", err)
+	}
+	return card.Title, string(payload), nil
+}
+
+// Deliver はTeamsのIncoming Webhookにカードを送信します
+func (s *TeamsService) Deliver(ctx context.Context, webhookURL, subject, payload string) error {
+	return postWebhook(ctx, s.client, domain.ChannelTeams, webhookURL, payload)
+}
@@ -0,0 +1,104 @@
+package pdf
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+)
+
+// addActionItemSection renders the action item burndown, aging and overdue tables
+func (s *IncidentPDFService) addActionItemSection(m core.Maroto, report *domain.ActionItemReport) {
+	m.AddRow(18,
+		col.New(12).Add(
+			text.New("Action Items", props.Text{
+				Size:  16,
+				Style: fontstyle.Bold,
+				Color: &props.Color{Red: 30, Green: 58, Blue: 138},
+			}),
+		),
+	)
+
+	median := "-"
+	if report.MedianDaysToComplete != nil {
+		median = fmt.Sprintf("%.1f days", *report.MedianDaysToComplete)
+	}
+	m.AddRow(8,
+		col.New(12).Add(
+			text.New(fmt.Sprintf("Open: %d / Completed in period: %d / Median time to completion: %s (as of %s, %s)",
+				report.OpenAtEnd, report.CompletedInPeriod, median, report.AsOf.Format("2006-01-02"), report.Timezone), props.Text{
+				Size:  8,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+		),
+	)
+
+	// Burndown
+	s.addActionItemTableHeader(m, "Week", "Opened", "Completed", "Open at end")
+	for _, week := range report.Weeks {
+		s.addActionItemTableRow(m, week.WeekStart.Format("2006-01-02"), week.Opened, week.Completed, week.OpenAtEnd)
+	}
+	m.AddRow(8)
+
+	// Aging of open items
+	s.addActionItemTableHeader(m, "Age (days)", "High", "Medium", "Low", "Total")
+	for _, bucket := range report.Aging {
+		s.addActionItemTableRow(m, bucket.Label, bucket.High, bucket.Medium, bucket.Low, bucket.Total)
+	}
+	m.AddRow(8)
+
+	// Overdue
+	if len(report.OverdueByAssignee) > 0 {
+		s.addActionItemTableHeader(m, "Department", "Overdue", "Open")
+		for _, dept := range report.OverdueByDepartment {
+			s.addActionItemTableRow(m, displayDepartment(dept.Department), dept.Overdue, dept.Open)
+		}
+		m.AddRow(8)
+
+		s.addActionItemTableHeader(m, "Assignee", "Overdue", "Open")
+		for _, entry := range report.OverdueByAssignee {
+			name := entry.UserName
+			if entry.Department != "" {
+				name = fmt.Sprintf("%s (%s)", entry.UserName, entry.Department)
+			}
+			s.addActionItemTableRow(m, name, entry.Overdue, entry.Open)
+		}
+		m.AddRow(8)
+	}
+}
+
+// addActionItemTableHeader adds a header of a first column (4/12) followed by equally wide count columns
+func (s *IncidentPDFService) addActionItemTableHeader(m core.Maroto, firstColumn string, columns ...string) {
+	headerStyle := props.Text{Size: 8, Style: fontstyle.Bold, Align: align.Center}
+
+	cols := []core.Col{col.New(4).Add(text.New(firstColumn, props.Text{Size: 8, Style: fontstyle.Bold}))}
+	for _, column := range columns {
+		cols = append(cols, col.New(2).Add(text.New(column, headerStyle)))
+	}
+	m.AddRow(10, cols...)
+
+	m.AddRow(3,
+		col.New(12).Add(
+			text.New("═══════════════════════════════════════════════════════", props.Text{
+				Size:  8,
+				Align: align.Center,
+				Color: &props.Color{Red: 156, Green: 163, Blue: 175},
+			}),
+		),
+	)
+}
+
+func (s *IncidentPDFService) addActionItemTableRow(m core.Maroto, name string, counts ...int) {
+	cell := props.Text{Size: 9, Align: align.Center}
+
+	cols := []core.Col{col.New(4).Add(text.New(truncateString(name, 40), props.Text{Size: 9}))}
+	for _, count := range counts {
+		cols = append(cols, col.New(2).Add(text.New(fmt.Sprintf("%d", count), cell)))
+	}
+	m.AddRow(9, cols...)
+}
@@ -0,0 +1,242 @@
+package pdf
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"time"
+
+	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/config"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+)
+
+// GenerateHandoffReport generates a PDF of a shift handoff report, with times shown in loc
+func (s *IncidentPDFService) GenerateHandoffReport(report *domain.HandoffReport, loc *time.Location) ([]byte, error) {
+	cfg := config.NewBuilder().Build()
+	m := maroto.New(cfg)
+
+	s.addHandoffHeader(m, report, loc)
+	s.addHandoffCounts(m, report)
+
+	s.addHandoffSLATable(m, report.UpcomingSLA, report.To, loc)
+	s.addHandoffIncidentTable(m, "Still Open", report.StillOpen, loc)
+	s.addHandoffIncidentTable(m, "Opened", report.Opened, loc)
+	s.addHandoffIncidentTable(m, "Changed", report.Changed, loc)
+	s.addHandoffIncidentTable(m, "Resolved", report.Resolved, loc)
+	s.addHandoffEvents(m, report.NotableEvents, loc)
+
+	document, err := m.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate handoff PDF: %w", err)
+	}
+
+	return document.GetBytes(), nil
+}
+
+func (s *IncidentPDFService) addHandoffHeader(m core.Maroto, report *domain.HandoffReport, loc *time.Location) {
+	m.AddRow(20,
+		col.New(12).Add(
+			text.New("Shift Handoff Report", props.Text{
+				Size:  20,
+				Style: fontstyle.Bold,
+				Align: align.Center,
+				Color: &props.Color{Red: 30, Green: 58, Blue: 138},
+			}),
+		),
+	)
+
+	m.AddRow(12,
+		col.New(12).Add(
+			text.New(
+				fmt.Sprintf("Shift: %s - %s (%s)",
+					report.From.In(loc).Format("2006-01-02 15:04"),
+					report.To.In(loc).Format("2006-01-02 15:04"),
+					loc.String()),
+				props.Text{
+					Size:  12,
+					Align: align.Center,
+					Color: &props.Color{Red: 75, Green: 85, Blue: 99},
+				}),
+		),
+	)
+
+	m.AddRow(8,
+		col.New(12).Add(
+			text.New(fmt.Sprintf("Generated: %s", report.GeneratedAt.In(loc).Format("2006-01-02 15:04:05")), props.Text{
+				Size:  9,
+				Align: align.Center,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+		),
+	)
+}
+
+func (s *IncidentPDFService) addHandoffCounts(m core.Maroto, report *domain.HandoffReport) {
+	card := func(label string, count int, color *props.Color) core.Col {
+		return col.New(2).Add(
+			text.New(label, props.Text{
+				Size:  8,
+				Align: align.Center,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+			text.New(fmt.Sprintf("%d", count), props.Text{
+				Size:  18,
+				Style: fontstyle.Bold,
+				Align: align.Center,
+				Top:   5,
+				Color: color,
+			}),
+		)
+	}
+
+	m.AddRow(5)
+	m.AddRow(25,
+		col.New(1),
+		card("Opened", len(report.Opened), &props.Color{Red: 59, Green: 130, Blue: 246}),
+		card("Changed", len(report.Changed), &props.Color{Red: 107, Green: 114, Blue: 128}),
+		card("Resolved", len(report.Resolved), &props.Color{Red: 34, Green: 197, Blue: 94}),
+		card("Still Open", len(report.StillOpen), &props.Color{Red: 234, Green: 88, Blue: 12}),
+		card("SLA Due", len(report.UpcomingSLA), &props.Color{Red: 220, Green: 38, Blue: 38}),
+		col.New(1),
+	)
+	m.AddRow(5)
+}
+
+func (s *IncidentPDFService) addHandoffSectionTitle(m core.Maroto, title string) {
+	m.AddRow(14,
+		col.New(12).Add(
+			text.New(title, props.Text{
+				Size:  14,
+				Style: fontstyle.Bold,
+				Color: &props.Color{Red: 30, Green: 58, Blue: 138},
+			}),
+		),
+	)
+}
+
+func (s *IncidentPDFService) addHandoffEmpty(m core.Maroto) {
+	m.AddRow(8,
+		col.New(12).Add(
+			text.New("None", props.Text{
+				Size:  9,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+		),
+	)
+}
+
+func (s *IncidentPDFService) addHandoffSLATable(m core.Maroto, incidents []domain.HandoffIncident, handoffAt time.Time, loc *time.Location) {
+	s.addHandoffSectionTitle(m, "Upcoming SLA Deadlines")
+	if len(incidents) == 0 {
+		s.addHandoffEmpty(m)
+		return
+	}
+
+	header := props.Text{Size: 9, Style: fontstyle.Bold}
+	m.AddRow(8,
+		col.New(1).Add(text.New("ID", header)),
+		col.New(5).Add(text.New("Title", header)),
+		col.New(2).Add(text.New("Severity", header)),
+		col.New(2).Add(text.New("Deadline", header)),
+		col.New(2).Add(text.New("Remaining", header)),
+	)
+
+	for _, incident := range incidents {
+		remaining := incident.SLADeadline.Sub(handoffAt)
+		remainingText := fmt.Sprintf("%.1fh", remaining.Hours())
+		remainingStyle := props.Text{Size: 9}
+		if remaining < 0 {
+			remainingText = fmt.Sprintf("overdue %.1fh", -remaining.Hours())
+			remainingStyle.Style = fontstyle.Bold
+			remainingStyle.Color = &props.Color{Red: 220, Green: 38, Blue: 38}
+		}
+
+		m.AddRow(8,
+			col.New(1).Add(text.New(fmt.Sprintf("#%d", incident.ID), props.Text{Size: 9})),
+			col.New(5).Add(text.New(truncateString(incident.Title, 45), props.Text{Size: 9})),
+			col.New(2).Add(text.New(string(incident.Severity), props.Text{
+				Size:  9,
+				Style: fontstyle.Bold,
+				Color: s.getSeverityColor(incident.Severity),
+			})),
+			col.New(2).Add(text.New(incident.SLADeadline.In(loc).Format("01-02 15:04"), props.Text{Size: 9})),
+			col.New(2).Add(text.New(remainingText, remainingStyle)),
+		)
+	}
+	m.AddRow(5)
+}
+
+func (s *IncidentPDFService) addHandoffIncidentTable(m core.Maroto, title string, incidents []domain.HandoffIncident, loc *time.Location) {
+	s.addHandoffSectionTitle(m, title)
+	if len(incidents) == 0 {
+		s.addHandoffEmpty(m)
+		return
+	}
+
+	header := props.Text{Size: 9, Style: fontstyle.Bold}
+	m.AddRow(8,
+		col.New(1).Add(text.New("ID", header)),
+		col.New(4).Add(text.New("Title", header)),
+		col.New(2).Add(text.New("Severity", header)),
+		col.New(2).Add(text.New("Status", header)),
+		col.New(3).Add(text.New("Assignee", header)),
+	)
+
+	for _, incident := range incidents {
+		assignee := incident.AssigneeName
+		if assignee == "" {
+			assignee = "-"
+		}
+
+		m.AddRow(8,
+			col.New(1).Add(text.New(fmt.Sprintf("#%d", incident.ID), props.Text{Size: 9})),
+			col.New(4).Add(text.New(truncateString(incident.Title, 35), props.Text{Size: 9})),
+			col.New(2).Add(text.New(string(incident.Severity), props.Text{
+				Size:  9,
+				Style: fontstyle.Bold,
+				Color: s.getSeverityColor(incident.Severity),
+			})),
+			col.New(2).Add(text.New(formatStatus(string(incident.Status)), props.Text{
+				Size:  9,
+				Color: s.getStatusColor(incident.Status),
+			})),
+			col.New(3).Add(text.New(truncateString(assignee, 25), props.Text{Size: 9})),
+		)
+
+		for _, change := range incident.Changes {
+			m.AddRow(6,
+				col.New(1),
+				col.New(11).Add(text.New("- "+change, props.Text{
+					Size:  8,
+					Color: &props.Color{Red: 75, Green: 85, Blue: 99},
+				})),
+			)
+		}
+	}
+	m.AddRow(5)
+}
+
+func (s *IncidentPDFService) addHandoffEvents(m core.Maroto, events []domain.HandoffEvent, loc *time.Location) {
+	s.addHandoffSectionTitle(m, "Comments & Timeline")
+	if len(events) == 0 {
+		s.addHandoffEmpty(m)
+		return
+	}
+
+	for _, event := range events {
+		m.AddRow(6,
+			col.New(12).Add(text.New(
+				fmt.Sprintf("%s  #%d  %s  %s", event.At.In(loc).Format("01-02 15:04"), event.IncidentID, event.ActivityType, event.UserName),
+				props.Text{Size: 8, Style: fontstyle.Bold, Color: &props.Color{Red: 75, Green: 85, Blue: 99}},
+			)),
+		)
+		m.AddRow(8,
+			col.New(12).Add(text.New(truncateString(event.Comment, 200), props.Text{Size: 9})),
+		)
+	}
+}
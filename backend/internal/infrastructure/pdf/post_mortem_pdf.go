@@ -0,0 +1,377 @@
+package pdf
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/config"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+)
+
+// Horizontal indent per level of the causal tree, in mm
+const causalIndent = 6.0
+
+// GeneratePostMortemReport generates a PDF of a post-mortem (current content or a published version)
+// with the incident header and timeline, all sections, the causal analysis and the action items.
+// Times are shown in loc. The post-mortem must have Author loaded.
+func (s *IncidentPDFService) GeneratePostMortemReport(doc *domain.PostMortemDocument, loc *time.Location) ([]byte, error) {
+	cfg := config.NewBuilder().Build()
+	m := maroto.New(cfg)
+	pm := doc.PostMortem
+
+	s.addPostMortemHeader(m, doc, loc)
+	s.addPostMortemIncident(m, postMortemIncident(doc), loc)
+	s.addPostMortemTimeline(m, doc.Timeline, loc)
+	s.addPostMortemText(m, "Root Cause", pm.RootCause)
+	s.addPostMortemText(m, "Impact Analysis", pm.ImpactAnalysis)
+	for _, section := range pm.Sections {
+		s.addPostMortemSection(m, section)
+	}
+	s.addPostMortemWhys(m, "Five Whys", domain.ParseFiveWhys(pm.FiveWhysAnalysis))
+	s.addCausalTree(m, doc.CausalTree)
+	s.addPostMortemText(m, "What Went Well", pm.WhatWentWell)
+	s.addPostMortemText(m, "What Went Wrong", pm.WhatWentWrong)
+	s.addPostMortemText(m, "Lessons Learned", pm.LessonsLearned)
+	s.addPostMortemActionItems(m, pm.ActionItems, loc)
+
+	document, err := m.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate post-mortem PDF: %w", err)
+	}
+
+	return document.GetBytes(), nil
+}
+
+func (s *IncidentPDFService) addPostMortemHeader(m core.Maroto, doc *domain.PostMortemDocument, loc *time.Location) {
+	pm := doc.PostMortem
+	incident := postMortemIncident(doc)
+	m.AddRow(20,
+		col.New(12).Add(
+			text.New("Post-Mortem Report", props.Text{
+				Size:  20,
+				Style: fontstyle.Bold,
+				Align: align.Center,
+				Color: &props.Color{Red: 30, Green: 58, Blue: 138},
+			}),
+		),
+	)
+
+	if incident != nil {
+		m.AddAutoRow(
+			col.New(12).Add(
+				text.New(fmt.Sprintf("#%d %s", incident.ID, incident.Title), props.Text{
+					Size:  13,
+					Style: fontstyle.Bold,
+					Align: align.Center,
+				}),
+			),
+		)
+	}
+
+	author := "-"
+	if pm.Author != nil {
+		author = pm.Author.Name
+	}
+	meta := fmt.Sprintf("Status: %s   Author: %s   Created: %s", pm.Status, author, pm.CreatedAt.In(loc).Format("2006-01-02"))
+	if pm.PublishedAt != nil {
+		meta += fmt.Sprintf("   Published: %s", pm.PublishedAt.In(loc).Format("2006-01-02"))
+	}
+	m.AddRow(7,
+		col.New(12).Add(
+			text.New(meta, props.Text{
+				Size:  9,
+				Align: align.Center,
+				Top:   2,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+		),
+	)
+	m.AddRow(8,
+		col.New(12).Add(
+			text.New(postMortemVersionLabel(doc), props.Text{
+				Size:  9,
+				Style: fontstyle.Bold,
+				Align: align.Center,
+				Color: &props.Color{Red: 30, Green: 58, Blue: 138},
+			}),
+		),
+	)
+}
+
+// postMortemIncident returns the fully loaded incident of the document, falling back to the post-mortem's relation
+func postMortemIncident(doc *domain.PostMortemDocument) *domain.Incident {
+	if doc.Incident != nil {
+		return doc.Incident
+	}
+	return doc.PostMortem.Incident
+}
+
+// postMortemVersionLabel states which version of the post-mortem the document shows
+func postMortemVersionLabel(doc *domain.PostMortemDocument) string {
+	switch {
+	case doc.IsSnapshot && doc.Version < doc.LatestVersion:
+		return fmt.Sprintf("Version %d of %d (superseded)", doc.Version, doc.LatestVersion)
+	case doc.Version > 0:
+		return fmt.Sprintf("Version %d (latest published)", doc.Version)
+	case doc.LatestVersion > 0:
+		return fmt.Sprintf("Working copy - unpublished changes since version %d", doc.LatestVersion)
+	}
+	return "Draft - not yet published"
+}
+
+func (s *IncidentPDFService) addPostMortemText(m core.Maroto, title, body string) {
+	if body == "" {
+		return
+	}
+	s.addHandoffSectionTitle(m, title)
+	m.AddAutoRow(
+		col.New(12).Add(
+			text.New(body, props.Text{Size: 10}),
+		),
+	)
+	m.AddRow(4)
+}
+
+// addCausalTree renders the causal analysis as an indented outline, root causes highlighted
+func (s *IncidentPDFService) addCausalTree(m core.Maroto, tree *domain.CausalTree) {
+	if tree == nil || len(tree.Nodes) == 0 {
+		return
+	}
+
+	s.addHandoffSectionTitle(m, "Causal Analysis")
+	m.AddRow(7,
+		col.New(12).Add(
+			text.New(fmt.Sprintf("Root causes: %d   Contributing causes: %d", tree.RootCauses, tree.Contributing), props.Text{
+				Size:  9,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+		),
+	)
+
+	tree.Walk(func(node *domain.CausalTreeNode, depth int) {
+		label := "Contributing"
+		style := fontstyle.Normal
+		color := &props.Color{Red: 55, Green: 65, Blue: 81}
+		if node.IsRootCause {
+			label = "ROOT CAUSE"
+			style = fontstyle.Bold
+			color = &props.Color{Red: 185, Green: 28, Blue: 28}
+		}
+
+		m.AddAutoRow(
+			col.New(12).Add(
+				text.New(fmt.Sprintf("- [%s] [%s] %s", label, node.Category, node.Description), props.Text{
+					Size:  10,
+					Style: style,
+					Left:  float64(depth) * causalIndent,
+					Color: color,
+				}),
+			),
+		)
+	})
+	m.AddRow(4)
+}
+
+// addPostMortemIncident renders the incident header as a two-column table
+func (s *IncidentPDFService) addPostMortemIncident(m core.Maroto, incident *domain.Incident, loc *time.Location) {
+	if incident == nil {
+		return
+	}
+
+	assignee := "-"
+	if incident.Assignee != nil {
+		assignee = incident.Assignee.Name
+	}
+	tags := make([]string, 0, len(incident.Tags))
+	for _, tag := range incident.Tags {
+		tags = append(tags, tag.Name)
+	}
+	rows := [][2]string{
+		{"Service", valueOrDash(incident.Service)},
+		{"Impact Scope", valueOrDash(incident.ImpactScope)},
+		{"Assignee", assignee},
+		{"Tags", valueOrDash(strings.Join(tags, ", "))},
+		{"Detected", incident.DetectedAt.In(loc).Format("2006-01-02 15:04")},
+	}
+	if incident.ResolvedAt != nil {
+		duration := incident.ResolvedAt.Sub(incident.DetectedAt).Round(time.Minute)
+		rows = append(rows,
+			[2]string{"Resolved", incident.ResolvedAt.In(loc).Format("2006-01-02 15:04")},
+			[2]string{"Time to Resolve", fmt.Sprintf("%dh %dm", int(duration.Hours()), int(duration.Minutes())%60)},
+		)
+	}
+
+	s.addHandoffSectionTitle(m, "Incident")
+	label := props.Text{Size: 9, Style: fontstyle.Bold, Color: &props.Color{Red: 75, Green: 85, Blue: 99}}
+	m.AddRow(7,
+		col.New(3).Add(text.New("Severity", label)),
+		col.New(3).Add(text.New(string(incident.Severity), props.Text{
+			Size:  9,
+			Style: fontstyle.Bold,
+			Color: s.getSeverityColor(incident.Severity),
+		})),
+		col.New(3).Add(text.New("Status", label)),
+		col.New(3).Add(text.New(formatStatus(string(incident.Status)), props.Text{
+			Size:  9,
+			Color: s.getStatusColor(incident.Status),
+		})),
+	)
+	for _, row := range rows {
+		m.AddAutoRow(
+			col.New(3).Add(text.New(row[0], label)),
+			col.New(9).Add(text.New(row[1], props.Text{Size: 9})),
+		)
+	}
+	m.AddRow(4)
+	s.addPostMortemText(m, "Description", incident.Description)
+}
+
+func (s *IncidentPDFService) addPostMortemTimeline(m core.Maroto, timeline []*domain.IncidentActivity, loc *time.Location) {
+	if len(timeline) == 0 {
+		return
+	}
+
+	s.addHandoffSectionTitle(m, "Timeline")
+	header := props.Text{Size: 9, Style: fontstyle.Bold}
+	m.AddRow(8,
+		col.New(2).Add(text.New("Time", header)),
+		col.New(2).Add(text.New("Event", header)),
+		col.New(6).Add(text.New("Details", header)),
+		col.New(2).Add(text.New("By", header)),
+	)
+	for _, event := range timeline {
+		user := "-"
+		if event.User != nil {
+			user = event.User.Name
+		}
+		m.AddAutoRow(
+			col.New(2).Add(text.New(event.CreatedAt.In(loc).Format("01-02 15:04"), props.Text{Size: 9})),
+			col.New(2).Add(text.New(string(event.ActivityType), props.Text{Size: 9})),
+			col.New(6).Add(text.New(valueOrDash(event.Comment), props.Text{Size: 9})),
+			col.New(2).Add(text.New(truncateString(user, 20), props.Text{Size: 9})),
+		)
+	}
+	m.AddRow(4)
+}
+
+// addPostMortemSection renders a template section according to its type
+func (s *IncidentPDFService) addPostMortemSection(m core.Maroto, section domain.PostMortemSection) {
+	if section.IsEmpty() {
+		return
+	}
+
+	switch section.Type {
+	case domain.SectionTypeChecklist:
+		s.addHandoffSectionTitle(m, section.Title)
+		for _, item := range section.Checklist {
+			mark := "[ ]"
+			if item.Checked {
+				mark = "[x]"
+			}
+			m.AddAutoRow(
+				col.New(12).Add(text.New(fmt.Sprintf("%s %s", mark, item.Text), props.Text{Size: 10})),
+			)
+		}
+		m.AddRow(4)
+	case domain.SectionTypeTable:
+		s.addHandoffSectionTitle(m, section.Title)
+		if len(section.Columns) == 0 {
+			return
+		}
+		// maroto has a 12-column grid; wider tables keep the first 12 columns
+		columns := section.Columns
+		if len(columns) > 12 {
+			columns = columns[:12]
+		}
+		width := 12 / len(columns)
+		header := props.Text{Size: 9, Style: fontstyle.Bold}
+		cols := make([]core.Col, len(columns))
+		for i, column := range columns {
+			cols[i] = col.New(width).Add(text.New(column, header))
+		}
+		m.AddRow(8, cols...)
+		for _, row := range section.Rows {
+			cols := make([]core.Col, len(columns))
+			for i := range columns {
+				cell := ""
+				if i < len(row) {
+					cell = row[i]
+				}
+				cols[i] = col.New(width).Add(text.New(cell, props.Text{Size: 9}))
+			}
+			m.AddAutoRow(cols...)
+		}
+		m.AddRow(4)
+	case domain.SectionTypeFiveWhys:
+		s.addPostMortemWhys(m, section.Title, section.Whys)
+	default:
+		s.addPostMortemText(m, section.Title, section.Text)
+	}
+}
+
+func (s *IncidentPDFService) addPostMortemWhys(m core.Maroto, title string, whys []string) {
+	var filled []string
+	for _, why := range whys {
+		if strings.TrimSpace(why) != "" {
+			filled = append(filled, why)
+		}
+	}
+	if len(filled) == 0 {
+		return
+	}
+
+	s.addHandoffSectionTitle(m, title)
+	for i, why := range filled {
+		m.AddAutoRow(
+			col.New(12).Add(text.New(fmt.Sprintf("Why %d: %s", i+1, why), props.Text{Size: 10})),
+		)
+	}
+	m.AddRow(4)
+}
+
+func (s *IncidentPDFService) addPostMortemActionItems(m core.Maroto, items []domain.ActionItem, loc *time.Location) {
+	if len(items) == 0 {
+		return
+	}
+
+	s.addHandoffSectionTitle(m, "Action Items")
+	header := props.Text{Size: 9, Style: fontstyle.Bold}
+	m.AddRow(8,
+		col.New(5).Add(text.New("Title", header)),
+		col.New(3).Add(text.New("Owner", header)),
+		col.New(2).Add(text.New("Status", header)),
+		col.New(2).Add(text.New("Due", header)),
+	)
+	for _, item := range items {
+		owner := "-"
+		if item.Assignee != nil && item.Assignee.Name != "" {
+			owner = item.Assignee.Name
+		}
+		due := "-"
+		if item.DueDate != nil {
+			due = item.DueDate.In(loc).Format("2006-01-02")
+		}
+		m.AddAutoRow(
+			col.New(5).Add(text.New(fmt.Sprintf("%s (%s)", item.Title, item.Priority), props.Text{Size: 9})),
+			col.New(3).Add(text.New(truncateString(owner, 25), props.Text{Size: 9})),
+			col.New(2).Add(text.New(formatStatus(string(item.Status)), props.Text{Size: 9})),
+			col.New(2).Add(text.New(due, props.Text{Size: 9})),
+		)
+	}
+	m.AddRow(4)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,145 @@
+package pdf
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+
+	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/config"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+)
+
+// GenerateResponderLoadReport generates a standalone PDF of the responder load report
+func (s *IncidentPDFService) GenerateResponderLoadReport(report *domain.ResponderLoadReport) ([]byte, error) {
+	cfg := config.NewBuilder().Build()
+	m := maroto.New(cfg)
+
+	s.addSummaryHeader(m, report.Period.StartDate, report.Period.EndDate)
+	s.addResponderLoadSection(m, report)
+
+	document, err := m.Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate responder load PDF: %w", err)
+	}
+
+	return document.GetBytes(), nil
+}
+
+// addResponderLoadSection renders per-department and per-responder burden tables
+func (s *IncidentPDFService) addResponderLoadSection(m core.Maroto, report *domain.ResponderLoadReport) {
+	m.AddRow(18,
+		col.New(12).Add(
+			text.New("Responder Load", props.Text{
+				Size:  16,
+				Style: fontstyle.Bold,
+				Color: &props.Color{Red: 30, Green: 58, Blue: 138},
+			}),
+		),
+	)
+
+	m.AddRow(8,
+		col.New(12).Add(
+			text.New(fmt.Sprintf("Timezone: %s / After hours: weekdays outside %s / Night: %s",
+				report.Timezone, report.BusinessHours, report.NightHours), props.Text{
+				Size:  8,
+				Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+			}),
+		),
+	)
+
+	if len(report.Users) == 0 {
+		m.AddRow(10,
+			col.New(12).Add(
+				text.New("No responder activity in this period.", props.Text{
+					Size:  10,
+					Color: &props.Color{Red: 107, Green: 114, Blue: 128},
+				}),
+			),
+		)
+		return
+	}
+
+	// Departments
+	s.addLoadTableHeader(m, "Department")
+	for _, dept := range report.Departments {
+		s.addLoadTableRow(m, displayDepartment(dept.Department), dept.IncidentsHandled, dept.Interruptions,
+			dept.AfterHoursInterruptions, dept.WeekendInterruptions, dept.NightInterruptions,
+			dept.AssigneeHours, dept.MaxConsecutiveNights)
+	}
+
+	m.AddRow(8)
+
+	// Responders
+	s.addLoadTableHeader(m, "Responder")
+	for _, load := range report.Users {
+		name := load.UserName
+		if load.Department != "" {
+			name = fmt.Sprintf("%s (%s)", load.UserName, load.Department)
+		}
+		s.addLoadTableRow(m, name, load.IncidentsHandled, load.Interruptions,
+			load.AfterHoursInterruptions, load.WeekendInterruptions, load.NightInterruptions,
+			load.AssigneeHours, load.MaxConsecutiveNights)
+	}
+
+	m.AddRow(8)
+}
+
+func (s *IncidentPDFService) addLoadTableHeader(m core.Maroto, firstColumn string) {
+	headerStyle := props.Text{Size: 8, Style: fontstyle.Bold, Align: align.Center}
+	firstStyle := props.Text{Size: 8, Style: fontstyle.Bold}
+
+	m.AddRow(10,
+		col.New(3).Add(text.New(firstColumn, firstStyle)),
+		col.New(1).Add(text.New("Incidents", headerStyle)),
+		col.New(2).Add(text.New("Interruptions", headerStyle)),
+		col.New(1).Add(text.New("After hrs", headerStyle)),
+		col.New(1).Add(text.New("Weekend", headerStyle)),
+		col.New(1).Add(text.New("Night", headerStyle)),
+		col.New(2).Add(text.New("Assignee hrs", headerStyle)),
+		col.New(1).Add(text.New("Nights in row", headerStyle)),
+	)
+
+	m.AddRow(3,
+		col.New(12).Add(
+			text.New("═══════════════════════════════════════════════════════", props.Text{
+				Size:  8,
+				Align: align.Center,
+				Color: &props.Color{Red: 156, Green: 163, Blue: 175},
+			}),
+		),
+	)
+}
+
+func (s *IncidentPDFService) addLoadTableRow(m core.Maroto, name string, incidents, interruptions, afterHours, weekend, night int, assigneeHours float64, consecutiveNights int) {
+	cell := props.Text{Size: 9, Align: align.Center}
+
+	// Highlight streaks of three or more nights as a burnout warning
+	nightsStyle := cell
+	if consecutiveNights >= 3 {
+		nightsStyle.Style = fontstyle.Bold
+		nightsStyle.Color = &props.Color{Red: 220, Green: 38, Blue: 38}
+	}
+
+	m.AddRow(9,
+		col.New(3).Add(text.New(truncateString(name, 30), props.Text{Size: 9})),
+		col.New(1).Add(text.New(fmt.Sprintf("%d", incidents), cell)),
+		col.New(2).Add(text.New(fmt.Sprintf("%d", interruptions), cell)),
+		col.New(1).Add(text.New(fmt.Sprintf("%d", afterHours), cell)),
+		col.New(1).Add(text.New(fmt.Sprintf("%d", weekend), cell)),
+		col.New(1).Add(text.New(fmt.Sprintf("%d", night), cell)),
+		col.New(2).Add(text.New(fmt.Sprintf("%.1f", assigneeHours), cell)),
+		col.New(1).Add(text.New(fmt.Sprintf("%d", consecutiveNights), nightsStyle)),
+	)
+}
+
+func displayDepartment(department string) string {
+	if department == "" {
+		return "(No department)"
+	}
+	return department
+}
@@ -30,6 +30,16 @@ func (s *IncidentPDFService) GenerateSummaryReport(
 	// Add statistics cards
 	s.addStatisticsCards(m, stats)
 
+	// Add responder load if provided
+	if stats.ResponderLoad != nil {
+		s.addResponderLoadSection(m, stats.ResponderLoad)
+	}
+
+	// Add action item burndown and aging if provided
+	if stats.ActionItems != nil {
+		s.addActionItemSection(m, stats.ActionItems)
+	}
+
 	// Add incidents table
 	s.addEnhancedIncidentsTable(m, incidents)
 
@@ -48,6 +58,8 @@ type SummaryStats struct {
 	ResolvedCount    int
 	AverageMTTR      float64
 	SLAViolatedCount int
+	ResponderLoad    *domain.ResponderLoadReport // 任意
+	ActionItems      *domain.ActionItemReport    // 任意
 }
 
 func (s *IncidentPDFService) addSummaryHeader(m core.Maroto, startDate, endDate time.Time) {
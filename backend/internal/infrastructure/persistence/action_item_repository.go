@@ -4,8 +4,10 @@ import (
 	"context"
 	"incidex/internal/domain"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type actionItemRepository struct {
@@ -25,6 +27,10 @@ func (r *actionItemRepository) FindByID(ctx context.Context, id uint) (*domain.A
 	if err := r.db.WithContext(ctx).
 		Preload("PostMortem").
 		Preload("Assignee").
+		Preload("VerifiedBy").
+		Preload("Links", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Links.Incident").
+		Preload("BlockedBy").
 		First(&item, id).Error; err != nil {
 		return nil, err
 	}
@@ -43,6 +49,138 @@ func (r *actionItemRepository) FindByPostMortemID(ctx context.Context, postMorte
 	return items, nil
 }
 
+func (r *actionItemRepository) FindByIncidentID(ctx context.Context, incidentID uint) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Preload("Assignee").
+		Where("incident_id = ? OR id IN (?)", incidentID, linkedTo("incident_id", incidentID, r.db)).
+		Order("created_at DESC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) AttachToPostMortem(ctx context.Context, incidentID, postMortemID uint) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.ActionItem{}).
+		Where("incident_id = ? AND post_mortem_id IS NULL", incidentID).
+		Update("post_mortem_id", postMortemID)
+	return result.RowsAffected, result.Error
+}
+
+func (r *actionItemRepository) FindOpenWithDueDateByAssignee(ctx context.Context, assigneeID uint) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Preload("PostMortem").
+		Where("assignee_id = ? AND due_date IS NOT NULL AND status NOT IN ?", assigneeID, domain.DoneActionStatuses).
+		Order("due_date ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) FindOpenWithDueDate(ctx context.Context) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Preload("PostMortem").
+		Preload("Incident").
+		Where("assignee_id IS NOT NULL AND due_date IS NOT NULL AND status NOT IN ?", domain.DoneActionStatuses).
+		Order("due_date ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) FindOpenByAssignee(ctx context.Context, assigneeID uint) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Preload("Incident").
+		Where("assignee_id = ? AND status NOT IN ?", assigneeID, domain.DoneActionStatuses).
+		Order("due_date ASC NULLS LAST, CASE priority WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, id").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) UpdateNotificationState(ctx context.Context, item *domain.ActionItem) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.ActionItem{ID: item.ID}).
+		Select("due_soon_notified_at", "overdue_notified_at", "escalated_at").
+		Updates(item).Error
+}
+
+func (r *actionItemRepository) FindActiveBetween(ctx context.Context, start, end time.Time) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Preload("Assignee").
+		Where("created_at <= ? AND (completed_at IS NULL OR completed_at >= ?)", end, start).
+		Order("created_at").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) FindUnverified(ctx context.Context, completedBefore time.Time) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Preload("Assignee").
+		Where("status = ? AND completed_at < ?", domain.ActionStatusCompleted, completedBefore).
+		Order("completed_at").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) FindRepeatIncidents(ctx context.Context) ([]domain.RepeatIncidentMatch, error) {
+	var matches []domain.RepeatIncidentMatch
+	err := r.db.WithContext(ctx).Raw(`
+WITH item_incidents AS (
+	SELECT id AS action_item_id, incident_id FROM action_items WHERE incident_id IS NOT NULL
+	UNION SELECT action_item_id, incident_id FROM action_item_links
+)
+SELECT DISTINCT ai.id AS action_item_id, later.id AS incident_id, later.title, later.severity, later.service, later.detected_at,
+	(origin.service <> '' AND later.service = origin.service) AS same_service,
+	t.name AS tag_name
+FROM action_items AS ai
+JOIN item_incidents AS ii ON ii.action_item_id = ai.id
+JOIN incidents AS origin ON origin.id = ii.incident_id
+JOIN incidents AS later ON later.detected_at > ai.completed_at
+LEFT JOIN incident_tags AS ot ON ot.incident_id = origin.id
+LEFT JOIN incident_tags AS lt ON lt.incident_id = later.id AND lt.tag_id = ot.tag_id
+LEFT JOIN tags AS t ON t.id = lt.tag_id
+WHERE ai.status IN ? AND ai.completed_at IS NOT NULL
+	AND NOT EXISTS (SELECT 1 FROM item_incidents AS own WHERE own.action_item_id = ai.id AND own.incident_id = later.id)
+	AND ((origin.service <> '' AND later.service = origin.service) OR t.id IS NOT NULL)`,
+		domain.DoneActionStatuses).Scan(&matches).Error
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func (r *actionItemRepository) FindByExternalIssue(ctx context.Context, tracker domain.IssueTrackerKind, key string) (*domain.ActionItem, error) {
+	var item domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Where("external_tracker = ? AND external_key = ?", tracker, key).
+		First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *actionItemRepository) UpdateExternalIssue(ctx context.Context, item *domain.ActionItem) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.ActionItem{ID: item.ID}).
+		Select("external_tracker", "external_key", "external_url").
+		Updates(item).Error
+}
+
 func (r *actionItemRepository) Update(ctx context.Context, item *domain.ActionItem) error {
 	return r.db.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: false}).Save(item).Error
 }
@@ -68,6 +206,18 @@ func (r *actionItemRepository) FindAll(ctx context.Context, filters domain.Actio
 	if filters.AssigneeID != 0 {
 		query = query.Where("assignee_id = ?", filters.AssigneeID)
 	}
+	if filters.IncidentID != 0 {
+		query = query.Where("incident_id = ? OR id IN (?)", filters.IncidentID, linkedTo("incident_id", filters.IncidentID, r.db))
+	}
+	if filters.PostMortemID != 0 {
+		query = query.Where("post_mortem_id = ? OR id IN (?)", filters.PostMortemID, linkedTo("post_mortem_id", filters.PostMortemID, r.db))
+	}
+	switch filters.Source {
+	case domain.ActionItemSourceIncident:
+		query = query.Where("post_mortem_id IS NULL")
+	case domain.ActionItemSourcePostMortem:
+		query = query.Where("post_mortem_id IS NOT NULL")
+	}
 	if filters.Search != "" {
 		searchPattern := "%" + strings.ToLower(filters.Search) + "%"
 		query = query.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?",
@@ -123,3 +273,120 @@ func (r *actionItemRepository) FindAll(ctx context.Context, filters domain.Actio
 
 	return items, paginationResult, nil
 }
+
+// linkedTo selects the IDs of action items linked to the incident or post-mortem
+func linkedTo(column string, id uint, db *gorm.DB) *gorm.DB {
+	return db.Model(&domain.ActionItemLink{}).Select("action_item_id").Where(column+" = ?", id)
+}
+
+func (r *actionItemRepository) CreateLink(ctx context.Context, link *domain.ActionItemLink) error {
+	return r.db.WithContext(ctx).Create(link).Error
+}
+
+func (r *actionItemRepository) FindLinkByID(ctx context.Context, id uint) (*domain.ActionItemLink, error) {
+	var link domain.ActionItemLink
+	if err := r.db.WithContext(ctx).First(&link, id).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *actionItemRepository) DeleteLink(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.ActionItemLink{}, id).Error
+}
+
+func (r *actionItemRepository) AddDependency(ctx context.Context, itemID, blockedByID uint) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.ActionItemDependency{ActionItemID: itemID, BlockedByID: blockedByID}).Error
+}
+
+func (r *actionItemRepository) RemoveDependency(ctx context.Context, itemID, blockedByID uint) error {
+	return r.db.WithContext(ctx).
+		Where("action_item_id = ? AND blocked_by_id = ?", itemID, blockedByID).
+		Delete(&domain.ActionItemDependency{}).Error
+}
+
+func (r *actionItemRepository) FindAllDependencies(ctx context.Context) ([]domain.ActionItemDependency, error) {
+	var dependencies []domain.ActionItemDependency
+	if err := r.db.WithContext(ctx).Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+func (r *actionItemRepository) FindOpenBlockers(ctx context.Context, itemID uint) ([]*domain.ActionItem, error) {
+	var items []*domain.ActionItem
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN action_item_dependencies AS d ON d.blocked_by_id = action_items.id").
+		Where("d.action_item_id = ? AND action_items.status NOT IN ?", itemID, domain.DoneActionStatuses).
+		Order("action_items.id").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *actionItemRepository) FindRecurring(ctx context.Context, filters domain.RecurringRemediationFilters) ([]domain.RecurringRemediationCount, error) {
+	// Own incident and linked incidents of every action item
+	itemIncidents := r.db.Raw(
+		"SELECT id AS action_item_id, incident_id FROM action_items WHERE incident_id IS NOT NULL " +
+			"UNION SELECT action_item_id, incident_id FROM action_item_links")
+
+	query := r.db.WithContext(ctx).
+		Table("(?) AS ii", itemIncidents).
+		Joins("JOIN action_items AS ai ON ai.id = ii.action_item_id").
+		Select("ii.action_item_id, COUNT(DISTINCT ii.incident_id) AS incident_count").
+		Group("ii.action_item_id").
+		Having("COUNT(DISTINCT ii.incident_id) >= ?", filters.MinIncidents).
+		Order("incident_count DESC, ii.action_item_id")
+	if filters.Status != "" {
+		query = query.Where("ai.status = ?", filters.Status)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	var counts []domain.RecurringRemediationCount
+	if err := query.Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *actionItemRepository) FindIncidents(ctx context.Context, itemIDs []uint) (map[uint][]*domain.Incident, error) {
+	result := make(map[uint][]*domain.Incident)
+	if len(itemIDs) == 0 {
+		return result, nil
+	}
+	var rows []struct {
+		ActionItemID uint
+		IncidentID   uint
+	}
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT id AS action_item_id, incident_id FROM action_items WHERE incident_id IS NOT NULL AND id IN ? "+
+			"UNION SELECT action_item_id, incident_id FROM action_item_links WHERE action_item_id IN ?",
+		itemIDs, itemIDs).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	incidentIDs := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		incidentIDs = append(incidentIDs, row.IncidentID)
+	}
+	var incidents []*domain.Incident
+	if err := r.db.WithContext(ctx).Where("id IN ?", incidentIDs).Find(&incidents).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.Incident, len(incidents))
+	for _, incident := range incidents {
+		byID[incident.ID] = incident
+	}
+	for _, row := range rows {
+		if incident, ok := byID[row.IncidentID]; ok {
+			result[row.ActionItemID] = append(result[row.ActionItemID], incident)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type calendarFeedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedTokenRepository(db *gorm.DB) domain.CalendarFeedTokenRepository {
+	return &calendarFeedTokenRepository{db: db}
+}
+
+func (r *calendarFeedTokenRepository) FindByUserID(ctx context.Context, userID uint) (*domain.CalendarFeedToken, error) {
+	var token domain.CalendarFeedToken
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *calendarFeedTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.CalendarFeedToken, error) {
+	var token domain.CalendarFeedToken
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *calendarFeedTokenRepository) Replace(ctx context.Context, token *domain.CalendarFeedToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", token.UserID).Delete(&domain.CalendarFeedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *calendarFeedTokenRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.CalendarFeedToken{}).Error
+}
+
+func (r *calendarFeedTokenRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.CalendarFeedToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
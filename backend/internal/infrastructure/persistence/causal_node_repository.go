@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type causalNodeRepository struct {
+	db *gorm.DB
+}
+
+func NewCausalNodeRepository(db *gorm.DB) domain.CausalNodeRepository {
+	return &causalNodeRepository{db: db}
+}
+
+func (r *causalNodeRepository) Create(ctx context.Context, node *domain.CausalNode) error {
+	return r.db.WithContext(ctx).Create(node).Error
+}
+
+func (r *causalNodeRepository) FindByID(ctx context.Context, id uint) (*domain.CausalNode, error) {
+	var node domain.CausalNode
+	if err := r.db.WithContext(ctx).First(&node, id).Error; err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func (r *causalNodeRepository) FindByPostMortemID(ctx context.Context, postMortemID uint) ([]domain.CausalNode, error) {
+	var nodes []domain.CausalNode
+	err := r.db.WithContext(ctx).
+		Where("post_mortem_id = ?", postMortemID).
+		Order("position ASC, id ASC").
+		Find(&nodes).Error
+	return nodes, err
+}
+
+func (r *causalNodeRepository) Update(ctx context.Context, node *domain.CausalNode) error {
+	return r.db.WithContext(ctx).Save(node).Error
+}
+
+func (r *causalNodeRepository) Delete(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Delete(&domain.CausalNode{}, ids).Error
+}
+
+func (r *causalNodeRepository) GetCategorySummary(ctx context.Context, startDate, endDate time.Time, status domain.PMStatus) (*domain.CausalCategorySummary, error) {
+	base := func() *gorm.DB {
+		query := r.db.WithContext(ctx).
+			Table("causal_nodes").
+			Joins("JOIN post_mortems ON post_mortems.id = causal_nodes.post_mortem_id").
+			Where("post_mortems.created_at >= ? AND post_mortems.created_at < ?", startDate, endDate)
+		if status != "" {
+			query = query.Where("post_mortems.status = ?", status)
+		}
+		return query
+	}
+
+	var rows []struct {
+		Category    domain.CausalCategory
+		Total       int
+		RootCauses  int
+		PostMortems int
+	}
+	if err := base().
+		Select(`causal_nodes.category AS category,
+			COUNT(*) AS total,
+			SUM(CASE WHEN causal_nodes.is_root_cause THEN 1 ELSE 0 END) AS root_causes,
+			COUNT(DISTINCT causal_nodes.post_mortem_id) AS post_mortems`).
+		Group("causal_nodes.category").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var postMortems int64
+	if err := base().Distinct("causal_nodes.post_mortem_id").Count(&postMortems).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &domain.CausalCategorySummary{
+		Period: domain.ReportPeriod{
+			StartDate: startDate,
+			EndDate:   endDate,
+			Month:     int(startDate.Month()),
+			Year:      startDate.Year(),
+		},
+		PostMortems: int(postMortems),
+		Categories:  make([]domain.CausalCategoryStat, 0, len(domain.CausalCategories)),
+	}
+
+	byCategory := make(map[domain.CausalCategory]domain.CausalCategoryStat)
+	for _, row := range rows {
+		byCategory[row.Category] = domain.CausalCategoryStat{
+			Category:     row.Category,
+			Total:        row.Total,
+			RootCauses:   row.RootCauses,
+			Contributing: row.Total - row.RootCauses,
+			PostMortems:  row.PostMortems,
+		}
+		summary.RootCauses += row.RootCauses
+	}
+	for _, category := range domain.CausalCategories {
+		stat, ok := byCategory[category]
+		if !ok {
+			stat = domain.CausalCategoryStat{Category: category}
+		}
+		if summary.RootCauses > 0 {
+			stat.RootCauseShare = float64(stat.RootCauses) / float64(summary.RootCauses) * 100
+		}
+		summary.Categories = append(summary.Categories, stat)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type escalationPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewEscalationPolicyRepository(db *gorm.DB) domain.EscalationPolicyRepository {
+	return &escalationPolicyRepository{db: db}
+}
+
+func (r *escalationPolicyRepository) Create(ctx context.Context, policy *domain.EscalationPolicy) error {
+	return r.db.WithContext(ctx).Create(policy).Error
+}
+
+func (r *escalationPolicyRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Tag").
+		Preload("Creator").
+		Preload("Levels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("level_number ASC")
+		}).
+		Preload("Levels.Targets")
+}
+
+func (r *escalationPolicyRepository) FindAll(ctx context.Context) ([]*domain.EscalationPolicy, error) {
+	var policies []*domain.EscalationPolicy
+	if err := r.preload(r.db.WithContext(ctx)).
+		Order("name ASC").
+		Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *escalationPolicyRepository) FindActive(ctx context.Context) ([]*domain.EscalationPolicy, error) {
+	var policies []*domain.EscalationPolicy
+	if err := r.preload(r.db.WithContext(ctx)).
+		Where("is_active = ?", true).
+		Order("id ASC").
+		Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *escalationPolicyRepository) FindByID(ctx context.Context, id uint) (*domain.EscalationPolicy, error) {
+	var policy domain.EscalationPolicy
+	if err := r.preload(r.db.WithContext(ctx)).First(&policy, id).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *escalationPolicyRepository) Update(ctx context.Context, policy *domain.EscalationPolicy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Replace levels and their targets
+		var levelIDs []uint
+		if err := tx.Model(&domain.EscalationLevel{}).
+			Where("policy_id = ?", policy.ID).
+			Pluck("id", &levelIDs).Error; err != nil {
+			return err
+		}
+		if len(levelIDs) > 0 {
+			if err := tx.Where("level_id IN ?", levelIDs).Delete(&domain.EscalationTarget{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("policy_id = ?", policy.ID).Delete(&domain.EscalationLevel{}).Error; err != nil {
+				return err
+			}
+		}
+
+		levels := policy.Levels
+		policy.Levels = nil
+		if err := tx.Omit("Tag", "Creator").Save(policy).Error; err != nil {
+			return err
+		}
+
+		for i := range levels {
+			levels[i].ID = 0
+			levels[i].PolicyID = policy.ID
+			for j := range levels[i].Targets {
+				levels[i].Targets[j].ID = 0
+			}
+		}
+		if len(levels) > 0 {
+			if err := tx.Create(&levels).Error; err != nil {
+				return err
+			}
+		}
+		policy.Levels = levels
+		return nil
+	})
+}
+
+func (r *escalationPolicyRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.EscalationPolicy{}, id).Error
+}
+
+type incidentEscalationRepository struct {
+	db *gorm.DB
+}
+
+func NewIncidentEscalationRepository(db *gorm.DB) domain.IncidentEscalationRepository {
+	return &incidentEscalationRepository{db: db}
+}
+
+func (r *incidentEscalationRepository) Create(ctx context.Context, escalation *domain.IncidentEscalation) error {
+	return r.db.WithContext(ctx).Omit("Policy").Create(escalation).Error
+}
+
+func (r *incidentEscalationRepository) FindByIncidentID(ctx context.Context, incidentID uint) (*domain.IncidentEscalation, error) {
+	var escalation domain.IncidentEscalation
+	if err := r.db.WithContext(ctx).
+		Preload("Policy").
+		Preload("Policy.Levels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("level_number ASC")
+		}).
+		Preload("Policy.Levels.Targets").
+		Where("incident_id = ?", incidentID).
+		First(&escalation).Error; err != nil {
+		return nil, err
+	}
+	return &escalation, nil
+}
+
+func (r *incidentEscalationRepository) FindDue(ctx context.Context, now time.Time) ([]*domain.IncidentEscalation, error) {
+	var escalations []*domain.IncidentEscalation
+	if err := r.db.WithContext(ctx).
+		Preload("Policy").
+		Preload("Policy.Levels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("level_number ASC")
+		}).
+		Preload("Policy.Levels.Targets").
+		Where("status = ?", domain.EscalationStatusActive).
+		Where("next_escalation_at IS NOT NULL AND next_escalation_at <= ?", now).
+		Order("next_escalation_at ASC").
+		Find(&escalations).Error; err != nil {
+		return nil, err
+	}
+	return escalations, nil
+}
+
+func (r *incidentEscalationRepository) Update(ctx context.Context, escalation *domain.IncidentEscalation) error {
+	return r.db.WithContext(ctx).Omit("Policy").Save(escalation).Error
+}
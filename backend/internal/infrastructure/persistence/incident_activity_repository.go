@@ -18,11 +18,27 @@ func (r *incidentActivityRepository) Create(activity *domain.IncidentActivity) e
 	return r.db.Create(activity).Error
 }
 
+// CreateBatch creates all activities in a single transaction
+func (r *incidentActivityRepository) CreateBatch(activities []*domain.IncidentActivity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(activities, 100).Error
+	})
+}
+
+func (r *incidentActivityRepository) FindByID(id uint) (*domain.IncidentActivity, error) {
+	var activity domain.IncidentActivity
+	if err := r.db.Preload("User").First(&activity, id).Error; err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
 func (r *incidentActivityRepository) FindByIncidentID(incidentID uint, limit int) ([]*domain.IncidentActivity, error) {
 	var activities []*domain.IncidentActivity
 	query := r.db.Where("incident_id = ?", incidentID).
 		Preload("User").
-		Order("created_at DESC")
+		Order("created_at DESC").
+		Order("id DESC")
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -49,3 +65,57 @@ func (r *incidentActivityRepository) FindRecent(limit int) ([]*domain.IncidentAc
 	}
 	return activities, nil
 }
+
+// FindTimelineByIncidentID returns the timeline events of an incident ordered by event time
+func (r *incidentActivityRepository) FindTimelineByIncidentID(incidentID uint) ([]*domain.IncidentActivity, error) {
+	var activities []*domain.IncidentActivity
+	err := r.db.Where("incident_id = ?", incidentID).
+		Where("activity_type IN ?", domain.TimelineEventTypes).
+		Preload("User").
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// UpdateTimelineEvent saves the edited event together with the revision holding its previous values
+func (r *incidentActivityRepository) UpdateTimelineEvent(activity *domain.IncidentActivity, revision *domain.TimelineEventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.IncidentActivity{}).
+			Where("id = ?", activity.ID).
+			Updates(map[string]interface{}{
+				"activity_type": activity.ActivityType,
+				"created_at":    activity.CreatedAt,
+				"comment":       activity.Comment,
+			}).Error
+	})
+}
+
+// DeleteTimelineEvent deletes the event, keeping its values in the revision
+func (r *incidentActivityRepository) DeleteTimelineEvent(activity *domain.IncidentActivity, revision *domain.TimelineEventRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.IncidentActivity{}, activity.ID).Error
+	})
+}
+
+func (r *incidentActivityRepository) FindRevisionsByIncidentID(incidentID uint) ([]*domain.TimelineEventRevision, error) {
+	var revisions []*domain.TimelineEventRevision
+	err := r.db.Where("incident_id = ?", incidentID).
+		Preload("Editor").
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
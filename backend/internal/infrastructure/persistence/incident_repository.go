@@ -2,8 +2,10 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"incidex/internal/domain"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,7 +19,12 @@ func NewIncidentRepository(db *gorm.DB) domain.IncidentRepository {
 }
 
 func (r *incidentRepository) Create(ctx context.Context, incident *domain.Incident) error {
-	return r.db.WithContext(ctx).Create(incident).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(incident).Error; err != nil {
+			return err
+		}
+		return syncAssignment(tx, incident.ID, incident.AssigneeID, time.Now())
+	})
 }
 
 func (r *incidentRepository) FindAll(ctx context.Context, filters domain.IncidentFilters, pagination domain.Pagination) ([]*domain.Incident, *domain.PaginationResult, error) {
@@ -37,6 +44,9 @@ func (r *incidentRepository) FindAll(ctx context.Context, filters domain.Inciden
 	if filters.AssignedToID != nil {
 		query = query.Where("assignee_id = ?", *filters.AssignedToID)
 	}
+	if filters.Service != "" {
+		query = query.Where("service = ?", filters.Service)
+	}
 	if len(filters.TagIDs) > 0 {
 		query = query.Joins("JOIN incident_tags ON incident_tags.incident_id = incidents.id").
 			Where("incident_tags.tag_id IN ?", filters.TagIDs).
@@ -121,6 +131,7 @@ func (r *incidentRepository) FindByID(ctx context.Context, id uint) (*domain.Inc
 	var incident domain.Incident
 	if err := r.db.WithContext(ctx).
 		Preload("Assignee").
+		Preload("AcknowledgedBy").
 		Preload("Creator").
 		Preload("Tags").
 		First(&incident, id).Error; err != nil {
@@ -130,7 +141,44 @@ func (r *incidentRepository) FindByID(ctx context.Context, id uint) (*domain.Inc
 }
 
 func (r *incidentRepository) Update(ctx context.Context, incident *domain.Incident) error {
-	return r.db.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: false}).Save(incident).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: false}).Save(incident).Error; err != nil {
+			return err
+		}
+		return syncAssignment(tx, incident.ID, incident.AssigneeID, time.Now())
+	})
+}
+
+// syncAssignment keeps the assignment history in line with the incident's current assignee.
+// The open assignment is closed when the assignee changes and a new one is opened for the new assignee.
+func syncAssignment(tx *gorm.DB, incidentID uint, assigneeID *uint, at time.Time) error {
+	var current domain.IncidentAssignment
+	err := tx.Where("incident_id = ? AND unassigned_at IS NULL", incidentID).
+		Order("assigned_at DESC").
+		First(&current).Error
+	hasCurrent := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if hasCurrent && assigneeID != nil && current.UserID == *assigneeID {
+		return nil
+	}
+	if hasCurrent {
+		if err := tx.Model(&domain.IncidentAssignment{}).
+			Where("incident_id = ? AND unassigned_at IS NULL", incidentID).
+			Update("unassigned_at", at).Error; err != nil {
+			return err
+		}
+	}
+	if assigneeID == nil {
+		return nil
+	}
+	return tx.Create(&domain.IncidentAssignment{
+		IncidentID: incidentID,
+		UserID:     *assigneeID,
+		AssignedAt: at,
+	}).Error
 }
 
 func (r *incidentRepository) Delete(ctx context.Context, id uint) error {
@@ -0,0 +1,269 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Searchable document of a published post-mortem. The 'simple' configuration does not stem words,
+// so it behaves the same for English and Japanese; substring matching (kbPostMortemText, kbTitleText)
+// covers Japanese sentences that the parser cannot split into words. The post-mortem and incident
+// parts are kept apart so that each can use its own GIN index (migration 20250101000021).
+const (
+	kbPostMortemDocument = "setweight(to_tsvector('simple', COALESCE(pm.root_cause, '')), 'A') || " +
+		"setweight(to_tsvector('simple', COALESCE(pm.lessons_learned, '')), 'A') || " +
+		"setweight(to_tsvector('simple', COALESCE(pm.what_went_wrong, '')), 'B')"
+	kbTitleDocument  = "setweight(to_tsvector('simple', COALESCE(i.title, '')), 'C')"
+	kbDocument       = "(" + kbPostMortemDocument + ") || " + kbTitleDocument
+	kbPostMortemText = "LOWER(COALESCE(pm.root_cause, '') || ' ' || COALESCE(pm.lessons_learned, '') || ' ' || " +
+		"COALESCE(pm.what_went_wrong, ''))"
+	kbTitleText = "LOWER(COALESCE(i.title, ''))"
+	// Relevance added per term found as a substring
+	kbSubstringWeight = 0.1
+	// Boosts of similar post-mortem suggestions
+	kbSameServiceBoost = 0.3
+	kbSharedTagBoost   = 0.2
+)
+
+type knowledgeBaseRepository struct {
+	db *gorm.DB
+}
+
+func NewKnowledgeBaseRepository(db *gorm.DB) domain.KnowledgeBaseRepository {
+	return &knowledgeBaseRepository{db: db}
+}
+
+// published starts a query over published post-mortems joined with their incidents
+func (r *knowledgeBaseRepository) published(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("post_mortems AS pm").
+		Joins("JOIN incidents AS i ON i.id = pm.incident_id").
+		Where("pm.status = ?", domain.PMStatusPublished)
+}
+
+// matching applies the filters and search terms of the query; every term must occur
+func (r *knowledgeBaseRepository) matching(ctx context.Context, query domain.KnowledgeBaseQuery) *gorm.DB {
+	db := r.published(ctx)
+	if len(query.TagIDs) > 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM incident_tags AS it WHERE it.incident_id = i.id AND it.tag_id IN ?)", query.TagIDs)
+	}
+	if query.Service != "" {
+		db = db.Where("i.service = ?", query.Service)
+	}
+	if query.Severity != "" {
+		db = db.Where("i.severity = ?", query.Severity)
+	}
+	if query.Category != "" {
+		db = db.Where("EXISTS (SELECT 1 FROM causal_nodes AS cn WHERE cn.post_mortem_id = pm.id AND cn.category = ?)", query.Category)
+	}
+
+	terms := query.Terms()
+	if len(terms) == 0 {
+		return db
+	}
+	substring := make([]string, len(terms))
+	args := []interface{}{strings.Join(terms, " "), strings.Join(terms, " ")}
+	for i, term := range terms {
+		substring[i] = kbSubstring
+		args = append(args, likePattern(term), likePattern(term))
+	}
+	return db.Where(documentMatches("plainto_tsquery('simple', ?)")+" OR ("+strings.Join(substring, " AND ")+")", args...)
+}
+
+// kbSubstring is true when a term (given twice) occurs in the post-mortem or the incident title
+const kbSubstring = "(" + kbPostMortemText + " LIKE ? OR " + kbTitleText + " LIKE ?)"
+
+// documentMatches matches the search document against a tsquery whose argument is given twice
+func documentMatches(tsquery string) string {
+	return "((" + kbPostMortemDocument + ") @@ " + tsquery + " OR " + kbTitleDocument + " @@ " + tsquery + ")"
+}
+
+func (r *knowledgeBaseRepository) Search(ctx context.Context, query domain.KnowledgeBaseQuery, pagination domain.Pagination) ([]domain.KnowledgeBaseMatch, int64, error) {
+	var total int64
+	if err := r.matching(ctx, query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	score, args := "0", []interface{}{}
+	if terms := query.Terms(); len(terms) > 0 {
+		score, args = termScore(terms)
+		score = "ts_rank(" + kbDocument + ", plainto_tsquery('simple', ?)) + " + score
+		args = append([]interface{}{strings.Join(terms, " ")}, args...)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 20
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	var matches []domain.KnowledgeBaseMatch
+	err := r.matching(ctx, query).
+		Select("pm.id AS post_mortem_id, "+score+" AS score", args...).
+		Order("score DESC, pm.published_at DESC, pm.id DESC").
+		Offset((pagination.Page - 1) * pagination.Limit).
+		Limit(pagination.Limit).
+		Scan(&matches).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return matches, total, nil
+}
+
+func (r *knowledgeBaseRepository) Facets(ctx context.Context, query domain.KnowledgeBaseQuery) (*domain.KnowledgeBaseFacets, error) {
+	facets := &domain.KnowledgeBaseFacets{
+		Tags:             []domain.KnowledgeBaseFacetCount{},
+		Services:         []domain.KnowledgeBaseFacetCount{},
+		CausalCategories: []domain.KnowledgeBaseFacetCount{},
+	}
+
+	if err := r.matching(ctx, query).
+		Joins("JOIN incident_tags AS ft ON ft.incident_id = i.id").
+		Joins("JOIN tags AS t ON t.id = ft.tag_id").
+		Select("CAST(t.id AS TEXT) AS value, t.name AS label, COUNT(DISTINCT pm.id) AS count").
+		Group("t.id, t.name").
+		Order("count DESC, t.name").
+		Scan(&facets.Tags).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.matching(ctx, query).
+		Where("i.service <> ''").
+		Select("i.service AS value, COUNT(DISTINCT pm.id) AS count").
+		Group("i.service").
+		Order("count DESC, i.service").
+		Scan(&facets.Services).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.matching(ctx, query).
+		Joins("JOIN causal_nodes AS fc ON fc.post_mortem_id = pm.id").
+		Select("fc.category AS value, COUNT(DISTINCT pm.id) AS count").
+		Group("fc.category").
+		Order("count DESC, fc.category").
+		Scan(&facets.CausalCategories).Error; err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// FindSimilar ranks published post-mortems by the share of the terms they contain, boosted for the same
+// service and shared tags. A post-mortem qualifies through any of the terms, the service or a tag.
+func (r *knowledgeBaseRepository) FindSimilar(ctx context.Context, terms []string, service string, tagIDs []uint, excludeIncidentIDs []uint, limit int) ([]domain.KnowledgeBaseMatch, error) {
+	var (
+		score, conditions []string
+		scoreArgs, args   []interface{}
+	)
+	if len(terms) > 0 {
+		hits, hitArgs := termScore(terms)
+		score = append(score, fmt.Sprintf("(%s) / %g", hits, float64(len(terms))*kbSubstringWeight))
+		scoreArgs = append(scoreArgs, hitArgs...)
+
+		// Any single term is enough, both as a word of the document and as a substring
+		query := strings.Join(terms, " or ")
+		matches := []string{documentMatches("websearch_to_tsquery('simple', ?)")}
+		args = append(args, query, query)
+		for _, term := range terms {
+			matches = append(matches, kbSubstring)
+			args = append(args, likePattern(term), likePattern(term))
+		}
+		conditions = append(conditions, strings.Join(matches, " OR "))
+	}
+	if service != "" {
+		score = append(score, fmt.Sprintf("CASE WHEN i.service = ? THEN %g ELSE 0 END", kbSameServiceBoost))
+		scoreArgs = append(scoreArgs, service)
+		conditions = append(conditions, "i.service = ?")
+		args = append(args, service)
+	}
+	if len(tagIDs) > 0 {
+		sharedTags := "(SELECT COUNT(*) FROM incident_tags AS it WHERE it.incident_id = i.id AND it.tag_id IN ?)"
+		score = append(score, fmt.Sprintf("%g * %s", kbSharedTagBoost, sharedTags))
+		scoreArgs = append(scoreArgs, tagIDs)
+		conditions = append(conditions, sharedTags+" > 0")
+		args = append(args, tagIDs)
+	}
+	if len(conditions) == 0 {
+		return []domain.KnowledgeBaseMatch{}, nil
+	}
+
+	db := r.published(ctx)
+	if len(excludeIncidentIDs) > 0 {
+		db = db.Where("pm.incident_id NOT IN ?", excludeIncidentIDs)
+	}
+
+	var matches []domain.KnowledgeBaseMatch
+	err := db.
+		Where("("+strings.Join(conditions, ") OR (")+")", args...).
+		Select("pm.id AS post_mortem_id, "+strings.Join(score, " + ")+" AS score", scoreArgs...).
+		Order("score DESC, pm.published_at DESC").
+		Limit(limit).
+		Scan(&matches).Error
+	if err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+func (r *knowledgeBaseRepository) FindPostMortems(ctx context.Context, ids []uint) ([]*domain.PostMortem, error) {
+	var postMortems []*domain.PostMortem
+	if len(ids) == 0 {
+		return postMortems, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("Incident").
+		Preload("Incident.Tags").
+		Where("id IN ?", ids).
+		Find(&postMortems).Error
+	if err != nil {
+		return nil, err
+	}
+	return postMortems, nil
+}
+
+func (r *knowledgeBaseRepository) FindCausalCategories(ctx context.Context, postMortemIDs []uint) (map[uint][]domain.CausalCategory, error) {
+	categories := make(map[uint][]domain.CausalCategory)
+	if len(postMortemIDs) == 0 {
+		return categories, nil
+	}
+
+	var rows []struct {
+		PostMortemID uint
+		Category     domain.CausalCategory
+	}
+	err := r.db.WithContext(ctx).
+		Model(&domain.CausalNode{}).
+		Distinct("post_mortem_id", "category").
+		Where("post_mortem_id IN ?", postMortemIDs).
+		Order("post_mortem_id, category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		categories[row.PostMortemID] = append(categories[row.PostMortemID], row.Category)
+	}
+	return categories, nil
+}
+
+// termScore adds kbSubstringWeight for every term that occurs in the post-mortem
+func termScore(terms []string) (string, []interface{}) {
+	parts := make([]string, len(terms))
+	args := make([]interface{}, 2*len(terms))
+	for i, term := range terms {
+		parts[i] = fmt.Sprintf("CASE WHEN %s THEN %g ELSE 0 END", kbSubstring, kbSubstringWeight)
+		args[2*i], args[2*i+1] = likePattern(term), likePattern(term)
+	}
+	return strings.Join(parts, " + "), args
+}
+
+// likePattern matches the term anywhere, with LIKE wildcards in the term taken literally
+func likePattern(term string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(term))
+	return "%" + escaped + "%"
+}
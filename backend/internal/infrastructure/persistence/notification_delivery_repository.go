@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type notificationDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDeliveryRepository(db *gorm.DB) domain.NotificationDeliveryRepository {
+	return &notificationDeliveryRepository{db: db}
+}
+
+func (r *notificationDeliveryRepository) Create(ctx context.Context, delivery *domain.NotificationDelivery) error {
+	return r.db.WithContext(ctx).Omit("User").Create(delivery).Error
+}
+
+func (r *notificationDeliveryRepository) FindAll(ctx context.Context, filters domain.NotificationDeliveryFilters, pagination domain.Pagination) ([]*domain.NotificationDelivery, int64, error) {
+	var deliveries []*domain.NotificationDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.NotificationDelivery{})
+	if filters.UserID != nil {
+		query = query.Where("user_id = ?", *filters.UserID)
+	}
+	if filters.IncidentID != nil {
+		query = query.Where("incident_id = ?", *filters.IncidentID)
+	}
+	if filters.Channel != "" {
+		query = query.Where("channel = ?", filters.Channel)
+	}
+	if filters.EventType != "" {
+		query = query.Where("event_type = ?", filters.EventType)
+	}
+	if filters.Result != "" {
+		query = query.Where("result = ?", filters.Result)
+	}
+	if filters.Recipient != "" {
+		query = query.Where("LOWER(recipient) LIKE ?", likePattern(filters.Recipient))
+	}
+	if filters.StartDate != nil {
+		query = query.Where("created_at >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("created_at <= ?", *filters.EndDate)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	if err := query.Preload("User").
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(pagination.Limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
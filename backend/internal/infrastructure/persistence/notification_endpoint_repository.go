@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type notificationEndpointRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationEndpointRepository(db *gorm.DB) domain.NotificationEndpointRepository {
+	return &notificationEndpointRepository{db: db}
+}
+
+func (r *notificationEndpointRepository) Create(ctx context.Context, endpoint *domain.NotificationEndpoint) error {
+	return r.db.WithContext(ctx).Create(endpoint).Error
+}
+
+func (r *notificationEndpointRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.NotificationEndpoint, error) {
+	var endpoints []*domain.NotificationEndpoint
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *notificationEndpointRepository) FindByID(ctx context.Context, id uint) (*domain.NotificationEndpoint, error) {
+	var endpoint domain.NotificationEndpoint
+	if err := r.db.WithContext(ctx).First(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *notificationEndpointRepository) Update(ctx context.Context, endpoint *domain.NotificationEndpoint) error {
+	return r.db.WithContext(ctx).Save(endpoint).Error
+}
+
+func (r *notificationEndpointRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.NotificationEndpoint{}, id).Error
+}
@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationOutboxRepository(db *gorm.DB) domain.NotificationOutboxRepository {
+	return &notificationOutboxRepository{db: db}
+}
+
+func (r *notificationOutboxRepository) Create(ctx context.Context, message *domain.NotificationOutbox) error {
+	return r.db.WithContext(ctx).Create(message).Error
+}
+
+func (r *notificationOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.NotificationOutbox, error) {
+	var messages []*domain.NotificationOutbox
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several server instances drain the outbox without waiting on each other
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.OutboxPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&messages).Error; err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(messages))
+		for i, m := range messages {
+			ids[i] = m.ID
+		}
+		return tx.Model(&domain.NotificationOutbox{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *notificationOutboxRepository) Update(ctx context.Context, message *domain.NotificationOutbox) error {
+	return r.db.WithContext(ctx).Save(message).Error
+}
+
+func (r *notificationOutboxRepository) FindAll(ctx context.Context, filters domain.NotificationOutboxFilters, pagination domain.Pagination) ([]*domain.NotificationOutbox, int64, error) {
+	var messages []*domain.NotificationOutbox
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.NotificationOutbox{})
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.Channel != "" {
+		query = query.Where("channel = ?", filters.Channel)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pagination.Page - 1) * pagination.Limit
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(pagination.Limit).Find(&messages).Error; err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+func (r *notificationOutboxRepository) FindByID(ctx context.Context, id uint) (*domain.NotificationOutbox, error) {
+	var message domain.NotificationOutbox
+	if err := r.db.WithContext(ctx).First(&message, id).Error; err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *notificationOutboxRepository) ReplayDead(ctx context.Context, channel domain.NotificationChannel, now time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.NotificationOutbox{}).Where("status = ?", domain.OutboxDead)
+	if channel != "" {
+		query = query.Where("channel = ?", channel)
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":          domain.OutboxPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+	})
+	return result.RowsAffected, result.Error
+}
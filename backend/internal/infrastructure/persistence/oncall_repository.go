@@ -0,0 +1,145 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type onCallScheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewOnCallScheduleRepository(db *gorm.DB) domain.OnCallScheduleRepository {
+	return &onCallScheduleRepository{db: db}
+}
+
+func (r *onCallScheduleRepository) Create(ctx context.Context, schedule *domain.OnCallSchedule) error {
+	return r.db.WithContext(ctx).Create(schedule).Error
+}
+
+func (r *onCallScheduleRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Tag").
+		Preload("Creator").
+		Preload("Layers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("layer_order ASC")
+		}).
+		Preload("Layers.Participants", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Preload("Layers.Participants.User")
+}
+
+func (r *onCallScheduleRepository) FindAll(ctx context.Context) ([]*domain.OnCallSchedule, error) {
+	var schedules []*domain.OnCallSchedule
+	if err := r.preload(r.db.WithContext(ctx)).
+		Order("name ASC").
+		Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *onCallScheduleRepository) FindByID(ctx context.Context, id uint) (*domain.OnCallSchedule, error) {
+	var schedule domain.OnCallSchedule
+	if err := r.preload(r.db.WithContext(ctx)).First(&schedule, id).Error; err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *onCallScheduleRepository) FindAutoAssign(ctx context.Context) ([]*domain.OnCallSchedule, error) {
+	var schedules []*domain.OnCallSchedule
+	if err := r.preload(r.db.WithContext(ctx)).
+		Where("auto_assign = ?", true).
+		Order("id ASC").
+		Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (r *onCallScheduleRepository) Update(ctx context.Context, schedule *domain.OnCallSchedule) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Replace layers and their participants
+		var layerIDs []uint
+		if err := tx.Model(&domain.OnCallLayer{}).
+			Where("schedule_id = ?", schedule.ID).
+			Pluck("id", &layerIDs).Error; err != nil {
+			return err
+		}
+		if len(layerIDs) > 0 {
+			if err := tx.Where("layer_id IN ?", layerIDs).Delete(&domain.OnCallParticipant{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("schedule_id = ?", schedule.ID).Delete(&domain.OnCallLayer{}).Error; err != nil {
+				return err
+			}
+		}
+
+		layers := schedule.Layers
+		schedule.Layers = nil
+		if err := tx.Omit("Tag", "Creator").Save(schedule).Error; err != nil {
+			return err
+		}
+
+		for i := range layers {
+			layers[i].ID = 0
+			layers[i].ScheduleID = schedule.ID
+			for j := range layers[i].Participants {
+				layers[i].Participants[j].ID = 0
+			}
+		}
+		if len(layers) > 0 {
+			if err := tx.Create(&layers).Error; err != nil {
+				return err
+			}
+		}
+		schedule.Layers = layers
+		return nil
+	})
+}
+
+func (r *onCallScheduleRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.OnCallSchedule{}, id).Error
+}
+
+type onCallOverrideRepository struct {
+	db *gorm.DB
+}
+
+func NewOnCallOverrideRepository(db *gorm.DB) domain.OnCallOverrideRepository {
+	return &onCallOverrideRepository{db: db}
+}
+
+func (r *onCallOverrideRepository) Create(ctx context.Context, override *domain.OnCallOverride) error {
+	return r.db.WithContext(ctx).Omit("User").Create(override).Error
+}
+
+func (r *onCallOverrideRepository) FindByID(ctx context.Context, id uint) (*domain.OnCallOverride, error) {
+	var override domain.OnCallOverride
+	if err := r.db.WithContext(ctx).Preload("User").First(&override, id).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+func (r *onCallOverrideRepository) FindByScheduleID(ctx context.Context, scheduleID uint, from, to time.Time) ([]domain.OnCallOverride, error) {
+	var overrides []domain.OnCallOverride
+	if err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("schedule_id = ?", scheduleID).
+		Where("start_at <= ? AND end_at > ?", to, from).
+		Order("start_at ASC").
+		Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func (r *onCallOverrideRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.OnCallOverride{}, id).Error
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type postMortemRepository struct {
@@ -25,8 +26,11 @@ func (r *postMortemRepository) FindByID(ctx context.Context, id uint) (*domain.P
 	if err := r.db.WithContext(ctx).
 		Preload("Incident").
 		Preload("Author").
+		Preload("Template").
 		Preload("ActionItems").
 		Preload("ActionItems.Assignee").
+		Preload("Reviewers").
+		Preload("Reviewers.Reviewer").
 		First(&pm, id).Error; err != nil {
 		return nil, err
 	}
@@ -38,8 +42,11 @@ func (r *postMortemRepository) FindByIncidentID(ctx context.Context, incidentID
 	if err := r.db.WithContext(ctx).
 		Preload("Incident").
 		Preload("Author").
+		Preload("Template").
 		Preload("ActionItems").
 		Preload("ActionItems.Assignee").
+		Preload("Reviewers").
+		Preload("Reviewers.Reviewer").
 		Where("incident_id = ?", incidentID).
 		First(&pm).Error; err != nil {
 		return nil, err
@@ -48,7 +55,8 @@ func (r *postMortemRepository) FindByIncidentID(ctx context.Context, incidentID
 }
 
 func (r *postMortemRepository) Update(ctx context.Context, pm *domain.PostMortem) error {
-	return r.db.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: false}).Save(pm).Error
+	// Relations (reviewers, action items, ...) are managed by their own repositories
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(pm).Error
 }
 
 func (r *postMortemRepository) Delete(ctx context.Context, id uint) error {
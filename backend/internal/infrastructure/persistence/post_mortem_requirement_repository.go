@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postMortemPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewPostMortemPolicyRepository(db *gorm.DB) domain.PostMortemPolicyRepository {
+	return &postMortemPolicyRepository{db: db}
+}
+
+func (r *postMortemPolicyRepository) Create(ctx context.Context, policy *domain.PostMortemPolicy) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(policy).Error
+}
+
+func (r *postMortemPolicyRepository) FindAll(ctx context.Context) ([]*domain.PostMortemPolicy, error) {
+	var policies []*domain.PostMortemPolicy
+	if err := r.db.WithContext(ctx).
+		Preload("Tag").
+		Preload("Creator").
+		Order("name ASC").
+		Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (r *postMortemPolicyRepository) FindByID(ctx context.Context, id uint) (*domain.PostMortemPolicy, error) {
+	var policy domain.PostMortemPolicy
+	if err := r.db.WithContext(ctx).
+		Preload("Tag").
+		Preload("Creator").
+		First(&policy, id).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *postMortemPolicyRepository) Update(ctx context.Context, policy *domain.PostMortemPolicy) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(policy).Error
+}
+
+func (r *postMortemPolicyRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 既存の要件は期限を保持したままポリシーとの関連のみ解除
+		if err := tx.Model(&domain.PostMortemRequirement{}).
+			Where("policy_id = ?", id).
+			Update("policy_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.PostMortemPolicy{}, id).Error
+	})
+}
+
+type postMortemRequirementRepository struct {
+	db *gorm.DB
+}
+
+func NewPostMortemRequirementRepository(db *gorm.DB) domain.PostMortemRequirementRepository {
+	return &postMortemRequirementRepository{db: db}
+}
+
+func (r *postMortemRequirementRepository) Create(ctx context.Context, requirement *domain.PostMortemRequirement) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(requirement).Error
+}
+
+func (r *postMortemRequirementRepository) FindByIncidentID(ctx context.Context, incidentID uint) (*domain.PostMortemRequirement, error) {
+	var requirement domain.PostMortemRequirement
+	if err := r.db.WithContext(ctx).
+		Preload("Policy").
+		Preload("WaivedBy").
+		Where("incident_id = ?", incidentID).
+		First(&requirement).Error; err != nil {
+		return nil, err
+	}
+	return &requirement, nil
+}
+
+func (r *postMortemRequirementRepository) Update(ctx context.Context, requirement *domain.PostMortemRequirement) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Save(requirement).Error
+}
+
+func (r *postMortemRequirementRepository) FindPending(ctx context.Context) ([]*domain.PostMortemRequirement, error) {
+	var requirements []*domain.PostMortemRequirement
+	if err := r.db.WithContext(ctx).
+		Preload("Incident").
+		Where("status = ?", domain.RequirementStatusPending).
+		Order("due_at ASC").
+		Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+func (r *postMortemRequirementRepository) FindResolvedBetween(ctx context.Context, start, end time.Time) ([]*domain.PostMortemRequirement, error) {
+	var requirements []*domain.PostMortemRequirement
+	if err := r.db.WithContext(ctx).
+		Where("resolved_at >= ? AND resolved_at < ?", start, end).
+		Order("resolved_at ASC").
+		Find(&requirements).Error; err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
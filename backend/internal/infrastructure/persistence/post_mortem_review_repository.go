@@ -0,0 +1,110 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type postMortemReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewPostMortemReviewRepository(db *gorm.DB) domain.PostMortemReviewRepository {
+	return &postMortemReviewRepository{db: db}
+}
+
+func (r *postMortemReviewRepository) FindReviewers(ctx context.Context, postMortemID uint) ([]domain.PostMortemReviewer, error) {
+	var reviewers []domain.PostMortemReviewer
+	err := r.db.WithContext(ctx).
+		Preload("Reviewer").
+		Where("post_mortem_id = ?", postMortemID).
+		Order("id ASC").
+		Find(&reviewers).Error
+	return reviewers, err
+}
+
+func (r *postMortemReviewRepository) ReplaceReviewers(ctx context.Context, postMortemID uint, reviewerIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 外されたレビュアーを削除
+		query := tx.Where("post_mortem_id = ?", postMortemID)
+		if len(reviewerIDs) > 0 {
+			query = query.Where("reviewer_id NOT IN ?", reviewerIDs)
+		}
+		if err := query.Delete(&domain.PostMortemReviewer{}).Error; err != nil {
+			return err
+		}
+
+		// 新しく追加されたレビュアーを作成（既存のレビュアーは判定を保持）
+		var existing []uint
+		if err := tx.Model(&domain.PostMortemReviewer{}).
+			Where("post_mortem_id = ?", postMortemID).
+			Pluck("reviewer_id", &existing).Error; err != nil {
+			return err
+		}
+		assigned := make(map[uint]bool, len(existing))
+		for _, id := range existing {
+			assigned[id] = true
+		}
+		for _, reviewerID := range reviewerIDs {
+			if assigned[reviewerID] {
+				continue
+			}
+			reviewer := &domain.PostMortemReviewer{
+				PostMortemID: postMortemID,
+				ReviewerID:   reviewerID,
+				Decision:     domain.ReviewDecisionPending,
+			}
+			if err := tx.Create(reviewer).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postMortemReviewRepository) UpdateReviewer(ctx context.Context, reviewer *domain.PostMortemReviewer) error {
+	return r.db.WithContext(ctx).Omit("Reviewer").Save(reviewer).Error
+}
+
+func (r *postMortemReviewRepository) ResetDecisions(ctx context.Context, postMortemID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.PostMortemReviewer{}).
+		Where("post_mortem_id = ?", postMortemID).
+		Updates(map[string]interface{}{
+			"decision":   domain.ReviewDecisionPending,
+			"comment":    "",
+			"decided_at": nil,
+		}).Error
+}
+
+func (r *postMortemReviewRepository) CreateComment(ctx context.Context, comment *domain.PostMortemReviewComment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+func (r *postMortemReviewRepository) FindCommentByID(ctx context.Context, id uint) (*domain.PostMortemReviewComment, error) {
+	var comment domain.PostMortemReviewComment
+	if err := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("ResolvedBy").
+		First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *postMortemReviewRepository) FindComments(ctx context.Context, postMortemID uint) ([]domain.PostMortemReviewComment, error) {
+	var comments []domain.PostMortemReviewComment
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("ResolvedBy").
+		Where("post_mortem_id = ?", postMortemID).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+func (r *postMortemReviewRepository) UpdateComment(ctx context.Context, comment *domain.PostMortemReviewComment) error {
+	return r.db.WithContext(ctx).Omit("Author", "ResolvedBy").Save(comment).Error
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type postMortemTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewPostMortemTemplateRepository(db *gorm.DB) domain.PostMortemTemplateRepository {
+	return &postMortemTemplateRepository{db: db}
+}
+
+func (r *postMortemTemplateRepository) Create(ctx context.Context, template *domain.PostMortemTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(template).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaultTemplates(tx, template)
+	})
+}
+
+func (r *postMortemTemplateRepository) FindAll(ctx context.Context) ([]*domain.PostMortemTemplate, error) {
+	var templates []*domain.PostMortemTemplate
+
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Tags").
+		Order("is_default DESC, name ASC").
+		Find(&templates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *postMortemTemplateRepository) FindByID(ctx context.Context, id uint) (*domain.PostMortemTemplate, error) {
+	var template domain.PostMortemTemplate
+
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Tags").
+		First(&template, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *postMortemTemplateRepository) Update(ctx context.Context, template *domain.PostMortemTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// タグの関連付けを置き換える
+		if err := tx.Model(template).Association("Tags").Replace(template.Tags); err != nil {
+			return err
+		}
+		if err := tx.Omit("Tags", "Creator").Save(template).Error; err != nil {
+			return err
+		}
+		return clearOtherDefaultTemplates(tx, template)
+	})
+}
+
+func (r *postMortemTemplateRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 作成済みのポストモーテムはセクションを保持しているため、参照のみ外す
+		if err := tx.Model(&domain.PostMortem{}).Where("template_id = ?", id).Update("template_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.PostMortemTemplate{ID: id}).Association("Tags").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.PostMortemTemplate{}, id).Error
+	})
+}
+
+// clearOtherDefaultTemplates keeps at most one default template
+func clearOtherDefaultTemplates(tx *gorm.DB, template *domain.PostMortemTemplate) error {
+	if !template.IsDefault {
+		return nil
+	}
+	return tx.Model(&domain.PostMortemTemplate{}).
+		Where("id <> ? AND is_default = ?", template.ID, true).
+		Update("is_default", false).Error
+}
@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"context"
+	"incidex/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postMortemVersionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostMortemVersionRepository(db *gorm.DB) domain.PostMortemVersionRepository {
+	return &postMortemVersionRepository{db: db}
+}
+
+func (r *postMortemVersionRepository) Publish(ctx context.Context, pm *domain.PostMortem, version *domain.PostMortemVersion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("PublishedBy").Create(version).Error; err != nil {
+			return err
+		}
+		// Relations (reviewers, action items, ...) are managed by their own repositories
+		return tx.Omit(clause.Associations).Save(pm).Error
+	})
+}
+
+func (r *postMortemVersionRepository) FindByPostMortemID(ctx context.Context, postMortemID uint) ([]domain.PostMortemVersion, error) {
+	var versions []domain.PostMortemVersion
+	err := r.db.WithContext(ctx).
+		Omit("content").
+		Preload("PublishedBy").
+		Where("post_mortem_id = ?", postMortemID).
+		Order("version DESC").
+		Find(&versions).Error
+	return versions, err
+}
+
+func (r *postMortemVersionRepository) FindByVersion(ctx context.Context, postMortemID uint, version int) (*domain.PostMortemVersion, error) {
+	var pmVersion domain.PostMortemVersion
+	if err := r.db.WithContext(ctx).
+		Preload("PublishedBy").
+		Where("post_mortem_id = ? AND version = ?", postMortemID, version).
+		First(&pmVersion).Error; err != nil {
+		return nil, err
+	}
+	return &pmVersion, nil
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"incidex/internal/domain"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -73,6 +74,20 @@ func (r *reportRepository) GetMonthlyReport(startDate, endDate time.Time) (*doma
 		report.Comparison = comparison
 	}
 
+	// Get customer impact totals
+	customerImpact, err := r.getCustomerImpact(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	report.CustomerImpact = customerImpact
+
+	// Get lifecycle metrics derived from timeline events
+	lifecycle, err := r.GetLifecycleMetrics(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	report.Lifecycle = lifecycle
+
 	return report, nil
 }
 
@@ -283,6 +298,119 @@ func (r *reportRepository) getPerformanceMetrics(startDate, endDate time.Time) (
 	return metrics, nil
 }
 
+// getCustomerImpact totals the customer-impact windows overlapping the period, per month (UTC) and per service.
+// Impact minutes are clipped to the period; the quantified figures are counted once per incident.
+func (r *reportRepository) getCustomerImpact(startDate, endDate time.Time) (*domain.CustomerImpactSummary, error) {
+	var incidents []*domain.Incident
+	err := r.db.
+		Where("impact_started_at IS NOT NULL AND impact_started_at <= ?", endDate).
+		Where("impact_ended_at IS NULL OR impact_ended_at >= ?", startDate).
+		Order("impact_started_at ASC").
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.CustomerImpactSummary{
+		ByMonth:   []domain.ImpactByMonth{},
+		ByService: []domain.ImpactByService{},
+	}
+	months := make(map[string]*domain.ImpactByMonth)
+	var monthKeys []string
+	services := make(map[string]*domain.ImpactByService)
+	var serviceKeys []string
+	now := time.Now()
+
+	for _, incident := range incidents {
+		impactStart, impactEnd, ok := incident.ImpactWindow(now)
+		if !ok {
+			continue
+		}
+		if impactStart.Before(startDate) {
+			impactStart = startDate
+		}
+		if impactEnd.After(endDate) {
+			impactEnd = endDate
+		}
+		if impactEnd.Before(impactStart) {
+			continue
+		}
+		minutes := impactEnd.Sub(impactStart).Minutes()
+
+		summary.ImpactedIncidents++
+		summary.TotalImpactMinutes += minutes
+
+		// Split the window at month boundaries
+		for cursor := impactStart.UTC(); cursor.Before(impactEnd); {
+			monthStart := time.Date(cursor.Year(), cursor.Month(), 1, 0, 0, 0, 0, time.UTC)
+			next := monthStart.AddDate(0, 1, 0)
+			if next.After(impactEnd) {
+				next = impactEnd
+			}
+			key := monthStart.Format("2006-01")
+			month, exists := months[key]
+			if !exists {
+				month = &domain.ImpactByMonth{Month: key}
+				months[key] = month
+				monthKeys = append(monthKeys, key)
+			}
+			month.ImpactMinutes += next.Sub(cursor).Minutes()
+			month.ImpactedIncidents++
+			cursor = next
+		}
+
+		service, exists := services[incident.Service]
+		if !exists {
+			service = &domain.ImpactByService{Service: incident.Service, AffectedRegions: []string{}}
+			services[incident.Service] = service
+			serviceKeys = append(serviceKeys, incident.Service)
+		}
+		service.ImpactMinutes += minutes
+		service.ImpactedIncidents++
+
+		impact := incident.CustomerImpact
+		if impact.AffectedUsers != nil {
+			summary.AffectedUsers += *impact.AffectedUsers
+			service.AffectedUsers += *impact.AffectedUsers
+		}
+		if impact.FailedRequests != nil {
+			summary.FailedRequests += *impact.FailedRequests
+			service.FailedRequests += *impact.FailedRequests
+		}
+		if impact.EstimatedRevenueLoss != nil {
+			summary.EstimatedRevenueLoss += *impact.EstimatedRevenueLoss
+			service.EstimatedRevenueLoss += *impact.EstimatedRevenueLoss
+		}
+		for _, region := range impact.AffectedRegions {
+			if !containsString(service.AffectedRegions, region) {
+				service.AffectedRegions = append(service.AffectedRegions, region)
+			}
+		}
+	}
+
+	sort.Strings(monthKeys)
+	for _, key := range monthKeys {
+		summary.ByMonth = append(summary.ByMonth, *months[key])
+	}
+	for _, key := range serviceKeys {
+		summary.ByService = append(summary.ByService, *services[key])
+	}
+	sort.SliceStable(summary.ByService, func(i, j int) bool {
+		return summary.ByService[i].ImpactMinutes > summary.ByService[j].ImpactMinutes
+	})
+
+	return summary, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *reportRepository) getPeriodComparison(startDate, endDate time.Time) (*domain.PeriodComparison, error) {
 	// Calculate previous period (same duration)
 	duration := endDate.Sub(startDate)
@@ -353,3 +481,85 @@ func (r *reportRepository) getPeriodComparison(startDate, endDate time.Time) (*d
 
 	return comparison, nil
 }
+
+func (r *reportRepository) GetAssignmentsBetween(startDate, endDate time.Time) ([]domain.IncidentAssignment, error) {
+	var assignments []domain.IncidentAssignment
+	err := r.db.
+		Preload("Incident").
+		Where("assigned_at <= ?", endDate).
+		Where("unassigned_at IS NULL OR unassigned_at >= ?", startDate).
+		Order("assigned_at ASC").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *reportRepository) GetActivitiesBetween(startDate, endDate time.Time, types []domain.ActivityType) ([]domain.IncidentActivity, error) {
+	var activities []domain.IncidentActivity
+	query := r.db.Preload("User").Where("created_at BETWEEN ? AND ?", startDate, endDate)
+	if len(types) > 0 {
+		query = query.Where("activity_type IN ?", types)
+	}
+	if err := query.Order("created_at ASC").Find(&activities).Error; err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+func (r *reportRepository) GetHandoffIncidents(startDate, endDate time.Time) ([]*domain.Incident, error) {
+	var incidents []*domain.Incident
+	activeIncidents := r.db.Model(&domain.IncidentActivity{}).
+		Select("incident_id").
+		Where("created_at BETWEEN ? AND ?", startDate, endDate)
+
+	err := r.db.
+		Preload("Assignee").
+		Where("detected_at BETWEEN ? AND ?", startDate, endDate).
+		Or("resolved_at BETWEEN ? AND ?", startDate, endDate).
+		Or("status IN ?", []domain.Status{domain.StatusOpen, domain.StatusInvestigating}).
+		Or("detected_at <= ? AND resolved_at > ?", endDate, endDate).
+		Or("id IN (?)", activeIncidents).
+		Order("detected_at ASC").
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// GetLifecycleMetrics aggregates the timeline milestones of incidents detected in the period
+func (r *reportRepository) GetLifecycleMetrics(startDate, endDate time.Time) (*domain.LifecycleMetrics, error) {
+	var incidents []*domain.Incident
+	err := r.db.
+		Preload("Tags").
+		Where("detected_at BETWEEN ? AND ?", startDate, endDate).
+		Find(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var events []domain.IncidentActivity
+	if len(incidents) > 0 {
+		ids := make([]uint, len(incidents))
+		for i, incident := range incidents {
+			ids[i] = incident.ID
+		}
+		err = r.db.
+			Where("incident_id IN ?", ids).
+			Where("activity_type IN ?", []domain.ActivityType{
+				domain.ActivityTypeDetected,
+				domain.ActivityTypeInvestigationStarted,
+				domain.ActivityTypeRootCauseIdentified,
+				domain.ActivityTypeMitigation,
+				domain.ActivityTypeTimelineResolved,
+			}).
+			Find(&events).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.BuildLifecycleMetrics(startDate, endDate, incidents, events), nil
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"incidex/internal/pkg/logger"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job is a unit of periodic background work.
+type Job func(ctx context.Context) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Scheduler runs registered jobs at fixed intervals until its context is cancelled.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries []entry
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates an empty scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job. Jobs registered after Start are not run.
+func (s *Scheduler) Register(name string, interval time.Duration, job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry{name: name, interval: interval, job: job})
+}
+
+// Start launches one goroutine per registered job.
+// Each job runs once immediately and then on every tick of its interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	entries := append([]entry(nil), s.entries...)
+	s.mu.Unlock()
+
+	for _, e := range entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+}
+
+// Wait blocks until all job goroutines have exited.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	s.run(ctx, e)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, e)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, e entry) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Log.Error("Scheduled job panicked", zap.String("job", e.name), zap.Any("panic", r))
+		}
+	}()
+
+	if err := e.job(ctx); err != nil {
+		logger.Log.Error("Scheduled job failed", zap.String("job", e.name), zap.Error(err))
+	}
+}
@@ -0,0 +1,290 @@
+package timelineimport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format is the kind of source a timeline is imported from
+type Format string
+
+const (
+	FormatSlack Format = "slack" // Slack チャンネルエクスポートの JSON
+	FormatText  Format = "text"  // 1行1イベントの "timestamp message" 形式のログ
+	FormatCSV   Format = "csv"   // ヘッダー付き CSV
+)
+
+// Entry is a single event read from the source
+type Entry struct {
+	Line      int // 元データの行番号（Slack はメッセージの順番）
+	Time      time.Time
+	Author    string
+	Message   string
+	EventType string // CSV の type 列（その他の形式では空）
+}
+
+// Result is the outcome of parsing a source
+type Result struct {
+	Entries []Entry
+	Skipped int // 日時を読み取れなかった行・メッセージの数
+}
+
+// Parse reads entries from content. Timestamps without a UTC offset are interpreted in loc.
+func Parse(format Format, content string, loc *time.Location) (*Result, error) {
+	switch format {
+	case FormatSlack:
+		return parseSlack(content)
+	case FormatText:
+		return parseText(content, loc), nil
+	case FormatCSV:
+		return parseCSV(content, loc)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+type slackMessage struct {
+	Type        string `json:"type"`
+	Subtype     string `json:"subtype"`
+	User        string `json:"user"`
+	UserName    string `json:"user_name"`
+	Username    string `json:"username"`
+	Text        string `json:"text"`
+	Ts          string `json:"ts"`
+	UserProfile *struct {
+		RealName    string `json:"real_name"`
+		DisplayName string `json:"display_name"`
+	} `json:"user_profile"`
+}
+
+// parseSlack reads a channel export (the per-day JSON array of messages)
+func parseSlack(content string) (*Result, error) {
+	var messages []slackMessage
+	if err := json.Unmarshal([]byte(content), &messages); err != nil {
+		return nil, fmt.Errorf("invalid Slack export: %w", err)
+	}
+
+	result := &Result{}
+	for i, message := range messages {
+		if message.Type != "" && message.Type != "message" {
+			continue
+		}
+		// Join/leave notifications are not part of the incident
+		if message.Subtype == "channel_join" || message.Subtype == "channel_leave" {
+			continue
+		}
+		text := strings.TrimSpace(message.Text)
+		if text == "" {
+			continue
+		}
+
+		seconds, err := strconv.ParseFloat(message.Ts, 64)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		sec := int64(seconds)
+		at := time.Unix(sec, int64((seconds-float64(sec))*1e9)).Truncate(time.Millisecond)
+
+		result.Entries = append(result.Entries, Entry{
+			Line:    i + 1,
+			Time:    at,
+			Author:  slackAuthor(message),
+			Message: text,
+		})
+	}
+	return result, nil
+}
+
+func slackAuthor(message slackMessage) string {
+	if message.UserProfile != nil {
+		if message.UserProfile.RealName != "" {
+			return message.UserProfile.RealName
+		}
+		if message.UserProfile.DisplayName != "" {
+			return message.UserProfile.DisplayName
+		}
+	}
+	if message.UserName != "" {
+		return message.UserName
+	}
+	if message.Username != "" {
+		return message.Username
+	}
+	return message.User
+}
+
+// Leading timestamp of a log line, optionally in brackets, e.g.
+// "2024-01-01T10:00:00Z msg", "[2024-01-01 10:00:00,123] msg", "2024/01/01 10:00 msg"
+var textTimestamp = regexp.MustCompile(`^\[?(\d{4}[-/]\d{2}[-/]\d{2}[T ]\d{2}:\d{2}(?::\d{2})?(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?)\]?\s*(.*)$`)
+
+// parseText reads "timestamp message" lines. Lines without a timestamp continue the
+// previous message (e.g. stack traces); lines before the first timestamp are skipped.
+func parseText(content string, loc *time.Location) *Result {
+	result := &Result{}
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		match := textTimestamp.FindStringSubmatch(line)
+		if match == nil {
+			if n := len(result.Entries); n > 0 {
+				result.Entries[n-1].Message += "\n" + line
+			} else {
+				result.Skipped++
+			}
+			continue
+		}
+
+		at, err := parseTimestamp(match[1], loc)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		result.Entries = append(result.Entries, Entry{
+			Line:    i + 1,
+			Time:    at,
+			Message: strings.TrimSpace(match[2]),
+		})
+	}
+	return result
+}
+
+// CSV header names accepted for each column
+var csvColumns = map[string][]string{
+	"time":    {"time", "timestamp", "event_time", "datetime", "date"},
+	"message": {"message", "description", "text", "event"},
+	"type":    {"type", "event_type"},
+	"author":  {"author", "user", "name"},
+}
+
+// parseCSV reads a CSV with a header row; time and message columns are required
+func parseCSV(content string, loc *time.Location) (*Result, error) {
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(content, "\ufeff")))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	index := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for column, aliases := range csvColumns {
+			if _, found := index[column]; found {
+				continue
+			}
+			for _, alias := range aliases {
+				if name == alias {
+					index[column] = i
+				}
+			}
+		}
+	}
+	if _, ok := index["time"]; !ok {
+		return nil, fmt.Errorf("CSV header must include a time column (%s)", strings.Join(csvColumns["time"], ", "))
+	}
+	if _, ok := index["message"]; !ok {
+		return nil, fmt.Errorf("CSV header must include a message column (%s)", strings.Join(csvColumns["message"], ", "))
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	result := &Result{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		message := field(record, "message")
+		if message == "" {
+			continue
+		}
+		at, err := parseTimestamp(field(record, "time"), loc)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		result.Entries = append(result.Entries, Entry{
+			Line:      line,
+			Time:      at,
+			Author:    field(record, "author"),
+			Message:   message,
+			EventType: field(record, "type"),
+		})
+	}
+	return result, nil
+}
+
+// Accepted layouts for timestamps without an explicit UTC offset
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// parseTimestamp accepts RFC3339, Unix seconds and the local layouts above
+// ("/" may be used as the date separator and "," as the decimal separator).
+func parseTimestamp(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty timestamp")
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 1e9 {
+		sec := int64(seconds)
+		return time.Unix(sec, int64((seconds-float64(sec))*1e9)).Truncate(time.Millisecond), nil
+	}
+
+	normalized := strings.Replace(value, ",", ".", 1)
+	if len(normalized) >= 10 {
+		normalized = strings.ReplaceAll(normalized[:10], "/", "-") + normalized[10:]
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, normalized); err == nil {
+		return t, nil
+	}
+	// Offset without colon, e.g. +0900
+	if t, err := time.Parse("2006-01-02T15:04:05.999999999-0700", strings.Replace(normalized, " ", "T", 1)); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05.999999999Z07:00", strings.Replace(normalized, " ", "T", 1)); err == nil {
+		return t, nil
+	}
+
+	// Strip fractional seconds for the local layouts
+	base := normalized
+	var fraction time.Duration
+	if dot := strings.LastIndex(normalized, "."); dot > 16 {
+		base = normalized[:dot]
+		if f, err := strconv.ParseFloat("0"+normalized[dot:], 64); err == nil {
+			fraction = time.Duration(f * float64(time.Second)).Truncate(time.Millisecond)
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, base, loc); err == nil {
+			return t.Add(fraction), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", value)
+}
@@ -20,14 +20,39 @@ func NewActionItemHandler(actionItemUsecase usecase.ActionItemUsecase) *ActionIt
 	}
 }
 
+// CreateActionItemRequest creates an action item of an incident, a post-mortem or both (at least one is required)
 type CreateActionItemRequest struct {
-	PostMortemID uint    `json:"post_mortem_id" binding:"required"`
+	IncidentID   *uint   `json:"incident_id"`
+	PostMortemID *uint   `json:"post_mortem_id"` // インシデントは省略時にポストモーテムのものになる
 	Title        string  `json:"title" binding:"required,max=500"`
 	Description  string  `json:"description"`
 	AssigneeID   *uint   `json:"assignee_id"`
 	Priority     string  `json:"priority" binding:"required,oneof=high medium low"`
 	DueDate      *string `json:"due_date"` // RFC3339 format
 	RelatedLinks string  `json:"related_links"`
+	IssueTracker string  `json:"issue_tracker" binding:"omitempty,oneof=github jira"` // 指定時は外部トラッカーにIssueを作成
+}
+
+// OpenExternalIssueRequest opens an issue for an action item in an external tracker
+type OpenExternalIssueRequest struct {
+	IssueTracker string `json:"issue_tracker" binding:"required,oneof=github jira"`
+}
+
+// VerifyActionItemRequest records how a completed action item was checked to work
+type VerifyActionItemRequest struct {
+	Note        string `json:"note" binding:"required"`
+	EvidenceURL string `json:"evidence_url" binding:"omitempty,url,max=1000"`
+}
+
+// LinkActionItemRequest links an action item to a further incident or post-mortem (one of them is required)
+type LinkActionItemRequest struct {
+	IncidentID   *uint `json:"incident_id"`
+	PostMortemID *uint `json:"post_mortem_id"`
+}
+
+// AddActionItemDependencyRequest makes an action item wait for another one
+type AddActionItemDependencyRequest struct {
+	BlockedByID uint `json:"blocked_by_id" binding:"required"`
 }
 
 type UpdateActionItemRequest struct {
@@ -35,14 +60,14 @@ type UpdateActionItemRequest struct {
 	Description  string  `json:"description"`
 	AssigneeID   *uint   `json:"assignee_id"`
 	Priority     string  `json:"priority" binding:"required,oneof=high medium low"`
-	Status       string  `json:"status" binding:"required,oneof=pending in_progress completed"`
+	Status       string  `json:"status" binding:"required,oneof=pending in_progress completed verified"`
 	DueDate      *string `json:"due_date"` // RFC3339 format
 	RelatedLinks string  `json:"related_links"`
 }
 
 // Create godoc
 // @Summary Create a new action item
-// @Description Create a new action item for a post-mortem
+// @Description Create a new action item for an incident and/or a post-mortem. Items created for an incident without a post-mortem move into the post-mortem when it is created. With issue_tracker, an issue is also opened in the external tracker (the item is created even if that fails).
 // @Tags action-items
 // @Accept json
 // @Produce json
@@ -72,6 +97,7 @@ func (h *ActionItemHandler) Create(c *gin.Context) {
 
 	item, err := h.actionItemUsecase.CreateActionItem(
 		c.Request.Context(),
+		req.IncidentID,
 		req.PostMortemID,
 		req.Title,
 		req.Description,
@@ -79,6 +105,7 @@ func (h *ActionItemHandler) Create(c *gin.Context) {
 		domain.Priority(req.Priority),
 		dueDate,
 		req.RelatedLinks,
+		domain.IssueTrackerKind(req.IssueTracker),
 	)
 	if err != nil {
 		HandleError(c, err)
@@ -123,14 +150,14 @@ func (h *ActionItemHandler) GetByID(c *gin.Context) {
 // @Tags action-items
 // @Accept json
 // @Produce json
-// @Param postMortemId path int true "Post-mortem ID"
+// @Param id path int true "Post-mortem ID"
 // @Success 200 {array} domain.ActionItem
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /api/action-items/post-mortem/{postMortemId} [get]
+// @Router /api/post-mortems/{id}/action-items [get]
 // @Security BearerAuth
 func (h *ActionItemHandler) GetByPostMortemID(c *gin.Context) {
-	idStr := c.Param("postMortemId")
+	idStr := c.Param("id")
 	postMortemID, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
@@ -146,6 +173,35 @@ func (h *ActionItemHandler) GetByPostMortemID(c *gin.Context) {
 	c.JSON(http.StatusOK, items)
 }
 
+// GetByIncidentID godoc
+// @Summary Get action items by incident ID
+// @Description Get all action items of an incident, both those raised during the incident and those of its post-mortem
+// @Tags action-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {array} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/incidents/{id}/action-items [get]
+// @Security BearerAuth
+func (h *ActionItemHandler) GetByIncidentID(c *gin.Context) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	items, err := h.actionItemUsecase.GetActionItemsByIncidentID(c.Request.Context(), uint(incidentID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
 // GetAll godoc
 // @Summary Get all action items
 // @Description Get all action items with filters and pagination
@@ -155,6 +211,9 @@ func (h *ActionItemHandler) GetByPostMortemID(c *gin.Context) {
 // @Param status query string false "Status filter"
 // @Param priority query string false "Priority filter"
 // @Param assignee_id query int false "Assignee ID filter"
+// @Param incident_id query int false "Incident ID filter (items of its post-mortem included)"
+// @Param post_mortem_id query int false "Post-mortem ID filter"
+// @Param source query string false "Attachment filter (incident: not yet in a post-mortem, post_mortem: in a post-mortem)"
 // @Param search query string false "Search query"
 // @Param sort_by query string false "Sort by field"
 // @Param order query string false "Sort order (asc/desc)"
@@ -171,6 +230,7 @@ func (h *ActionItemHandler) GetAll(c *gin.Context) {
 		Search:   c.Query("search"),
 		SortBy:   c.Query("sort_by"),
 		Order:    c.Query("order"),
+		Source:   domain.ActionItemSource(c.Query("source")),
 	}
 
 	if assigneeIDStr := c.Query("assignee_id"); assigneeIDStr != "" {
@@ -179,6 +239,18 @@ func (h *ActionItemHandler) GetAll(c *gin.Context) {
 			filters.AssigneeID = uint(assigneeID)
 		}
 	}
+	if incidentIDStr := c.Query("incident_id"); incidentIDStr != "" {
+		incidentID, err := strconv.ParseUint(incidentIDStr, 10, 32)
+		if err == nil {
+			filters.IncidentID = uint(incidentID)
+		}
+	}
+	if postMortemIDStr := c.Query("post_mortem_id"); postMortemIDStr != "" {
+		postMortemID, err := strconv.ParseUint(postMortemIDStr, 10, 32)
+		if err == nil {
+			filters.PostMortemID = uint(postMortemID)
+		}
+	}
 
 	pagination := domain.Pagination{
 		Page:  1,
@@ -316,3 +388,279 @@ func (h *ActionItemHandler) Delete(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Action item deleted successfully"})
 }
+
+// AddLink godoc
+// @Summary Link an action item to another incident or post-mortem
+// @Description Record that the same remediation also came out of another incident
+// @Tags action-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Action item ID"
+// @Param link body LinkActionItemRequest true "Incident or post-mortem"
+// @Success 201 {object} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/action-items/{id}/links [post]
+// @Security BearerAuth
+func (h *ActionItemHandler) AddLink(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action item ID"})
+		return
+	}
+
+	var req LinkActionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.actionItemUsecase.AddLink(c.Request.Context(), userID, uint(id), req.IncidentID, req.PostMortemID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// RemoveLink godoc
+// @Summary Remove a link of an action item
+// @Tags action-items
+// @Produce json
+// @Param id path int true "Action item ID"
+// @Param linkId path int true "Link ID"
+// @Success 200 {object} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/action-items/{id}/links/{linkId} [delete]
+// @Security BearerAuth
+func (h *ActionItemHandler) RemoveLink(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action item ID"})
+		return
+	}
+	linkID, err := strconv.ParseUint(c.Param("linkId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid link ID"})
+		return
+	}
+
+	item, err := h.actionItemUsecase.RemoveLink(c.Request.Context(), uint(id), uint(linkID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// OpenExternalIssue godoc
+// @Summary Open an external issue for an action item
+// @Description Open an issue in GitHub Issues or Jira; closing or reopening it there updates the action item through the tracker's webhook
+// @Tags action-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Action item ID"
+// @Param issue body OpenExternalIssueRequest true "Issue tracker"
+// @Success 201 {object} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /api/action-items/{id}/external-issue [post]
+// @Security BearerAuth
+func (h *ActionItemHandler) OpenExternalIssue(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action item ID"})
+		return
+	}
+
+	var req OpenExternalIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.actionItemUsecase.OpenExternalIssue(c.Request.Context(), uint(id), domain.IssueTrackerKind(req.IssueTracker))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// AddDependency godoc
+// @Summary Add a blocking dependency
+// @Description The action item cannot be completed while the blocking action item is open. Cycles are refused.
+// @Tags action-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Action item ID"
+// @Param dependency body AddActionItemDependencyRequest true "Blocking action item"
+// @Success 201 {object} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/action-items/{id}/dependencies [post]
+// @Security BearerAuth
+func (h *ActionItemHandler) AddDependency(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action item ID"})
+		return
+	}
+
+	var req AddActionItemDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.actionItemUsecase.AddDependency(c.Request.Context(), uint(id), req.BlockedByID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// RemoveDependency godoc
+// @Summary Remove a blocking dependency
+// @Tags action-items
+// @Produce json
+// @Param id path int true "Action item ID"
+// @Param blockerId path int true "Blocking action item ID"
+// @Success 200 {object} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/action-items/{id}/dependencies/{blockerId} [delete]
+// @Security BearerAuth
+func (h *ActionItemHandler) RemoveDependency(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action item ID"})
+		return
+	}
+	blockerID, err := strconv.ParseUint(c.Param("blockerId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blocking action item ID"})
+		return
+	}
+
+	item, err := h.actionItemUsecase.RemoveDependency(c.Request.Context(), uint(id), uint(blockerID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetRecurring godoc
+// @Summary Get recurring remediations
+// @Description Action items that came out of several incidents, with how many incidents they might have prevented
+// @Tags action-items
+// @Produce json
+// @Param min_incidents query int false "Minimum number of incidents" default(2)
+// @Param status query string false "Status filter"
+// @Param limit query int false "Maximum number of items" default(50)
+// @Success 200 {array} domain.RecurringRemediation
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/action-items/recurring [get]
+// @Security BearerAuth
+func (h *ActionItemHandler) GetRecurring(c *gin.Context) {
+	minIncidents, _ := strconv.Atoi(c.DefaultQuery("min_incidents", "2"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	remediations, err := h.actionItemUsecase.GetRecurringRemediations(c.Request.Context(), domain.RecurringRemediationFilters{
+		MinIncidents: minIncidents,
+		Status:       c.Query("status"),
+		Limit:        limit,
+	})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, remediations)
+}
+
+// Verify godoc
+// @Summary Verify a completed action item
+// @Description Record that a completed action item was checked to actually prevent a recurrence, with a note and an optional link to evidence
+// @Tags action-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Action item ID"
+// @Param verification body VerifyActionItemRequest true "Verification"
+// @Success 200 {object} domain.ActionItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/action-items/{id}/verify [post]
+// @Security BearerAuth
+func (h *ActionItemHandler) Verify(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid action item ID"})
+		return
+	}
+
+	var req VerifyActionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	item, err := h.actionItemUsecase.VerifyActionItem(c.Request.Context(), userID, uint(id), req.Note, req.EvidenceURL)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// GetEffectiveness godoc
+// @Summary Get the action item effectiveness report
+// @Description Completed action items that have not been verified for unverified_days days, and completed items whose service or tag had another incident after completion
+// @Tags action-items
+// @Produce json
+// @Param unverified_days query int false "Days after completion until an unverified item is flagged" default(30)
+// @Param limit query int false "Maximum number of items per list" default(50)
+// @Success 200 {object} domain.ActionItemEffectivenessReport
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/action-items/effectiveness [get]
+// @Security BearerAuth
+func (h *ActionItemHandler) GetEffectiveness(c *gin.Context) {
+	unverifiedDays, err := strconv.Atoi(c.DefaultQuery("unverified_days", "30"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unverified_days"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	report, err := h.actionItemUsecase.GetEffectivenessReport(c.Request.Context(), unverifiedDays, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"incidex/internal/infrastructure/ical"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Timezone used for date-based output (calendar all-day events, report classification) unless ?tz= is given
+const defaultTimezone = "Asia/Tokyo"
+
+type CalendarHandler struct {
+	calendarUsecase usecase.CalendarUsecase
+	feedService     *ical.FeedService
+}
+
+func NewCalendarHandler(calendarUsecase usecase.CalendarUsecase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUsecase: calendarUsecase,
+		feedService:     ical.NewFeedService(),
+	}
+}
+
+// CalendarFeedTokenResponse describes the user's feed token and subscription paths.
+// Token and the feed paths are only included right after the token is (re)generated.
+type CalendarFeedTokenResponse struct {
+	Exists     bool       `json:"exists"`
+	Token      string     `json:"token,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	OnCallFeed string     `json:"oncall_feed,omitempty"`
+	ActionFeed string     `json:"action_items_feed,omitempty"`
+}
+
+// GetToken godoc
+// @Summary Get calendar feed token status
+// @Description Get whether the current user has a calendar feed token. The token itself is never returned here.
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} CalendarFeedTokenResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/calendar/token [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetToken(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.calendarUsecase.GetFeedToken(c.Request.Context(), userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusOK, CalendarFeedTokenResponse{Exists: false})
+		return
+	}
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CalendarFeedTokenResponse{
+		Exists:     true,
+		CreatedAt:  &token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+	})
+}
+
+// RegenerateToken godoc
+// @Summary Generate calendar feed token
+// @Description Generate a new calendar feed token for the current user, invalidating the previous one
+// @Tags calendar
+// @Produce json
+// @Success 201 {object} CalendarFeedTokenResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/calendar/token [post]
+// @Security BearerAuth
+func (h *CalendarHandler) RegenerateToken(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	plain, token, err := h.calendarUsecase.RegenerateFeedToken(c.Request.Context(), userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, CalendarFeedTokenResponse{
+		Exists:     true,
+		Token:      plain,
+		CreatedAt:  &token.CreatedAt,
+		OnCallFeed: fmt.Sprintf("/api/calendar/feeds/%s/oncall.ics", plain),
+		ActionFeed: fmt.Sprintf("/api/calendar/feeds/%s/action-items.ics", plain),
+	})
+}
+
+// RevokeToken godoc
+// @Summary Revoke calendar feed token
+// @Description Revoke the current user's calendar feed token. Existing subscriptions stop working.
+// @Tags calendar
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Router /api/calendar/token [delete]
+// @Security BearerAuth
+func (h *CalendarHandler) RevokeToken(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendarUsecase.RevokeFeedToken(c.Request.Context(), userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// OnCallFeed godoc
+// @Summary On-call shifts feed
+// @Description iCalendar feed of the token owner's on-call shifts across all schedules (past 30 days to next 90 days)
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Success 200 {file} file "iCalendar file"
+// @Failure 401 {object} map[string]string
+// @Router /api/calendar/feeds/{token}/oncall.ics [get]
+func (h *CalendarHandler) OnCallFeed(c *gin.Context) {
+	user, err := h.calendarUsecase.Authenticate(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	feed, err := h.calendarUsecase.GetUserOnCallFeed(c.Request.Context(), user.ID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	name := fmt.Sprintf("オンコール (%s)", user.Name)
+	h.writeCalendar(c, "oncall.ics", h.feedService.GenerateOnCallFeed(name, feed.Shifts, feed.Schedules, false))
+}
+
+// ActionItemFeed godoc
+// @Summary Action item due dates feed
+// @Description iCalendar feed of due dates of open action items assigned to the token owner
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Param tz query string false "IANA timezone used to interpret due dates (default Asia/Tokyo)"
+// @Success 200 {file} file "iCalendar file"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/calendar/feeds/{token}/action-items.ics [get]
+func (h *CalendarHandler) ActionItemFeed(c *gin.Context) {
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", defaultTimezone))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tz"})
+		return
+	}
+
+	user, err := h.calendarUsecase.Authenticate(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	items, err := h.calendarUsecase.GetUserActionItemFeed(c.Request.Context(), user.ID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	name := fmt.Sprintf("アクションアイテム期限 (%s)", user.Name)
+	h.writeCalendar(c, "action-items.ics", h.feedService.GenerateActionItemFeed(name, items, loc))
+}
+
+// ScheduleFeed godoc
+// @Summary Team on-call feed
+// @Description iCalendar feed of all shifts of a schedule (past 30 days to next 90 days)
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Param id path int true "Schedule ID"
+// @Success 200 {file} file "iCalendar file"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/calendar/feeds/{token}/schedules/{id}/oncall.ics [get]
+func (h *CalendarHandler) ScheduleFeed(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	if _, err := h.calendarUsecase.Authenticate(c.Request.Context(), c.Param("token")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	feed, err := h.calendarUsecase.GetScheduleOnCallFeed(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	name := fmt.Sprintf("オンコール: %s", feed.Schedules[uint(id)].Name)
+	filename := fmt.Sprintf("schedule_%d_oncall.ics", id)
+	h.writeCalendar(c, filename, h.feedService.GenerateOnCallFeed(name, feed.Shifts, feed.Schedules, true))
+}
+
+func (h *CalendarHandler) writeCalendar(c *gin.Context, filename string, data []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s", filename))
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// currentUserID reads the authenticated user ID set by the JWT middleware, writing an error response if missing.
+func currentUserID(c *gin.Context) (uint, bool) {
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false
+	}
+	userID, ok := userIDValue.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user ID"})
+		return 0, false
+	}
+	return userID, true
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CausalNodeRequest represents the request body for adding or editing a causal analysis node
+type CausalNodeRequest struct {
+	ParentID    *uint                 `json:"parent_id"` // 省略時は最上位（インシデントの直接原因）
+	Description string                `json:"description" binding:"required"`
+	Category    domain.CausalCategory `json:"category" binding:"required"` // people / process / technology / external
+	IsRootCause bool                  `json:"is_root_cause"`
+	Position    int                   `json:"position"`
+}
+
+func (r CausalNodeRequest) toInput() usecase.CausalNodeInput {
+	return usecase.CausalNodeInput{
+		ParentID:    r.ParentID,
+		Description: r.Description,
+		Category:    r.Category,
+		IsRootCause: r.IsRootCause,
+		Position:    r.Position,
+	}
+}
+
+// GetCausalTree godoc
+// @Summary Get the causal analysis tree of a post-mortem
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Success 200 {object} domain.CausalTree
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/causal-tree [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) GetCausalTree(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	tree, err := h.postMortemUsecase.GetCausalTree(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// AddCausalNode godoc
+// @Summary Add a cause to the causal analysis tree
+// @Tags post-mortems
+// @Accept json
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param node body CausalNodeRequest true "Causal node"
+// @Success 201 {object} domain.CausalTree
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/causal-tree/nodes [post]
+// @Security BearerAuth
+func (h *PostMortemHandler) AddCausalNode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	var req CausalNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	tree, err := h.postMortemUsecase.AddCausalNode(c.Request.Context(), userID, role, uint(id), req.toInput())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tree)
+}
+
+// UpdateCausalNode godoc
+// @Summary Edit or move a cause in the causal analysis tree
+// @Description Setting parent_id moves the node together with its subtree
+// @Tags post-mortems
+// @Accept json
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param nodeId path int true "Causal node ID"
+// @Param node body CausalNodeRequest true "Causal node"
+// @Success 200 {object} domain.CausalTree
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/causal-tree/nodes/{nodeId} [put]
+// @Security BearerAuth
+func (h *PostMortemHandler) UpdateCausalNode(c *gin.Context) {
+	id, nodeID, ok := parseCausalNodeParams(c)
+	if !ok {
+		return
+	}
+
+	var req CausalNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	tree, err := h.postMortemUsecase.UpdateCausalNode(c.Request.Context(), userID, role, id, nodeID, req.toInput())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// DeleteCausalNode godoc
+// @Summary Delete a cause and all causes below it
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param nodeId path int true "Causal node ID"
+// @Success 200 {object} domain.CausalTree
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/causal-tree/nodes/{nodeId} [delete]
+// @Security BearerAuth
+func (h *PostMortemHandler) DeleteCausalNode(c *gin.Context) {
+	id, nodeID, ok := parseCausalNodeParams(c)
+	if !ok {
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	tree, err := h.postMortemUsecase.DeleteCausalNode(c.Request.Context(), userID, role, id, nodeID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetCausalCategorySummary godoc
+// @Summary Aggregate causal analysis categories across post-mortems
+// @Description Count root and contributing causes per category (people/process/technology/external) for post-mortems created in the period
+// @Tags post-mortems
+// @Produce json
+// @Param months query int false "Number of months to include, including the current month (1-24)" default(12)
+// @Param status query string false "Post-mortem status filter (draft/in_review/changes_requested/approved/published)"
+// @Success 200 {object} domain.CausalCategorySummary
+// @Failure 400 {object} map[string]string
+// @Router /api/post-mortems/causal-categories [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) GetCausalCategorySummary(c *gin.Context) {
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil || months < 1 || months > 24 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "months must be between 1 and 24"})
+		return
+	}
+
+	summary, err := h.postMortemUsecase.GetCausalCategorySummary(c.Request.Context(), months, domain.PMStatus(c.Query("status")))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// parseCausalNodeParams reads the post-mortem and node IDs, writing a 400 response on invalid input.
+func parseCausalNodeParams(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return 0, 0, false
+	}
+	nodeID, err := strconv.ParseUint(c.Param("nodeId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid causal node ID"})
+		return 0, 0, false
+	}
+	return uint(id), uint(nodeID), true
+}
+
+// currentUser returns the authenticated user's ID and role, writing an error response if missing.
+func currentUser(c *gin.Context) (uint, domain.Role, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return 0, "", false
+	}
+	roleValue, exists := c.Get("role")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User role not found"})
+		return 0, "", false
+	}
+	role, ok := roleValue.(domain.Role)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user role type"})
+		return 0, "", false
+	}
+	return userID, role, true
+}
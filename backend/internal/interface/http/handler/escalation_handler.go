@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EscalationHandler struct {
+	escalationUsecase usecase.EscalationUsecase
+}
+
+func NewEscalationHandler(escalationUsecase usecase.EscalationUsecase) *EscalationHandler {
+	return &EscalationHandler{
+		escalationUsecase: escalationUsecase,
+	}
+}
+
+type EscalationTargetRequest struct {
+	TargetType string `json:"target_type" binding:"required"`
+	TargetID   uint   `json:"target_id" binding:"required"`
+}
+
+type EscalationLevelRequest struct {
+	TimeoutMinutes int                       `json:"timeout_minutes" binding:"required,min=1"`
+	Targets        []EscalationTargetRequest `json:"targets" binding:"required,min=1,dive"`
+}
+
+type EscalationPolicyRequest struct {
+	Name        string                   `json:"name" binding:"required,max=200"`
+	Description string                   `json:"description"`
+	Trigger     string                   `json:"trigger" binding:"required,oneof=unacknowledged unresolved"`
+	IsActive    *bool                    `json:"is_active"`
+	Severity    string                   `json:"severity" binding:"omitempty,oneof=critical high medium low"`
+	TagID       *uint                    `json:"tag_id"`
+	Service     string                   `json:"service" binding:"max=100"`
+	Levels      []EscalationLevelRequest `json:"levels" binding:"required,min=1,dive"`
+}
+
+// toLevels converts the request levels into domain levels in the given order.
+func (r *EscalationPolicyRequest) toLevels() []domain.EscalationLevel {
+	levels := make([]domain.EscalationLevel, len(r.Levels))
+	for i, level := range r.Levels {
+		levels[i].TimeoutMinutes = level.TimeoutMinutes
+		for _, target := range level.Targets {
+			levels[i].Targets = append(levels[i].Targets, domain.EscalationTarget{
+				TargetType: domain.EscalationTargetType(target.TargetType),
+				TargetID:   target.TargetID,
+			})
+		}
+	}
+	return levels
+}
+
+func (r *EscalationPolicyRequest) isActive() bool {
+	return r.IsActive == nil || *r.IsActive
+}
+
+// Create godoc
+// @Summary Create an escalation policy
+// @Description Create a multi-level escalation policy (admin only)
+// @Tags escalation-policies
+// @Accept json
+// @Produce json
+// @Param policy body EscalationPolicyRequest true "Escalation policy data"
+// @Success 201 {object} domain.EscalationPolicy
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/escalation-policies [post]
+// @Security BearerAuth
+func (h *EscalationHandler) Create(c *gin.Context) {
+	var req EscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userID, ok := userIDValue.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user ID"})
+		return
+	}
+
+	policy, err := h.escalationUsecase.CreatePolicy(
+		c.Request.Context(),
+		userID,
+		req.Name,
+		req.Description,
+		domain.EscalationTrigger(req.Trigger),
+		req.isActive(),
+		domain.Severity(req.Severity),
+		req.TagID,
+		req.Service,
+		req.toLevels(),
+	)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// GetAll godoc
+// @Summary List escalation policies
+// @Description Get all escalation policies with their levels
+// @Tags escalation-policies
+// @Produce json
+// @Success 200 {array} domain.EscalationPolicy
+// @Failure 500 {object} map[string]string
+// @Router /api/escalation-policies [get]
+// @Security BearerAuth
+func (h *EscalationHandler) GetAll(c *gin.Context) {
+	policies, err := h.escalationUsecase.GetAllPolicies(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// GetByID godoc
+// @Summary Get escalation policy by ID
+// @Description Get an escalation policy with its levels and targets
+// @Tags escalation-policies
+// @Produce json
+// @Param id path int true "Escalation policy ID"
+// @Success 200 {object} domain.EscalationPolicy
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/escalation-policies/{id} [get]
+// @Security BearerAuth
+func (h *EscalationHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid escalation policy ID"})
+		return
+	}
+
+	policy, err := h.escalationUsecase.GetPolicyByID(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// Update godoc
+// @Summary Update an escalation policy
+// @Description Update an escalation policy; levels are replaced as a whole (admin only)
+// @Tags escalation-policies
+// @Accept json
+// @Produce json
+// @Param id path int true "Escalation policy ID"
+// @Param policy body EscalationPolicyRequest true "Escalation policy data"
+// @Success 200 {object} domain.EscalationPolicy
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/escalation-policies/{id} [put]
+// @Security BearerAuth
+func (h *EscalationHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid escalation policy ID"})
+		return
+	}
+
+	var req EscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.escalationUsecase.UpdatePolicy(
+		c.Request.Context(),
+		uint(id),
+		req.Name,
+		req.Description,
+		domain.EscalationTrigger(req.Trigger),
+		req.isActive(),
+		domain.Severity(req.Severity),
+		req.TagID,
+		req.Service,
+		req.toLevels(),
+	)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// Delete godoc
+// @Summary Delete an escalation policy
+// @Description Delete an escalation policy (admin only)
+// @Tags escalation-policies
+// @Produce json
+// @Param id path int true "Escalation policy ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/escalation-policies/{id} [delete]
+// @Security BearerAuth
+func (h *EscalationHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid escalation policy ID"})
+		return
+	}
+
+	if err := h.escalationUsecase.DeletePolicy(c.Request.Context(), uint(id)); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Escalation policy deleted successfully"})
+}
+
+// GetByIncidentID godoc
+// @Summary Get escalation state of an incident
+// @Description Get the escalation progress of an incident
+// @Tags escalation-policies
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {object} domain.IncidentEscalation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/incidents/{id}/escalation [get]
+// @Security BearerAuth
+func (h *EscalationHandler) GetByIncidentID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	escalation, err := h.escalationUsecase.GetIncidentEscalation(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, escalation)
+}
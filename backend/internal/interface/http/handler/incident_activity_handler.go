@@ -2,9 +2,12 @@ package handler
 
 import (
 	"incidex/internal/domain"
+	"incidex/internal/infrastructure/timelineimport"
 	"incidex/internal/usecase"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -105,6 +108,34 @@ func (h *IncidentActivityHandler) GetActivities(c *gin.Context) {
 	c.JSON(http.StatusOK, activities)
 }
 
+// GetLifecycle godoc
+// @Summary Get lifecycle metrics of an incident
+// @Description Get the time to acknowledge/diagnose/mitigate/resolve and per-phase durations of an incident, derived from its timeline events
+// @Tags incident-activities
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {object} domain.IncidentLifecycle
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/incidents/{id}/lifecycle [get]
+// @Security BearerAuth
+func (h *IncidentActivityHandler) GetLifecycle(c *gin.Context) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	lifecycle, err := h.activityUsecase.GetLifecycle(c.Request.Context(), uint(incidentID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lifecycle)
+}
+
 type AddCommentRequest struct {
 	Comment string `json:"comment" binding:"required,min=1,max=5000"`
 }
@@ -176,3 +207,285 @@ func (h *IncidentActivityHandler) AddTimelineEvent(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, activity)
 }
+
+type UpdateTimelineEventRequest struct {
+	EventType   string `json:"event_type" binding:"required,oneof=detected investigation_started root_cause_identified mitigation timeline_resolved other"`
+	EventTime   string `json:"event_time" binding:"required"`
+	Description string `json:"description" binding:"required,min=1,max=5000"`
+}
+
+// GetTimeline godoc
+// @Summary Get timeline events of an incident
+// @Description Get the timeline events of an incident ordered by event time (oldest first)
+// @Tags incident-activities
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {array} domain.IncidentActivity
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/incidents/{id}/timeline [get]
+// @Security BearerAuth
+func (h *IncidentActivityHandler) GetTimeline(c *gin.Context) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	events, err := h.activityUsecase.GetTimeline(uint(incidentID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// UpdateTimelineEvent godoc
+// @Summary Update a timeline event
+// @Description Correct the event type, time and description of a timeline event. The previous values are kept in the timeline history.
+// @Tags incident-activities
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param eventId path int true "Timeline event ID"
+// @Param event body UpdateTimelineEventRequest true "Timeline Event"
+// @Success 200 {object} domain.IncidentActivity
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/incidents/{id}/timeline/{eventId} [put]
+// @Security BearerAuth
+func (h *IncidentActivityHandler) UpdateTimelineEvent(c *gin.Context) {
+	incidentID, eventID, ok := parseTimelineEventParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateTimelineEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	eventTime, err := time.Parse(time.RFC3339, req.EventTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event_time format (expected RFC3339)"})
+		return
+	}
+
+	activity, err := h.activityUsecase.UpdateTimelineEvent(incidentID, eventID, userID, domain.ActivityType(req.EventType), eventTime, req.Description)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, activity)
+}
+
+// DeleteTimelineEvent godoc
+// @Summary Delete a timeline event
+// @Description Delete a timeline event. Its values are kept in the timeline history.
+// @Tags incident-activities
+// @Param id path int true "Incident ID"
+// @Param eventId path int true "Timeline event ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/incidents/{id}/timeline/{eventId} [delete]
+// @Security BearerAuth
+func (h *IncidentActivityHandler) DeleteTimelineEvent(c *gin.Context) {
+	incidentID, eventID, ok := parseTimelineEventParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.activityUsecase.DeleteTimelineEvent(incidentID, eventID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetTimelineHistory godoc
+// @Summary Get timeline edit history
+// @Description Get the edits and deletions of an incident's timeline events with the original values, newest first
+// @Tags incident-activities
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {array} domain.TimelineEventRevision
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/incidents/{id}/timeline/history [get]
+// @Security BearerAuth
+func (h *IncidentActivityHandler) GetTimelineHistory(c *gin.Context) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	revisions, err := h.activityUsecase.GetTimelineRevisions(uint(incidentID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// Maximum size of an imported chat export or log
+const maxTimelineImportSize = 5 << 20
+
+// ImportTimelineRequest is sent as JSON, or as multipart/form-data with the source in a "file" field
+type ImportTimelineRequest struct {
+	Format           string   `json:"format" form:"format" binding:"required,oneof=slack text csv"`
+	Content          string   `json:"content" form:"content"`
+	From             string   `json:"from" form:"from"`
+	To               string   `json:"to" form:"to"`
+	Keywords         []string `json:"keywords" form:"keywords"`
+	DefaultEventType string   `json:"default_event_type" form:"default_event_type"`
+	Timezone         string   `json:"timezone" form:"timezone"`
+	Exclude          []int    `json:"exclude" form:"exclude"`
+	Preview          *bool    `json:"preview" form:"preview"`
+}
+
+// ImportTimeline godoc
+// @Summary Import timeline events from a chat export or log
+// @Description Parse a Slack channel export (JSON), a "timestamp message" text log or a CSV (time, message, optional type/author columns) into timeline events. By default only a preview is returned; send preview=false to import. Events already on the timeline (same second and description) are reported as duplicates and never imported.
+// @Tags incident-activities
+// @Accept json
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param request body ImportTimelineRequest true "Import source and filters"
+// @Success 200 {object} usecase.TimelineImportResult "Preview"
+// @Success 201 {object} usecase.TimelineImportResult "Imported"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/incidents/{id}/timeline/import [post]
+// @Security BearerAuth
+func (h *IncidentActivityHandler) ImportTimeline(c *gin.Context) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	var req ImportTimelineRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if req.Content == "" && strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "content or file is required"})
+			return
+		}
+		if fileHeader.Size > maxTimelineImportSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "File is too large (max 5MB)"})
+			return
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+			return
+		}
+		defer file.Close()
+		data, err := io.ReadAll(io.LimitReader(file, maxTimelineImportSize))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+			return
+		}
+		req.Content = string(data)
+	}
+	if req.Content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "content or file is required"})
+		return
+	}
+	if len(req.Content) > maxTimelineImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Content is too large (max 5MB)"})
+		return
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = defaultTimezone
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+		return
+	}
+
+	importReq := usecase.TimelineImportRequest{
+		Format:           timelineimport.Format(req.Format),
+		Content:          req.Content,
+		Location:         loc,
+		Keywords:         req.Keywords,
+		DefaultEventType: domain.ActivityType(req.DefaultEventType),
+		Exclude:          req.Exclude,
+		Preview:          req.Preview == nil || *req.Preview,
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format (expected RFC3339)"})
+			return
+		}
+		importReq.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format (expected RFC3339)"})
+			return
+		}
+		importReq.To = &to
+	}
+
+	result, err := h.activityUsecase.ImportTimeline(c.Request.Context(), uint(incidentID), userID, importReq)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Preview {
+		status = http.StatusCreated
+	}
+	c.JSON(status, result)
+}
+
+// parseTimelineEventParams reads the incident and event IDs, writing a 400 response on invalid input.
+func parseTimelineEventParams(c *gin.Context) (uint, uint, bool) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return 0, 0, false
+	}
+	eventID, err := strconv.ParseUint(c.Param("eventId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		return 0, 0, false
+	}
+	return uint(incidentID), uint(eventID), true
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
 	"incidex/internal/domain"
 	"incidex/internal/usecase"
 	"net/http"
@@ -25,9 +27,11 @@ type CreateIncidentRequest struct {
 	Severity    string   `json:"severity" binding:"required,oneof=critical high medium low"`
 	Status      string   `json:"status" binding:"required,oneof=open investigating resolved closed"`
 	ImpactScope string   `json:"impact_scope"`
+	Service     string   `json:"service" binding:"max=100"`
 	DetectedAt  string   `json:"detected_at" binding:"required"`
 	AssigneeID  *uint    `json:"assignee_id"`
 	TagIDs      []uint   `json:"tag_ids"`
+	CustomerImpactRequest
 }
 
 type UpdateIncidentRequest struct {
@@ -36,10 +40,104 @@ type UpdateIncidentRequest struct {
 	Severity    string   `json:"severity" binding:"required,oneof=critical high medium low"`
 	Status      string   `json:"status" binding:"required,oneof=open investigating resolved closed"`
 	ImpactScope string   `json:"impact_scope"`
+	Service     *string  `json:"service" binding:"omitempty,max=100"` // 省略時は変更しない
 	DetectedAt  string   `json:"detected_at" binding:"required"`
 	ResolvedAt  *string  `json:"resolved_at"`
 	AssigneeID  *uint    `json:"assignee_id"`
 	TagIDs      []uint   `json:"tag_ids"`
+	CustomerImpactRequest
+}
+
+// CustomerImpactRequest holds the customer-impact window and figures of an incident
+type CustomerImpactRequest struct {
+	ImpactStartedAt      nullable[string]  `json:"impact_started_at" swaggertype:"string"`
+	ImpactEndedAt        nullable[string]  `json:"impact_ended_at" swaggertype:"string"`
+	AffectedUsers        nullable[int64]   `json:"affected_users" swaggertype:"integer"`
+	FailedRequests       nullable[int64]   `json:"failed_requests" swaggertype:"integer"`
+	EstimatedRevenueLoss nullable[float64] `json:"estimated_revenue_loss" swaggertype:"number"`
+	AffectedRegions      []string          `json:"affected_regions" binding:"omitempty,max=50,dive,required,max=50"`
+}
+
+// nullable is a JSON field that tells an omitted field (Set is false) from an explicit null (Set with a nil Value)
+type nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (n *nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// toDomain parses the impact timestamps (RFC3339), writing a 400 response on invalid input
+func (r *CustomerImpactRequest) toDomain(c *gin.Context) (domain.CustomerImpact, bool) {
+	impact := domain.CustomerImpact{
+		AffectedUsers:        r.AffectedUsers.Value,
+		FailedRequests:       r.FailedRequests.Value,
+		EstimatedRevenueLoss: r.EstimatedRevenueLoss.Value,
+		AffectedRegions:      r.AffectedRegions,
+	}
+	if impact.AffectedRegions == nil {
+		impact.AffectedRegions = []string{}
+	}
+
+	var ok bool
+	if impact.ImpactStartedAt, ok = parseImpactTime(c, "impact_started_at", r.ImpactStartedAt.Value); !ok {
+		return domain.CustomerImpact{}, false
+	}
+	if impact.ImpactEndedAt, ok = parseImpactTime(c, "impact_ended_at", r.ImpactEndedAt.Value); !ok {
+		return domain.CustomerImpact{}, false
+	}
+
+	return impact, true
+}
+
+// toUpdate converts the fields that were sent into a partial update, writing a 400 response on invalid input.
+// null clears a field, as does an empty timestamp.
+func (r *CustomerImpactRequest) toUpdate(c *gin.Context) (domain.CustomerImpactUpdate, bool) {
+	update := domain.CustomerImpactUpdate{
+		AffectedUsers:             r.AffectedUsers.Value,
+		FailedRequests:            r.FailedRequests.Value,
+		EstimatedRevenueLoss:      r.EstimatedRevenueLoss.Value,
+		AffectedRegions:           r.AffectedRegions,
+		ClearImpactStartedAt:      r.ImpactStartedAt.Set && (r.ImpactStartedAt.Value == nil || *r.ImpactStartedAt.Value == ""),
+		ClearImpactEndedAt:        r.ImpactEndedAt.Set && (r.ImpactEndedAt.Value == nil || *r.ImpactEndedAt.Value == ""),
+		ClearAffectedUsers:        r.AffectedUsers.Set && r.AffectedUsers.Value == nil,
+		ClearFailedRequests:       r.FailedRequests.Set && r.FailedRequests.Value == nil,
+		ClearEstimatedRevenueLoss: r.EstimatedRevenueLoss.Set && r.EstimatedRevenueLoss.Value == nil,
+	}
+
+	var ok bool
+	if update.ImpactStartedAt, ok = parseImpactTime(c, "impact_started_at", r.ImpactStartedAt.Value); !ok {
+		return domain.CustomerImpactUpdate{}, false
+	}
+	if update.ImpactEndedAt, ok = parseImpactTime(c, "impact_ended_at", r.ImpactEndedAt.Value); !ok {
+		return domain.CustomerImpactUpdate{}, false
+	}
+
+	return update, true
+}
+
+// parseImpactTime parses an optional RFC3339 timestamp; nil and empty values yield nil
+func parseImpactTime(c *gin.Context, field string, value *string) (*time.Time, bool) {
+	if value == nil || *value == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s format (expected RFC3339)", field)})
+		return nil, false
+	}
+	return &parsed, true
 }
 
 type IncidentListResponse struct {
@@ -74,6 +172,11 @@ func (h *IncidentHandler) Create(c *gin.Context) {
 		return
 	}
 
+	impact, ok := req.CustomerImpactRequest.toDomain(c)
+	if !ok {
+		return
+	}
+
 	incident, err := h.incidentUsecase.CreateIncident(
 		c.Request.Context(),
 		userID,
@@ -82,9 +185,11 @@ func (h *IncidentHandler) Create(c *gin.Context) {
 		domain.Severity(req.Severity),
 		domain.Status(req.Status),
 		req.ImpactScope,
+		req.Service,
 		detectedAt,
 		req.AssigneeID,
 		req.TagIDs,
+		impact,
 	)
 	if err != nil {
 		HandleError(c, err)
@@ -134,6 +239,7 @@ func (h *IncidentHandler) GetAll(c *gin.Context) {
 		SortBy:       sortBy,
 		Order:        order,
 		AssignedToID: assignedToID,
+		Service:      c.Query("service"),
 	}
 
 	pagination := domain.Pagination{
@@ -229,6 +335,11 @@ func (h *IncidentHandler) Update(c *gin.Context) {
 		resolvedAt = &parsed
 	}
 
+	impact, ok := req.CustomerImpactRequest.toUpdate(c)
+	if !ok {
+		return
+	}
+
 	incident, err := h.incidentUsecase.UpdateIncident(
 		c.Request.Context(),
 		userIDUint,
@@ -239,10 +350,12 @@ func (h *IncidentHandler) Update(c *gin.Context) {
 		domain.Severity(req.Severity),
 		domain.Status(req.Status),
 		req.ImpactScope,
+		req.Service,
 		detectedAt,
 		resolvedAt,
 		req.AssigneeID,
 		req.TagIDs,
+		impact,
 	)
 	if err != nil {
 		HandleError(c, err)
@@ -357,3 +470,32 @@ func (h *IncidentHandler) AssignIncident(c *gin.Context) {
 
 	c.JSON(http.StatusOK, incident)
 }
+
+func (h *IncidentHandler) Acknowledge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid incident ID"})
+		return
+	}
+
+	// Get user ID from context
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	userID, ok := userIDValue.(uint)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID format"})
+		return
+	}
+
+	incident, err := h.incidentUsecase.AcknowledgeIncident(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"incidex/internal/domain"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCustomerImpactRequestToUpdate(t *testing.T) {
+	users, loss := int64(1200), 50000.0
+	current := func() *domain.Incident {
+		return &domain.Incident{CustomerImpact: domain.CustomerImpact{
+			AffectedUsers:        &users,
+			FailedRequests:       &users,
+			EstimatedRevenueLoss: &loss,
+			AffectedRegions:      []string{"ap-northeast-1"},
+		}}
+	}
+
+	tests := []struct {
+		name  string
+		body  string
+		check func(t *testing.T, incident *domain.Incident)
+	}{
+		{
+			name: "omitted fields are kept",
+			body: `{"failed_requests": 30}`,
+			check: func(t *testing.T, incident *domain.Incident) {
+				if incident.AffectedUsers == nil || *incident.AffectedUsers != 1200 {
+					t.Errorf("affected_users = %v, want 1200", incident.AffectedUsers)
+				}
+				if incident.FailedRequests == nil || *incident.FailedRequests != 30 {
+					t.Errorf("failed_requests = %v, want 30", incident.FailedRequests)
+				}
+				if len(incident.AffectedRegions) != 1 {
+					t.Errorf("affected_regions = %v, want them kept", incident.AffectedRegions)
+				}
+			},
+		},
+		{
+			name: "null clears",
+			body: `{"affected_users": null, "failed_requests": null, "estimated_revenue_loss": null, "affected_regions": []}`,
+			check: func(t *testing.T, incident *domain.Incident) {
+				if incident.AffectedUsers != nil || incident.FailedRequests != nil || incident.EstimatedRevenueLoss != nil {
+					t.Errorf("impact figures = %v, %v, %v, want all cleared",
+						incident.AffectedUsers, incident.FailedRequests, incident.EstimatedRevenueLoss)
+				}
+				if len(incident.AffectedRegions) != 0 {
+					t.Errorf("affected_regions = %v, want them cleared", incident.AffectedRegions)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req CustomerImpactRequest
+			if err := json.Unmarshal([]byte(tt.body), &req); err != nil {
+				t.Fatalf("invalid request: %v", err)
+			}
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+			update, ok := req.toUpdate(c)
+			if !ok {
+				t.Fatal("toUpdate rejected the request")
+			}
+			incident := current()
+			incident.ApplyCustomerImpact(update)
+			tt.check(t, incident)
+		})
+	}
+}
+
+func TestCustomerImpactRequestClearsImpactWindow(t *testing.T) {
+	started := "2025-03-01T09:00:00Z"
+	for _, body := range []string{`{"impact_started_at": null}`, `{"impact_started_at": ""}`} {
+		var req CustomerImpactRequest
+		if err := json.Unmarshal([]byte(body), &req); err != nil {
+			t.Fatalf("invalid request %s: %v", body, err)
+		}
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		update, ok := req.toUpdate(c)
+		if !ok {
+			t.Fatalf("toUpdate rejected %s", body)
+		}
+		if !update.ClearImpactStartedAt || update.ClearImpactEndedAt {
+			t.Errorf("%s: clear flags = %v, %v, want only the start cleared", body, update.ClearImpactStartedAt, update.ClearImpactEndedAt)
+		}
+	}
+
+	var req CustomerImpactRequest
+	if err := json.Unmarshal([]byte(`{"impact_started_at": "`+started+`"}`), &req); err != nil {
+		t.Fatalf("invalid request: %v", err)
+	}
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	update, ok := req.toUpdate(c)
+	if !ok || update.ClearImpactStartedAt || update.ImpactStartedAt == nil || update.ImpactStartedAt.Format(time.RFC3339) != started {
+		t.Errorf("update = %+v, want impact_started_at %s", update, started)
+	}
+}
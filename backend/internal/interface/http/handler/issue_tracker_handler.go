@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBodySize limits the payloads accepted from issue trackers
+const maxWebhookBodySize = 5 << 20
+
+type IssueTrackerHandler struct {
+	issueTrackerUsecase *usecase.IssueTrackerUsecase
+}
+
+func NewIssueTrackerHandler(issueTrackerUsecase *usecase.IssueTrackerUsecase) *IssueTrackerHandler {
+	return &IssueTrackerHandler{
+		issueTrackerUsecase: issueTrackerUsecase,
+	}
+}
+
+// GetTrackers godoc
+// @Summary List the configured issue trackers
+// @Description Trackers that action items can be opened in as issues
+// @Tags issue-trackers
+// @Produce json
+// @Success 200 {object} map[string][]string
+// @Failure 401 {object} ErrorResponse
+// @Router /api/issue-trackers [get]
+// @Security BearerAuth
+func (h *IssueTrackerHandler) GetTrackers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"trackers": h.issueTrackerUsecase.Trackers()})
+}
+
+// Webhook godoc
+// @Summary Receive an issue tracker webhook
+// @Description Closing an issue opened for an action item completes the item, reopening it reopens the item. Authenticated by the webhook signature (GitHub: X-Hub-Signature-256, Jira: X-Hub-Signature).
+// @Tags issue-trackers
+// @Accept json
+// @Produce json
+// @Param tracker path string true "Issue tracker (github, jira)"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/issue-trackers/{tracker}/webhook [post]
+func (h *IssueTrackerHandler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read webhook body"})
+		return
+	}
+
+	kind := domain.IssueTrackerKind(c.Param("tracker"))
+	if err := h.issueTrackerUsecase.HandleWebhook(c.Request.Context(), kind, c.Request.Header, body); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KnowledgeBaseHandler struct {
+	knowledgeBaseUsecase *usecase.KnowledgeBaseUsecase
+}
+
+func NewKnowledgeBaseHandler(knowledgeBaseUsecase *usecase.KnowledgeBaseUsecase) *KnowledgeBaseHandler {
+	return &KnowledgeBaseHandler{
+		knowledgeBaseUsecase: knowledgeBaseUsecase,
+	}
+}
+
+// Search godoc
+// @Summary Search the lessons learned of published post-mortems
+// @Description Full-text search over root causes, lessons learned and what went wrong, ranked by relevance with highlighted snippets and facets by tag, service and causal category
+// @Tags knowledge-base
+// @Produce json
+// @Param q query string false "Search words (all must occur)"
+// @Param tag_ids query string false "Comma-separated tag IDs"
+// @Param service query string false "Service"
+// @Param category query string false "Causal category (people, process, technology, external)"
+// @Param severity query string false "Incident severity"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} domain.KnowledgeBaseResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/knowledge-base/search [get]
+// @Security BearerAuth
+func (h *KnowledgeBaseHandler) Search(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	// Parse tag_ids (comma-separated)
+	var tagIDs []uint
+	if tagIDsStr := c.Query("tag_ids"); tagIDsStr != "" {
+		for _, idStr := range strings.Split(tagIDsStr, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(idStr), 10, 32)
+			if err == nil {
+				tagIDs = append(tagIDs, uint(id))
+			}
+		}
+	}
+
+	query := domain.KnowledgeBaseQuery{
+		Query:    c.Query("q"),
+		TagIDs:   tagIDs,
+		Service:  c.Query("service"),
+		Category: domain.CausalCategory(c.Query("category")),
+		Severity: domain.Severity(c.Query("severity")),
+	}
+
+	result, err := h.knowledgeBaseUsecase.Search(c.Request.Context(), query, domain.Pagination{Page: page, Limit: limit})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSimilarPostMortems godoc
+// @Summary Get similar past post-mortems of an incident
+// @Description Published post-mortems of other incidents sharing words of the title/description, the service or tags
+// @Tags knowledge-base
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {array} domain.KnowledgeBaseHit
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/incidents/{id}/similar-post-mortems [get]
+// @Security BearerAuth
+func (h *KnowledgeBaseHandler) GetSimilarPostMortems(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	hits, err := h.knowledgeBaseUsecase.FindSimilarForIncident(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hits)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationDeliveryHandler struct {
+	deliveryUsecase *usecase.NotificationDeliveryUsecase
+}
+
+func NewNotificationDeliveryHandler(deliveryUsecase *usecase.NotificationDeliveryUsecase) *NotificationDeliveryHandler {
+	return &NotificationDeliveryHandler{
+		deliveryUsecase: deliveryUsecase,
+	}
+}
+
+// GetByIncidentID godoc
+// @Summary Get the notification delivery log of an incident
+// @Description Every attempt to deliver a notification about the incident, newest first: recipient, channel, event, payload hash, result, error and latency
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(50)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/incidents/{id}/notifications [get]
+// @Security BearerAuth
+func (h *NotificationDeliveryHandler) GetByIncidentID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	deliveries, pagination, err := h.deliveryUsecase.ListForIncident(c.Request.Context(), uint(id), domain.Pagination{Page: page, Limit: limit})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"pagination": pagination,
+	})
+}
+
+// Search godoc
+// @Summary Search the notification delivery log
+// @Description Attempts to deliver notifications, newest first, to prove who was notified of what and when. Admin only.
+// @Tags notifications
+// @Produce json
+// @Param user_id query int false "Recipient user ID"
+// @Param incident_id query int false "Incident ID"
+// @Param channel query string false "Channel (email, slack, teams, discord, mattermost)"
+// @Param event_type query string false "Event type (e.g. incident_created, assigned, escalation)"
+// @Param result query string false "Result (sent, failed)"
+// @Param recipient query string false "Part of the recipient address"
+// @Param start_date query string false "Attempted at or after (RFC3339)"
+// @Param end_date query string false "Attempted at or before (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(50)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notification-deliveries [get]
+// @Security BearerAuth
+func (h *NotificationDeliveryHandler) Search(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filters := domain.NotificationDeliveryFilters{
+		Channel:   domain.NotificationChannel(c.Query("channel")),
+		EventType: domain.NotificationEvent(c.Query("event_type")),
+		Result:    domain.DeliveryResult(c.Query("result")),
+		Recipient: c.Query("recipient"),
+	}
+
+	if userID := c.Query("user_id"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			return
+		}
+		uid := uint(id)
+		filters.UserID = &uid
+	}
+	if incidentID := c.Query("incident_id"); incidentID != "" {
+		id, err := strconv.ParseUint(incidentID, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident_id"})
+			return
+		}
+		iid := uint(id)
+		filters.IncidentID = &iid
+	}
+	if startDate := c.Query("start_date"); startDate != "" {
+		sd, err := time.Parse(time.RFC3339, startDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format, use RFC3339"})
+			return
+		}
+		filters.StartDate = &sd
+	}
+	if endDate := c.Query("end_date"); endDate != "" {
+		ed, err := time.Parse(time.RFC3339, endDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format, use RFC3339"})
+			return
+		}
+		filters.EndDate = &ed
+	}
+
+	deliveries, pagination, err := h.deliveryUsecase.Search(c.Request.Context(), filters, domain.Pagination{Page: page, Limit: limit})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"pagination": pagination,
+	})
+}
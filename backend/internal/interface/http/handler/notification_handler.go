@@ -128,3 +128,155 @@ func (h *NotificationHandler) GetUserNotificationSetting(c *gin.Context) {
 
 	c.JSON(http.StatusOK, setting)
 }
+
+// NotificationEndpointRequest represents the request body for creating or updating a notification endpoint
+type NotificationEndpointRequest struct {
+	Channel domain.NotificationChannel `json:"channel" binding:"required"` // email, slack, teams, discord, mattermost
+	Name    string                     `json:"name"`
+	Target  string                     `json:"target" binding:"required"` // メールアドレスまたはWebhook URL
+	Enabled *bool                      `json:"enabled"`                   // 省略時は true
+}
+
+func (r NotificationEndpointRequest) input() usecase.NotificationEndpointInput {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+	return usecase.NotificationEndpointInput{
+		Channel: r.Channel,
+		Name:    r.Name,
+		Target:  r.Target,
+		Enabled: enabled,
+	}
+}
+
+// ListMyNotificationEndpoints godoc
+// @Summary List my notification endpoints
+// @Description Chat webhooks and extra email addresses notifications are sent to besides the account email
+// @Tags notifications
+// @Produce json
+// @Success 200 {array} domain.NotificationEndpoint
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/endpoints [get]
+// @Security BearerAuth
+func (h *NotificationHandler) ListMyNotificationEndpoints(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.notificationUsecase.ListEndpoints(c.Request.Context(), userID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoints)
+}
+
+// CreateMyNotificationEndpoint godoc
+// @Summary Add a notification endpoint
+// @Description Add a Slack, Microsoft Teams, Discord or Mattermost incoming webhook, or an email address, to be notified at
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param endpoint body NotificationEndpointRequest true "Endpoint details"
+// @Success 201 {object} domain.NotificationEndpoint
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/endpoints [post]
+// @Security BearerAuth
+func (h *NotificationHandler) CreateMyNotificationEndpoint(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req NotificationEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.notificationUsecase.CreateEndpoint(c.Request.Context(), userID, req.input())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+// UpdateMyNotificationEndpoint godoc
+// @Summary Update a notification endpoint
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Param endpoint body NotificationEndpointRequest true "Endpoint details"
+// @Success 200 {object} domain.NotificationEndpoint
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notifications/endpoints/{id} [put]
+// @Security BearerAuth
+func (h *NotificationHandler) UpdateMyNotificationEndpoint(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid endpoint ID"})
+		return
+	}
+
+	var req NotificationEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.notificationUsecase.UpdateEndpoint(c.Request.Context(), userID, uint(id), req.input())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// DeleteMyNotificationEndpoint godoc
+// @Summary Delete a notification endpoint
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Endpoint ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/notifications/endpoints/{id} [delete]
+// @Security BearerAuth
+func (h *NotificationHandler) DeleteMyNotificationEndpoint(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid endpoint ID"})
+		return
+	}
+
+	if err := h.notificationUsecase.DeleteEndpoint(c.Request.Context(), userID, uint(id)); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification endpoint deleted successfully"})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationOutboxHandler struct {
+	outboxUsecase *usecase.NotificationOutboxUsecase
+}
+
+func NewNotificationOutboxHandler(outboxUsecase *usecase.NotificationOutboxUsecase) *NotificationOutboxHandler {
+	return &NotificationOutboxHandler{
+		outboxUsecase: outboxUsecase,
+	}
+}
+
+// List godoc
+// @Summary List queued notifications
+// @Description Notifications of the outbox, newest first, with their delivery state, attempts and last error. Webhook URLs are masked. Admin only.
+// @Tags notifications
+// @Produce json
+// @Param status query string false "Delivery state (pending, sent, dead)"
+// @Param channel query string false "Channel (email, slack, teams, discord, mattermost)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(50)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notification-outbox [get]
+// @Security BearerAuth
+func (h *NotificationOutboxHandler) List(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filters := domain.NotificationOutboxFilters{
+		Status:  domain.OutboxStatus(c.Query("status")),
+		Channel: domain.NotificationChannel(c.Query("channel")),
+	}
+
+	messages, pagination, err := h.outboxUsecase.List(c.Request.Context(), filters, domain.Pagination{Page: page, Limit: limit})
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": messages,
+		"pagination":    pagination,
+	})
+}
+
+// Get godoc
+// @Summary Get a queued notification
+// @Description A notification of the outbox including its payload. Admin only.
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} domain.NotificationOutbox
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notification-outbox/{id} [get]
+// @Security BearerAuth
+func (h *NotificationOutboxHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	message, err := h.outboxUsecase.Get(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// Replay godoc
+// @Summary Replay a dead-lettered notification
+// @Description Queues a notification that gave up after its maximum number of attempts again, with a fresh set of attempts. Admin only.
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} domain.NotificationOutbox
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notification-outbox/{id}/replay [post]
+// @Security BearerAuth
+func (h *NotificationOutboxHandler) Replay(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	message, err := h.outboxUsecase.Replay(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// ReplayAll godoc
+// @Summary Replay all dead-lettered notifications
+// @Description Queues every dead-lettered notification, optionally of one channel, again. Admin only.
+// @Tags notifications
+// @Produce json
+// @Param channel query string false "Channel (email, slack, teams, discord, mattermost)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/notification-outbox/replay [post]
+// @Security BearerAuth
+func (h *NotificationOutboxHandler) ReplayAll(c *gin.Context) {
+	count, err := h.outboxUsecase.ReplayAll(c.Request.Context(), domain.NotificationChannel(c.Query("channel")))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"replayed": count})
+}
@@ -0,0 +1,453 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OnCallHandler struct {
+	onCallUsecase usecase.OnCallUsecase
+}
+
+func NewOnCallHandler(onCallUsecase usecase.OnCallUsecase) *OnCallHandler {
+	return &OnCallHandler{
+		onCallUsecase: onCallUsecase,
+	}
+}
+
+type OnCallLayerRequest struct {
+	Name           string `json:"name" binding:"max=200"`
+	RotationType   string `json:"rotation_type" binding:"required,oneof=daily weekly"`
+	StartDate      string `json:"start_date" binding:"required"`   // YYYY-MM-DD
+	HandoffTime    string `json:"handoff_time" binding:"required"` // HH:MM
+	ParticipantIDs []uint `json:"participant_ids" binding:"required,min=1"`
+}
+
+type OnCallScheduleRequest struct {
+	Name        string               `json:"name" binding:"required,max=200"`
+	Description string               `json:"description"`
+	Timezone    string               `json:"timezone" binding:"required"`
+	AutoAssign  bool                 `json:"auto_assign"`
+	Severity    string               `json:"severity" binding:"omitempty,oneof=critical high medium low"`
+	TagID       *uint                `json:"tag_id"`
+	Service     string               `json:"service" binding:"max=100"`
+	Layers      []OnCallLayerRequest `json:"layers" binding:"required,min=1,dive"`
+}
+
+type OnCallOverrideRequest struct {
+	UserID  uint   `json:"user_id" binding:"required"`
+	StartAt string `json:"start_at" binding:"required"` // RFC3339 format
+	EndAt   string `json:"end_at" binding:"required"`   // RFC3339 format
+	Reason  string `json:"reason" binding:"max=500"`
+}
+
+// toLayers converts the request layers into domain layers in the given order.
+func (r *OnCallScheduleRequest) toLayers() []domain.OnCallLayer {
+	layers := make([]domain.OnCallLayer, len(r.Layers))
+	for i, layer := range r.Layers {
+		layers[i] = domain.OnCallLayer{
+			Name:         layer.Name,
+			RotationType: domain.RotationType(layer.RotationType),
+			StartDate:    layer.StartDate,
+			HandoffTime:  layer.HandoffTime,
+		}
+		for _, userID := range layer.ParticipantIDs {
+			layers[i].Participants = append(layers[i].Participants, domain.OnCallParticipant{UserID: userID})
+		}
+	}
+	return layers
+}
+
+// Create godoc
+// @Summary Create an on-call schedule
+// @Description Create an on-call schedule with rotation layers (admin only)
+// @Tags oncall
+// @Accept json
+// @Produce json
+// @Param schedule body OnCallScheduleRequest true "On-call schedule data"
+// @Success 201 {object} domain.OnCallSchedule
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/oncall-schedules [post]
+// @Security BearerAuth
+func (h *OnCallHandler) Create(c *gin.Context) {
+	var req OnCallScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userID, ok := userIDValue.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user ID"})
+		return
+	}
+
+	schedule, err := h.onCallUsecase.CreateSchedule(
+		c.Request.Context(),
+		userID,
+		req.Name,
+		req.Description,
+		req.Timezone,
+		req.AutoAssign,
+		domain.Severity(req.Severity),
+		req.TagID,
+		req.Service,
+		req.toLayers(),
+	)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// GetAll godoc
+// @Summary List on-call schedules
+// @Description Get all on-call schedules with their layers
+// @Tags oncall
+// @Produce json
+// @Success 200 {array} domain.OnCallSchedule
+// @Failure 500 {object} map[string]string
+// @Router /api/oncall-schedules [get]
+// @Security BearerAuth
+func (h *OnCallHandler) GetAll(c *gin.Context) {
+	schedules, err := h.onCallUsecase.GetAllSchedules(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// GetByID godoc
+// @Summary Get on-call schedule by ID
+// @Description Get an on-call schedule with its layers and participants
+// @Tags oncall
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} domain.OnCallSchedule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id} [get]
+// @Security BearerAuth
+func (h *OnCallHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	schedule, err := h.onCallUsecase.GetScheduleByID(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// Update godoc
+// @Summary Update an on-call schedule
+// @Description Update an on-call schedule; layers are replaced as a whole (admin only)
+// @Tags oncall
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param schedule body OnCallScheduleRequest true "On-call schedule data"
+// @Success 200 {object} domain.OnCallSchedule
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id} [put]
+// @Security BearerAuth
+func (h *OnCallHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	var req OnCallScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.onCallUsecase.UpdateSchedule(
+		c.Request.Context(),
+		uint(id),
+		req.Name,
+		req.Description,
+		req.Timezone,
+		req.AutoAssign,
+		domain.Severity(req.Severity),
+		req.TagID,
+		req.Service,
+		req.toLayers(),
+	)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// Delete godoc
+// @Summary Delete an on-call schedule
+// @Description Delete an on-call schedule (admin only)
+// @Tags oncall
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id} [delete]
+// @Security BearerAuth
+func (h *OnCallHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	if err := h.onCallUsecase.DeleteSchedule(c.Request.Context(), uint(id)); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "On-call schedule deleted successfully"})
+}
+
+// GetOnCall godoc
+// @Summary Who is on call
+// @Description Get the on-call user of a schedule at the given time (defaults to now)
+// @Tags oncall
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param at query string false "Point in time (RFC3339)"
+// @Success 200 {object} domain.OnCallShift
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id}/oncall [get]
+// @Security BearerAuth
+func (h *OnCallHandler) GetOnCall(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	at := time.Now()
+	if atStr := c.Query("at"); atStr != "" {
+		at, err = time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid at format (expected RFC3339)"})
+			return
+		}
+	}
+
+	shift, err := h.onCallUsecase.WhoIsOnCall(c.Request.Context(), uint(id), at)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shift)
+}
+
+// GetShifts godoc
+// @Summary List on-call shifts
+// @Description Get the effective shifts of a schedule overlapping the given period (defaults to the next 30 days)
+// @Tags oncall
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param from query string false "Start of period (RFC3339)"
+// @Param to query string false "End of period (RFC3339)"
+// @Success 200 {array} domain.OnCallShift
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id}/shifts [get]
+// @Security BearerAuth
+func (h *OnCallHandler) GetShifts(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	from := time.Now()
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format (expected RFC3339)"})
+			return
+		}
+	}
+	to := from.AddDate(0, 0, 30)
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format (expected RFC3339)"})
+			return
+		}
+	}
+
+	shifts, err := h.onCallUsecase.GetShifts(c.Request.Context(), uint(id), from, to)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shifts)
+}
+
+// GetOverrides godoc
+// @Summary List on-call overrides
+// @Description Get overrides of a schedule overlapping the given period (defaults to the next 30 days)
+// @Tags oncall
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param from query string false "Start of period (RFC3339)"
+// @Param to query string false "End of period (RFC3339)"
+// @Success 200 {array} domain.OnCallOverride
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id}/overrides [get]
+// @Security BearerAuth
+func (h *OnCallHandler) GetOverrides(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	from := time.Now()
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format (expected RFC3339)"})
+			return
+		}
+	}
+	to := from.AddDate(0, 0, 30)
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format (expected RFC3339)"})
+			return
+		}
+	}
+
+	overrides, err := h.onCallUsecase.GetOverrides(c.Request.Context(), uint(id), from, to)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overrides)
+}
+
+// CreateOverride godoc
+// @Summary Create an on-call override
+// @Description Temporarily replace the on-call user of a schedule
+// @Tags oncall
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param override body OnCallOverrideRequest true "Override data"
+// @Success 201 {object} domain.OnCallOverride
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id}/overrides [post]
+// @Security BearerAuth
+func (h *OnCallHandler) CreateOverride(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	var req OnCallOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_at format (expected RFC3339)"})
+		return
+	}
+	endAt, err := time.Parse(time.RFC3339, req.EndAt)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_at format (expected RFC3339)"})
+		return
+	}
+
+	userIDValue, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	userID, ok := userIDValue.(uint)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user ID"})
+		return
+	}
+
+	override, err := h.onCallUsecase.CreateOverride(c.Request.Context(), userID, uint(id), req.UserID, startAt, endAt, req.Reason)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, override)
+}
+
+// DeleteOverride godoc
+// @Summary Delete an on-call override
+// @Description Delete an override of a schedule
+// @Tags oncall
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param overrideId path int true "Override ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/oncall-schedules/{id}/overrides/{overrideId} [delete]
+// @Security BearerAuth
+func (h *OnCallHandler) DeleteOverride(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	overrideID, err := strconv.ParseUint(c.Param("overrideId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid override ID"})
+		return
+	}
+
+	if err := h.onCallUsecase.DeleteOverride(c.Request.Context(), uint(id), uint(overrideID)); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "On-call override deleted successfully"})
+}
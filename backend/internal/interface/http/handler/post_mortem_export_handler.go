@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportPDF godoc
+// @Summary Export a post-mortem to PDF
+// @Description Generate a PDF of the post-mortem with the incident header, timeline, all sections, causal analysis and action items. The document states which version it shows.
+// @Tags post-mortems
+// @Produce application/pdf
+// @Param id path int true "Post-mortem ID"
+// @Param version query int false "Published version to export (default: current content)"
+// @Param tz query string false "IANA timezone used for times (default Asia/Tokyo)"
+// @Success 200 {file} file "PDF file"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/pdf [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) ExportPDF(c *gin.Context) {
+	doc, loc, ok := h.postMortemDocument(c)
+	if !ok {
+		return
+	}
+
+	pdfBytes, err := h.pdfService.GeneratePostMortemReport(doc, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate PDF: %v", err)})
+		return
+	}
+
+	filename := postMortemExportFilename(doc, "pdf")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Length", strconv.Itoa(len(pdfBytes)))
+	c.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// ExportMarkdown godoc
+// @Summary Export a post-mortem to Markdown
+// @Description Render the post-mortem with the incident header, timeline, all sections, causal analysis and action items as Markdown (e.g. for a wiki). The document states which version it shows.
+// @Tags post-mortems
+// @Produce text/markdown
+// @Param id path int true "Post-mortem ID"
+// @Param version query int false "Published version to export (default: current content)"
+// @Param tz query string false "IANA timezone used for times (default Asia/Tokyo)"
+// @Success 200 {string} string "Markdown document"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/markdown [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) ExportMarkdown(c *gin.Context) {
+	doc, loc, ok := h.postMortemDocument(c)
+	if !ok {
+		return
+	}
+
+	body := h.documentService.GeneratePostMortemReport(doc, loc)
+
+	filename := postMortemExportFilename(doc, "md")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(body))
+}
+
+// ExportHTML godoc
+// @Summary Export a post-mortem to HTML
+// @Description Render the post-mortem as a standalone HTML page (no external assets) with the same content as the Markdown export, for sharing with people without an account.
+// @Tags post-mortems
+// @Produce text/html
+// @Param id path int true "Post-mortem ID"
+// @Param version query int false "Published version to export (default: current content)"
+// @Param tz query string false "IANA timezone used for times (default Asia/Tokyo)"
+// @Success 200 {string} string "HTML document"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/html [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) ExportHTML(c *gin.Context) {
+	doc, loc, ok := h.postMortemDocument(c)
+	if !ok {
+		return
+	}
+
+	body, err := h.documentService.GeneratePostMortemHTML(doc, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate HTML: %v", err)})
+		return
+	}
+
+	filename := postMortemExportFilename(doc, "html")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", body)
+}
+
+// postMortemDocument loads the post-mortem for export and the timezone to show times in,
+// writing an error response on failure.
+func (h *PostMortemHandler) postMortemDocument(c *gin.Context) (*domain.PostMortemDocument, *time.Location, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return nil, nil, false
+	}
+	version, err := strconv.Atoi(c.DefaultQuery("version", "0"))
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return nil, nil, false
+	}
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", defaultTimezone))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tz"})
+		return nil, nil, false
+	}
+
+	doc, err := h.postMortemUsecase.GetPostMortemDocument(c.Request.Context(), uint(id), version)
+	if err != nil {
+		HandleError(c, err)
+		return nil, nil, false
+	}
+	return doc, loc, true
+}
+
+func postMortemExportFilename(doc *domain.PostMortemDocument, ext string) string {
+	if doc.Version > 0 {
+		return fmt.Sprintf("postmortem_%d_v%d.%s", doc.PostMortem.ID, doc.Version, ext)
+	}
+	return fmt.Sprintf("postmortem_%d_%s.%s", doc.PostMortem.ID, time.Now().Format("20060102"), ext)
+}
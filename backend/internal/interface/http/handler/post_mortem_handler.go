@@ -2,6 +2,8 @@ package handler
 
 import (
 	"incidex/internal/domain"
+	"incidex/internal/infrastructure/markdown"
+	"incidex/internal/infrastructure/pdf"
 	"incidex/internal/usecase"
 	"net/http"
 	"strconv"
@@ -11,11 +13,15 @@ import (
 
 type PostMortemHandler struct {
 	postMortemUsecase usecase.PostMortemUsecase
+	pdfService        *pdf.IncidentPDFService
+	documentService   *markdown.DocumentService
 }
 
 func NewPostMortemHandler(postMortemUsecase usecase.PostMortemUsecase) *PostMortemHandler {
 	return &PostMortemHandler{
 		postMortemUsecase: postMortemUsecase,
+		pdfService:        pdf.NewIncidentPDFService(),
+		documentService:   markdown.NewDocumentService(),
 	}
 }
 
@@ -27,6 +33,8 @@ type CreatePostMortemRequest struct {
 	WhatWentWrong    string                     `json:"what_went_wrong"`
 	LessonsLearned   string                     `json:"lessons_learned"`
 	FiveWhysAnalysis *domain.FiveWhysAnalysis   `json:"five_whys_analysis"`
+	TemplateID       *uint                      `json:"template_id"` // 省略時はインシデントのタグ・重要度から選択
+	Sections         []domain.PostMortemSection `json:"sections"`
 }
 
 type UpdatePostMortemRequest struct {
@@ -36,6 +44,7 @@ type UpdatePostMortemRequest struct {
 	WhatWentWrong    string                     `json:"what_went_wrong"`
 	LessonsLearned   string                     `json:"lessons_learned"`
 	FiveWhysAnalysis *domain.FiveWhysAnalysis   `json:"five_whys_analysis"`
+	Sections         []domain.PostMortemSection `json:"sections"` // 指定したセクションのみ更新
 }
 
 // Create godoc
@@ -80,6 +89,8 @@ func (h *PostMortemHandler) Create(c *gin.Context) {
 		req.WhatWentWrong,
 		req.LessonsLearned,
 		req.FiveWhysAnalysis,
+		req.TemplateID,
+		req.Sections,
 	)
 	if err != nil {
 		HandleError(c, err)
@@ -276,6 +287,7 @@ func (h *PostMortemHandler) Update(c *gin.Context) {
 		req.WhatWentWrong,
 		req.LessonsLearned,
 		req.FiveWhysAnalysis,
+		req.Sections,
 	)
 	if err != nil {
 		HandleError(c, err)
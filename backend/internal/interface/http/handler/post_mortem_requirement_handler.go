@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PostMortemRequirementHandler struct {
+	requirementUsecase *usecase.PostMortemRequirementUsecase
+}
+
+func NewPostMortemRequirementHandler(requirementUsecase *usecase.PostMortemRequirementUsecase) *PostMortemRequirementHandler {
+	return &PostMortemRequirementHandler{
+		requirementUsecase: requirementUsecase,
+	}
+}
+
+// PostMortemPolicyRequest represents the request body for creating or updating a mandatory post-mortem policy
+type PostMortemPolicyRequest struct {
+	Name                 string          `json:"name" binding:"required"`
+	Description          string          `json:"description"`
+	IsActive             *bool           `json:"is_active"`              // 省略時は true
+	Severity             domain.Severity `json:"severity"`               // 空の場合は全ての重要度が対象
+	TagID                *uint           `json:"tag_id"`                 // 省略時は全てのタグが対象
+	DueBusinessDays      int             `json:"due_business_days"`      // 省略時は 5 営業日
+	ReminderBusinessDays int             `json:"reminder_business_days"` // 期限の何営業日前にリマインドするか
+}
+
+func (r PostMortemPolicyRequest) input() usecase.PostMortemPolicyInput {
+	isActive := true
+	if r.IsActive != nil {
+		isActive = *r.IsActive
+	}
+	return usecase.PostMortemPolicyInput{
+		Name:                 r.Name,
+		Description:          r.Description,
+		IsActive:             isActive,
+		Severity:             r.Severity,
+		TagID:                r.TagID,
+		DueBusinessDays:      r.DueBusinessDays,
+		ReminderBusinessDays: r.ReminderBusinessDays,
+	}
+}
+
+// WaivePostMortemRequest represents the request body for waiving a mandatory post-mortem
+type WaivePostMortemRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CreatePolicy godoc
+// @Summary Create a mandatory post-mortem policy
+// @Description Require a post-mortem within a number of business days for resolved incidents matching the severity/tag (admin only)
+// @Tags post-mortem-policies
+// @Accept json
+// @Produce json
+// @Param policy body PostMortemPolicyRequest true "Policy details"
+// @Success 201 {object} domain.PostMortemPolicy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortem-policies [post]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) CreatePolicy(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req PostMortemPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.requirementUsecase.CreatePolicy(c.Request.Context(), userID, req.input())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// GetAllPolicies godoc
+// @Summary Get all mandatory post-mortem policies
+// @Tags post-mortem-policies
+// @Produce json
+// @Success 200 {array} domain.PostMortemPolicy
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortem-policies [get]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) GetAllPolicies(c *gin.Context) {
+	policies, err := h.requirementUsecase.GetAllPolicies(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// GetPolicyByID godoc
+// @Summary Get a mandatory post-mortem policy by ID
+// @Tags post-mortem-policies
+// @Produce json
+// @Param id path int true "Policy ID"
+// @Success 200 {object} domain.PostMortemPolicy
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/post-mortem-policies/{id} [get]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) GetPolicyByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy ID"})
+		return
+	}
+
+	policy, err := h.requirementUsecase.GetPolicyByID(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy godoc
+// @Summary Update a mandatory post-mortem policy
+// @Description Update a policy (admin only). Due dates of existing requirements are kept.
+// @Tags post-mortem-policies
+// @Accept json
+// @Produce json
+// @Param id path int true "Policy ID"
+// @Param policy body PostMortemPolicyRequest true "Policy details"
+// @Success 200 {object} domain.PostMortemPolicy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortem-policies/{id} [put]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) UpdatePolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy ID"})
+		return
+	}
+
+	var req PostMortemPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.requirementUsecase.UpdatePolicy(c.Request.Context(), uint(id), req.input())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy godoc
+// @Summary Delete a mandatory post-mortem policy
+// @Description Delete a policy (admin only). Requirements already created stay in force.
+// @Tags post-mortem-policies
+// @Produce json
+// @Param id path int true "Policy ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/post-mortem-policies/{id} [delete]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) DeletePolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy ID"})
+		return
+	}
+
+	if err := h.requirementUsecase.DeletePolicy(c.Request.Context(), uint(id)); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post-mortem policy deleted successfully"})
+}
+
+// GetRequirement godoc
+// @Summary Get the mandatory post-mortem requirement of an incident
+// @Tags post-mortem-policies
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {object} domain.PostMortemRequirement
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/incidents/{id}/postmortem/requirement [get]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) GetRequirement(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	requirement, err := h.requirementUsecase.GetRequirement(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requirement)
+}
+
+// WaiveRequirement godoc
+// @Summary Waive the mandatory post-mortem of an incident
+// @Description Allow the incident to be closed without a published post-mortem (admin only)
+// @Tags post-mortem-policies
+// @Accept json
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Param request body WaivePostMortemRequest true "Waiver reason"
+// @Success 200 {object} domain.PostMortemRequirement
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/incidents/{id}/postmortem/requirement/waive [post]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) WaiveRequirement(c *gin.Context) {
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	var req WaivePostMortemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	requirement, err := h.requirementUsecase.WaiveRequirement(c.Request.Context(), userID, role, uint(id), req.Reason)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requirement)
+}
+
+// GetComplianceReport godoc
+// @Summary Get the monthly mandatory post-mortem compliance
+// @Description Count required post-mortems per month of resolution: on time, late, overdue, pending and waived
+// @Tags post-mortem-policies
+// @Produce json
+// @Param months query int false "Number of months including the current one (1-24, default 12)"
+// @Success 200 {object} domain.PostMortemComplianceReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortems/compliance [get]
+// @Security BearerAuth
+func (h *PostMortemRequirementHandler) GetComplianceReport(c *gin.Context) {
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid months"})
+		return
+	}
+
+	report, err := h.requirementUsecase.GetComplianceReport(c.Request.Context(), months)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AssignReviewersRequest represents the request body for assigning post-mortem reviewers
+type AssignReviewersRequest struct {
+	ReviewerIDs []uint `json:"reviewer_ids"`
+}
+
+// ReviewDecisionRequest represents a reviewer's decision
+type ReviewDecisionRequest struct {
+	Decision domain.ReviewDecision `json:"decision" binding:"required"` // approved / changes_requested
+	Comment  string                `json:"comment"`                     // changes_requested の場合は必須
+}
+
+// ReviewCommentRequest represents the request body for adding a review comment
+type ReviewCommentRequest struct {
+	SectionKey string `json:"section_key"` // テンプレートのセクションキーまたは固定フィールド名（省略時は全体へのコメント）
+	Quote      string `json:"quote"`
+	Body       string `json:"body" binding:"required"`
+}
+
+// ResolveReviewCommentRequest represents the request body for resolving a review comment
+type ResolveReviewCommentRequest struct {
+	Resolved bool `json:"resolved"`
+}
+
+// AssignReviewers godoc
+// @Summary Assign reviewers to a post-mortem
+// @Description Replace the reviewers of a post-mortem. Reviewers added while in review are notified.
+// @Tags post-mortems
+// @Accept json
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param reviewers body AssignReviewersRequest true "Reviewer user IDs"
+// @Success 200 {object} domain.PostMortem
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/reviewers [put]
+// @Security BearerAuth
+func (h *PostMortemHandler) AssignReviewers(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	var req AssignReviewersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	pm, err := h.postMortemUsecase.AssignReviewers(c.Request.Context(), userID, role, uint(id), req.ReviewerIDs)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pm)
+}
+
+// SubmitForReview godoc
+// @Summary Submit a post-mortem for review
+// @Description Move a draft (or a post-mortem with requested changes) to in_review and notify the reviewers
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Success 200 {object} domain.PostMortem
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/submit-review [post]
+// @Security BearerAuth
+func (h *PostMortemHandler) SubmitForReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	pm, err := h.postMortemUsecase.SubmitForReview(c.Request.Context(), userID, role, uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pm)
+}
+
+// Review godoc
+// @Summary Approve a post-mortem or request changes
+// @Description Record the decision of an assigned reviewer. The post-mortem is approved once the required number of reviewers approved it.
+// @Tags post-mortems
+// @Accept json
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param review body ReviewDecisionRequest true "Review decision"
+// @Success 200 {object} domain.PostMortem
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/review [post]
+// @Security BearerAuth
+func (h *PostMortemHandler) Review(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	var req ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	pm, err := h.postMortemUsecase.ReviewPostMortem(c.Request.Context(), userID, uint(id), req.Decision, req.Comment)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pm)
+}
+
+// GetReviewComments godoc
+// @Summary Get the review comments of a post-mortem
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Success 200 {array} domain.PostMortemReviewComment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/comments [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) GetReviewComments(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	comments, err := h.postMortemUsecase.GetReviewComments(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// AddReviewComment godoc
+// @Summary Add a review comment anchored to a section
+// @Tags post-mortems
+// @Accept json
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param comment body ReviewCommentRequest true "Review comment"
+// @Success 201 {object} domain.PostMortemReviewComment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/comments [post]
+// @Security BearerAuth
+func (h *PostMortemHandler) AddReviewComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	var req ReviewCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.postMortemUsecase.AddReviewComment(c.Request.Context(), userID, uint(id), req.SectionKey, req.Quote, req.Body)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// ResolveReviewComment godoc
+// @Summary Resolve or reopen a review comment
+// @Tags post-mortems
+// @Accept json
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param commentId path int true "Review comment ID"
+// @Param resolve body ResolveReviewCommentRequest true "Resolved flag"
+// @Success 200 {object} domain.PostMortemReviewComment
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/comments/{commentId}/resolve [put]
+// @Security BearerAuth
+func (h *PostMortemHandler) ResolveReviewComment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	var req ResolveReviewCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.postMortemUsecase.ResolveReviewComment(c.Request.Context(), userID, role, uint(id), uint(commentID), req.Resolved)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"incidex/internal/domain"
+	"incidex/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PostMortemTemplateHandler struct {
+	templateUsecase *usecase.PostMortemTemplateUsecase
+}
+
+func NewPostMortemTemplateHandler(templateUsecase *usecase.PostMortemTemplateUsecase) *PostMortemTemplateHandler {
+	return &PostMortemTemplateHandler{
+		templateUsecase: templateUsecase,
+	}
+}
+
+// PostMortemTemplateRequest represents the request body for creating or updating a post-mortem template
+type PostMortemTemplateRequest struct {
+	Name              string                             `json:"name" binding:"required"`
+	Description       string                             `json:"description"`
+	Sections          []domain.PostMortemTemplateSection `json:"sections" binding:"required"`
+	Severity          domain.Severity                    `json:"severity"` // 空の場合は全ての重要度が対象
+	IsDefault         bool                               `json:"is_default"`
+	RequiredApprovals *int                               `json:"required_approvals"` // 省略時はシステム既定値
+	TagIDs            []uint                             `json:"tag_ids"`
+}
+
+// Create godoc
+// @Summary Create a post-mortem template
+// @Description Create a template defining the ordered sections of a post-mortem (admin only)
+// @Tags post-mortem-templates
+// @Accept json
+// @Produce json
+// @Param template body PostMortemTemplateRequest true "Template details"
+// @Success 201 {object} domain.PostMortemTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortem-templates [post]
+// @Security BearerAuth
+func (h *PostMortemTemplateHandler) Create(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req PostMortemTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.templateUsecase.CreateTemplate(
+		c.Request.Context(),
+		userID,
+		req.Name,
+		req.Description,
+		req.Sections,
+		req.Severity,
+		req.IsDefault,
+		req.RequiredApprovals,
+		req.TagIDs,
+	)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// GetAll godoc
+// @Summary Get all post-mortem templates
+// @Tags post-mortem-templates
+// @Produce json
+// @Success 200 {array} domain.PostMortemTemplate
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortem-templates [get]
+// @Security BearerAuth
+func (h *PostMortemTemplateHandler) GetAll(c *gin.Context) {
+	templates, err := h.templateUsecase.GetAllTemplates(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetByID godoc
+// @Summary Get a post-mortem template by ID
+// @Tags post-mortem-templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} domain.PostMortemTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/post-mortem-templates/{id} [get]
+// @Security BearerAuth
+func (h *PostMortemTemplateHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.templateUsecase.GetTemplateByID(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Update godoc
+// @Summary Update a post-mortem template
+// @Description Update a post-mortem template (admin only). Existing post-mortems keep their sections.
+// @Tags post-mortem-templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param template body PostMortemTemplateRequest true "Template details"
+// @Success 200 {object} domain.PostMortemTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/post-mortem-templates/{id} [put]
+// @Security BearerAuth
+func (h *PostMortemTemplateHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var req PostMortemTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := h.templateUsecase.UpdateTemplate(
+		c.Request.Context(),
+		uint(id),
+		req.Name,
+		req.Description,
+		req.Sections,
+		req.Severity,
+		req.IsDefault,
+		req.RequiredApprovals,
+		req.TagIDs,
+	)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Delete godoc
+// @Summary Delete a post-mortem template
+// @Description Delete a post-mortem template (admin only). Post-mortems written with it keep their sections.
+// @Tags post-mortem-templates
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/post-mortem-templates/{id} [delete]
+// @Security BearerAuth
+func (h *PostMortemTemplateHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	if err := h.templateUsecase.DeleteTemplate(c.Request.Context(), uint(id)); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Post-mortem template deleted successfully"})
+}
+
+// GetForIncident godoc
+// @Summary Get the post-mortem template for an incident
+// @Description Get the template selected by the incident's tags and severity (falls back to the default template)
+// @Tags post-mortem-templates
+// @Produce json
+// @Param id path int true "Incident ID"
+// @Success 200 {object} domain.PostMortemTemplate
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/incidents/{id}/postmortem/template [get]
+// @Security BearerAuth
+func (h *PostMortemTemplateHandler) GetForIncident(c *gin.Context) {
+	incidentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	template, err := h.templateUsecase.GetTemplateForIncident(c.Request.Context(), uint(incidentID))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListVersions godoc
+// @Summary List the published versions of a post-mortem
+// @Description A snapshot is kept every time the post-mortem is published. The list omits the content.
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Success 200 {array} domain.PostMortemVersion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/versions [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) ListVersions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+
+	versions, err := h.postMortemUsecase.ListVersions(c.Request.Context(), uint(id))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// GetVersion godoc
+// @Summary Get a published version of a post-mortem
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} domain.PostMortemVersion
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/versions/{version} [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) GetVersion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+
+	pmVersion, err := h.postMortemUsecase.GetVersion(c.Request.Context(), uint(id), version)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pmVersion)
+}
+
+// DiffVersions godoc
+// @Summary Compare two versions of a post-mortem
+// @Description Line diff of every changed field. Omit "to" (or pass 0) to compare against the current working copy.
+// @Tags post-mortems
+// @Produce json
+// @Param id path int true "Post-mortem ID"
+// @Param from query int true "Published version to compare from"
+// @Param to query int false "Version to compare to (0: current working copy)"
+// @Success 200 {object} domain.PostMortemVersionDiff
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/post-mortems/{id}/versions/diff [get]
+// @Security BearerAuth
+func (h *PostMortemHandler) DiffVersions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid post-mortem ID"})
+		return
+	}
+	from, err := strconv.Atoi(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from is required"})
+		return
+	}
+	to, err := strconv.Atoi(c.DefaultQuery("to", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to"})
+		return
+	}
+
+	diff, err := h.postMortemUsecase.DiffVersions(c.Request.Context(), uint(id), from, to)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
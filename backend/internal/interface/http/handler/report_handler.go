@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
 	"incidex/internal/domain"
+	"incidex/internal/infrastructure/markdown"
 	"incidex/internal/infrastructure/pdf"
 	"incidex/internal/usecase"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -16,6 +20,7 @@ type ReportHandler struct {
 	reportUsecase  usecase.ReportUsecase
 	incidentUsecase usecase.IncidentUsecase
 	pdfService     *pdf.IncidentPDFService
+	documentService *markdown.DocumentService
 }
 
 func NewReportHandler(u usecase.ReportUsecase, incidentUsecase usecase.IncidentUsecase) *ReportHandler {
@@ -23,6 +28,7 @@ func NewReportHandler(u usecase.ReportUsecase, incidentUsecase usecase.IncidentU
 		reportUsecase: u,
 		incidentUsecase: incidentUsecase,
 		pdfService:    pdf.NewIncidentPDFService(),
+		documentService: markdown.NewDocumentService(),
 	}
 }
 
@@ -181,6 +187,26 @@ func (h *ReportHandler) GetMonthlyReportPDF(c *gin.Context) {
 	// Calculate statistics
 	stats := calculateMonthlyStats(filteredIncidents)
 
+	// Include responder load for the same period
+	loc, _ := time.LoadLocation(defaultTimezone)
+	if loc == nil {
+		loc = time.UTC
+	}
+	responderLoad, err := h.reportUsecase.GetResponderLoadReport(c.Request.Context(), startDate, endDate, loc)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	stats.ResponderLoad = responderLoad
+
+	// Include action item burndown and aging for the same period
+	actionItems, err := h.reportUsecase.GetActionItemReport(c.Request.Context(), startDate, endDate, loc)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	stats.ActionItems = actionItems
+
 	// Generate PDF
 	pdfBytes, err := h.pdfService.GenerateSummaryReport(filteredIncidents, startDate, endDate, stats)
 	if err != nil {
@@ -198,6 +224,328 @@ func (h *ReportHandler) GetMonthlyReportPDF(c *gin.Context) {
 	c.Data(http.StatusOK, "application/pdf", pdfBytes)
 }
 
+// GetResponderLoadReport reports on-call burden per responder and department
+// @Summary Get responder load report
+// @Description Get incidents handled, after-hours/weekend/night interruptions, time as assignee and consecutive-night pages per user and department
+// @Tags reports
+// @Produce json
+// @Param start_date query string false "Start date (RFC3339 format, defaults to start of current month)"
+// @Param end_date query string false "End date (RFC3339 format, defaults to end of current month)"
+// @Param tz query string false "IANA timezone used to classify after-hours (default Asia/Tokyo)"
+// @Success 200 {object} domain.ResponderLoadReport
+// @Failure 400 {object} map[string]string
+// @Router /reports/responder-load [get]
+func (h *ReportHandler) GetResponderLoadReport(c *gin.Context) {
+	startDate, endDate, loc, ok := parseResponderLoadParams(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUsecase.GetResponderLoadReport(c.Request.Context(), startDate, endDate, loc)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetResponderLoadReportPDF generates the responder load report in PDF format
+// @Summary Get responder load report PDF
+// @Description Get the responder load report in PDF format
+// @Tags reports
+// @Produce application/pdf
+// @Param start_date query string false "Start date (RFC3339 format, defaults to start of current month)"
+// @Param end_date query string false "End date (RFC3339 format, defaults to end of current month)"
+// @Param tz query string false "IANA timezone used to classify after-hours (default Asia/Tokyo)"
+// @Success 200 {file} file "PDF file"
+// @Failure 400 {object} map[string]string
+// @Router /reports/responder-load/pdf [get]
+func (h *ReportHandler) GetResponderLoadReportPDF(c *gin.Context) {
+	startDate, endDate, loc, ok := parseResponderLoadParams(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUsecase.GetResponderLoadReport(c.Request.Context(), startDate, endDate, loc)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	pdfBytes, err := h.pdfService.GenerateResponderLoadReport(report)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate PDF: %v", err)})
+		return
+	}
+
+	filename := fmt.Sprintf("responder_load_%s_%s.pdf", startDate.Format("20060102"), endDate.Format("20060102"))
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Length", strconv.Itoa(len(pdfBytes)))
+
+	c.Data(http.StatusOK, "application/pdf", pdfBytes)
+}
+
+// GetActionItemReport reports the action item burndown and aging
+// @Summary Get action item burndown and aging report
+// @Description Get action items opened versus completed per week, open items by age and priority, overdue items per assignee and department, and the median time to completion
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param start_date query string false "Start date (RFC3339 format, defaults to start of current month)"
+// @Param end_date query string false "End date (RFC3339 format, defaults to end of current month)"
+// @Param tz query string false "IANA timezone in which weeks start on Monday (default Asia/Tokyo)"
+// @Param format query string false "Output format: json or csv (default json)"
+// @Success 200 {object} domain.ActionItemReport
+// @Failure 400 {object} map[string]string
+// @Router /reports/action-items [get]
+func (h *ReportHandler) GetActionItemReport(c *gin.Context) {
+	startDate, endDate, loc, ok := parseResponderLoadParams(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUsecase.GetActionItemReport(c.Request.Context(), startDate, endDate, loc)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, report)
+	case "csv":
+		var buf bytes.Buffer
+		// UTF-8 BOM for Excel compatibility
+		buf.WriteString("\xEF\xBB\xBF")
+		if err := writeActionItemReportCSV(&buf, report, loc); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write CSV"})
+			return
+		}
+		filename := fmt.Sprintf("action_items_%s_%s.csv", startDate.In(loc).Format("20060102"), endDate.In(loc).Format("20060102"))
+		c.Header("Content-Description", "File Transfer")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+	}
+}
+
+// writeActionItemReportCSV writes the sections of the report one after another, each with its own header row
+func writeActionItemReportCSV(w io.Writer, report *domain.ActionItemReport, loc *time.Location) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"週", "作成", "完了", "週末時点の未完了"}}
+	for _, week := range report.Weeks {
+		rows = append(rows, []string{week.WeekStart.In(loc).Format("2006-01-02"),
+			strconv.Itoa(week.Opened), strconv.Itoa(week.Completed), strconv.Itoa(week.OpenAtEnd)})
+	}
+
+	rows = append(rows, nil, []string{"経過日数", "高", "中", "低", "合計"})
+	for _, bucket := range report.Aging {
+		rows = append(rows, []string{bucket.Label,
+			strconv.Itoa(bucket.High), strconv.Itoa(bucket.Medium), strconv.Itoa(bucket.Low), strconv.Itoa(bucket.Total)})
+	}
+
+	rows = append(rows, nil, []string{"担当者", "部署", "期限超過", "未完了"})
+	for _, entry := range report.OverdueByAssignee {
+		rows = append(rows, []string{entry.UserName, entry.Department, strconv.Itoa(entry.Overdue), strconv.Itoa(entry.Open)})
+	}
+
+	rows = append(rows, nil, []string{"部署", "期限超過", "未完了"})
+	for _, dept := range report.OverdueByDepartment {
+		rows = append(rows, []string{dept.Department, strconv.Itoa(dept.Overdue), strconv.Itoa(dept.Open)})
+	}
+
+	median := ""
+	if report.MedianDaysToComplete != nil {
+		median = strconv.FormatFloat(*report.MedianDaysToComplete, 'f', 1, 64)
+	}
+	rows = append(rows, nil,
+		[]string{"集計時点", report.AsOf.In(loc).Format("2006-01-02 15:04")},
+		[]string{"未完了", strconv.Itoa(report.OpenAtEnd)},
+		[]string{"期間中の完了", strconv.Itoa(report.CompletedInPeriod)},
+		[]string{"完了までの日数（中央値）", median},
+	)
+
+	for _, row := range rows {
+		if row == nil {
+			row = []string{}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// parseResponderLoadParams reads the period and timezone, writing a 400 response on invalid input.
+func parseResponderLoadParams(c *gin.Context) (time.Time, time.Time, *time.Location, bool) {
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", defaultTimezone))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tz"})
+		return time.Time{}, time.Time{}, nil, false
+	}
+
+	now := time.Now().In(loc)
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	endDate := startDate.AddDate(0, 1, 0).Add(-time.Second)
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		if startDate, err = time.Parse(time.RFC3339, startDateStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use RFC3339 format"})
+			return time.Time{}, time.Time{}, nil, false
+		}
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		if endDate, err = time.Parse(time.RFC3339, endDateStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use RFC3339 format"})
+			return time.Time{}, time.Time{}, nil, false
+		}
+	}
+	if endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must be after start_date"})
+		return time.Time{}, time.Time{}, nil, false
+	}
+
+	return startDate, endDate, loc, true
+}
+
+// GetHandoffReport generates a shift handoff report
+// @Summary Get shift handoff report
+// @Description Get incidents opened, changed, resolved and still open during a shift, upcoming SLA deadlines and notable comments/timeline events
+// @Tags reports
+// @Produce json
+// @Produce text/markdown
+// @Produce application/pdf
+// @Param from query string false "Shift start (RFC3339 format, defaults to 12 hours before to)"
+// @Param to query string false "Shift end (RFC3339 format, defaults to now)"
+// @Param format query string false "Output format: json, markdown or pdf (default json)"
+// @Param tz query string false "IANA timezone used for times in Markdown/PDF (default Asia/Tokyo)"
+// @Success 200 {object} domain.HandoffReport
+// @Failure 400 {object} map[string]string
+// @Router /reports/handoff [get]
+func (h *ReportHandler) GetHandoffReport(c *gin.Context) {
+	from, to, loc, ok := parseHandoffParams(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUsecase.GetHandoffReport(c.Request.Context(), from, to)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("handoff_%s_%s", from.In(loc).Format("200601021504"), to.In(loc).Format("200601021504"))
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, report)
+	case "markdown", "md":
+		body := []byte(h.documentService.GenerateHandoffReport(report, loc))
+		c.Header("Content-Description", "File Transfer")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.md", filename))
+		c.Header("Content-Length", strconv.Itoa(len(body)))
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", body)
+	case "pdf":
+		pdfBytes, err := h.pdfService.GenerateHandoffReport(report, loc)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate PDF: %v", err)})
+			return
+		}
+		c.Header("Content-Description", "File Transfer")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", filename))
+		c.Header("Content-Type", "application/pdf")
+		c.Header("Content-Length", strconv.Itoa(len(pdfBytes)))
+		c.Data(http.StatusOK, "application/pdf", pdfBytes)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json, markdown or pdf"})
+	}
+}
+
+// SendHandoffReportRequest selects the shift and who receives the handoff report.
+// If RecipientID is omitted, the report goes to the person on call for ScheduleID at the end of the shift.
+type SendHandoffReportRequest struct {
+	From        time.Time `json:"from" binding:"required"`
+	To          time.Time `json:"to" binding:"required"`
+	ScheduleID  uint      `json:"schedule_id"`
+	RecipientID *uint     `json:"recipient_id"`
+	Timezone    string    `json:"timezone"`
+}
+
+// SendHandoffReport emails the handoff report to the next on-call person
+// @Summary Email shift handoff report
+// @Description Email the handoff report (Markdown) to recipient_id, or to whoever is on call for schedule_id at the end of the shift
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body SendHandoffReportRequest true "Shift and recipient"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /reports/handoff/send [post]
+// @Security BearerAuth
+func (h *ReportHandler) SendHandoffReport(c *gin.Context) {
+	var req SendHandoffReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Timezone == "" {
+		req.Timezone = defaultTimezone
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+		return
+	}
+
+	recipient, err := h.reportUsecase.SendHandoffReport(c.Request.Context(), req.From, req.To, loc, req.ScheduleID, req.RecipientID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Handoff report sent",
+		"recipient": recipient,
+	})
+}
+
+// parseHandoffParams reads the shift period and timezone, writing a 400 response on invalid input.
+func parseHandoffParams(c *gin.Context) (time.Time, time.Time, *time.Location, bool) {
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", defaultTimezone))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tz"})
+		return time.Time{}, time.Time{}, nil, false
+	}
+
+	to := time.Now()
+	if toStr := c.Query("to"); toStr != "" {
+		if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to format. Use RFC3339 format"})
+			return time.Time{}, time.Time{}, nil, false
+		}
+	}
+	from := to.Add(-12 * time.Hour)
+	if fromStr := c.Query("from"); fromStr != "" {
+		if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from format. Use RFC3339 format"})
+			return time.Time{}, time.Time{}, nil, false
+		}
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return time.Time{}, time.Time{}, nil, false
+	}
+
+	return from, to, loc, true
+}
+
 func calculateMonthlyStats(incidents []*domain.Incident) *pdf.SummaryStats {
 	stats := &pdf.SummaryStats{
 		TotalIncidents: len(incidents),
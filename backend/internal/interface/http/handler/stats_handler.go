@@ -3,6 +3,7 @@ package handler
 import (
 	"incidex/internal/usecase"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -79,3 +80,31 @@ func (h *StatsHandler) GetTagStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"tag_stats": tagStats})
 }
+
+// GetLifecycleMetrics godoc
+// @Summary Get incident lifecycle metrics
+// @Description Retrieve MTTA, MTTD (diagnose), MTTM and MTTR and the duration of each phase (detect → investigate → root cause → mitigate → resolve), derived from timeline events and broken down by severity, tag and month
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Param months query int false "Number of months to include, including the current month (1-24)" default(12)
+// @Success 200 {object} domain.LifecycleMetrics
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/stats/lifecycle [get]
+// @Security BearerAuth
+func (h *StatsHandler) GetLifecycleMetrics(c *gin.Context) {
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil || months < 1 || months > 24 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "months must be between 1 and 24"})
+		return
+	}
+
+	metrics, err := h.statsUsecase.GetLifecycleMetrics(months)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
@@ -98,7 +98,7 @@ func (m *AuditMiddleware) Log() gin.HandlerFunc {
 }
 
 func shouldSkipAudit(path string) bool {
-	// Only skip health check endpoint
+	// Skip health check endpoint
 	skipPaths := []string{
 		"/api/health",
 	}
@@ -109,6 +109,11 @@ func shouldSkipAudit(path string) bool {
 		}
 	}
 
+	// Calendar feeds carry a secret token in the path and are polled by calendar apps
+	if strings.HasPrefix(path, "/api/calendar/feeds/") {
+		return true
+	}
+
 	return false
 }
 
@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddleware *middleware.JWTMiddleware, tagHandler *handler.TagHandler, incidentHandler *handler.IncidentHandler, userHandler *handler.UserHandler, statsHandler *handler.StatsHandler, activityHandler *handler.IncidentActivityHandler, exportHandler *handler.ExportHandler, attachmentHandler *handler.AttachmentHandler, notificationHandler *handler.NotificationHandler, templateHandler *handler.IncidentTemplateHandler, postMortemHandler *handler.PostMortemHandler, actionItemHandler *handler.ActionItemHandler, auditLogHandler *handler.AuditLogHandler, reportHandler *handler.ReportHandler) {
+func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddleware *middleware.JWTMiddleware, tagHandler *handler.TagHandler, incidentHandler *handler.IncidentHandler, userHandler *handler.UserHandler, statsHandler *handler.StatsHandler, activityHandler *handler.IncidentActivityHandler, exportHandler *handler.ExportHandler, attachmentHandler *handler.AttachmentHandler, notificationHandler *handler.NotificationHandler, templateHandler *handler.IncidentTemplateHandler, postMortemHandler *handler.PostMortemHandler, actionItemHandler *handler.ActionItemHandler, auditLogHandler *handler.AuditLogHandler, reportHandler *handler.ReportHandler, escalationHandler *handler.EscalationHandler, onCallHandler *handler.OnCallHandler, calendarHandler *handler.CalendarHandler, postMortemTemplateHandler *handler.PostMortemTemplateHandler, postMortemRequirementHandler *handler.PostMortemRequirementHandler, knowledgeBaseHandler *handler.KnowledgeBaseHandler, issueTrackerHandler *handler.IssueTrackerHandler, notificationOutboxHandler *handler.NotificationOutboxHandler, notificationDeliveryHandler *handler.NotificationDeliveryHandler) {
 	api := r.Group("/api")
 	{
 		// Auth routes
@@ -17,6 +17,17 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 			auth.POST("/login", authHandler.Login)
 		}
 
+		// Calendar feed routes (authenticated by the feed token in the URL so calendar apps can subscribe)
+		calendarFeeds := api.Group("/calendar/feeds/:token")
+		{
+			calendarFeeds.GET("/oncall.ics", calendarHandler.OnCallFeed)
+			calendarFeeds.GET("/action-items.ics", calendarHandler.ActionItemFeed)
+			calendarFeeds.GET("/schedules/:id/oncall.ics", calendarHandler.ScheduleFeed)
+		}
+
+		// Issue tracker webhooks (authenticated by the webhook signature)
+		api.POST("/issue-trackers/:tracker/webhook", issueTrackerHandler.Webhook)
+
 		// Protected routes
 		protected := api.Group("/")
 		protected.Use(jwtMiddleware.Handle())
@@ -50,11 +61,19 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 				incidents.DELETE("/:id", middleware.RequireEditorOrAdmin(), incidentHandler.Delete)
 				incidents.POST("/:id/summarize", middleware.RequireEditorOrAdmin(), incidentHandler.RegenerateSummary)
 				incidents.POST("/:id/assign", middleware.RequireEditorOrAdmin(), incidentHandler.AssignIncident)
+				incidents.POST("/:id/acknowledge", middleware.RequireEditorOrAdmin(), incidentHandler.Acknowledge)
+				incidents.GET("/:id/escalation", escalationHandler.GetByIncidentID)
 
 				// Incident activity routes
 				incidents.POST("/:id/comments", middleware.RequireEditorOrAdmin(), activityHandler.AddComment)
 				incidents.POST("/:id/timeline", middleware.RequireEditorOrAdmin(), activityHandler.AddTimelineEvent)
+				incidents.GET("/:id/timeline", activityHandler.GetTimeline)
+				incidents.GET("/:id/timeline/history", activityHandler.GetTimelineHistory)
+				incidents.POST("/:id/timeline/import", middleware.RequireEditorOrAdmin(), activityHandler.ImportTimeline)
+				incidents.PUT("/:id/timeline/:eventId", middleware.RequireEditorOrAdmin(), activityHandler.UpdateTimelineEvent)
+				incidents.DELETE("/:id/timeline/:eventId", middleware.RequireEditorOrAdmin(), activityHandler.DeleteTimelineEvent)
 				incidents.GET("/:id/activities", activityHandler.GetActivities)
+				incidents.GET("/:id/lifecycle", activityHandler.GetLifecycle)
 
 				// Incident attachment routes
 				incidents.POST("/:id/attachments", middleware.RequireEditorOrAdmin(), attachmentHandler.Upload)
@@ -64,7 +83,13 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 
 				// Post-mortem routes under incidents
 				incidents.GET("/:id/postmortem", postMortemHandler.GetByIncidentID)
+				incidents.GET("/:id/postmortem/template", postMortemTemplateHandler.GetForIncident)
 				incidents.POST("/:id/postmortem/ai-suggestion", middleware.RequireEditorOrAdmin(), postMortemHandler.GenerateAISuggestion)
+				incidents.GET("/:id/postmortem/requirement", postMortemRequirementHandler.GetRequirement)
+				incidents.POST("/:id/postmortem/requirement/waive", middleware.RequireAdmin(), postMortemRequirementHandler.WaiveRequirement)
+				incidents.GET("/:id/similar-post-mortems", knowledgeBaseHandler.GetSimilarPostMortems)
+				incidents.GET("/:id/action-items", actionItemHandler.GetByIncidentID)
+				incidents.GET("/:id/notifications", notificationDeliveryHandler.GetByIncidentID)
 			}
 
 			// User routes (admin only)
@@ -87,6 +112,7 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 				stats.GET("/dashboard", statsHandler.GetDashboardStats)
 				stats.GET("/sla", statsHandler.GetSLAMetrics)
 			stats.GET("/tags", statsHandler.GetTagStats)
+			stats.GET("/lifecycle", statsHandler.GetLifecycleMetrics)
 			}
 
 			// Export routes
@@ -103,6 +129,10 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 				notifications.GET("/settings", notificationHandler.GetMyNotificationSetting)
 				notifications.PUT("/settings", notificationHandler.UpdateMyNotificationSetting)
 				notifications.GET("/settings/:id", notificationHandler.GetUserNotificationSetting)
+				notifications.GET("/endpoints", notificationHandler.ListMyNotificationEndpoints)
+				notifications.POST("/endpoints", notificationHandler.CreateMyNotificationEndpoint)
+				notifications.PUT("/endpoints/:id", notificationHandler.UpdateMyNotificationEndpoint)
+				notifications.DELETE("/endpoints/:id", notificationHandler.DeleteMyNotificationEndpoint)
 			}
 
 			// Template routes
@@ -121,12 +151,56 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 			{
 				postMortems.POST("", middleware.RequireEditorOrAdmin(), postMortemHandler.Create)
 				postMortems.GET("", postMortemHandler.GetAll)
+				postMortems.GET("/causal-categories", postMortemHandler.GetCausalCategorySummary)
+				postMortems.GET("/compliance", postMortemRequirementHandler.GetComplianceReport)
 				postMortems.GET("/:id", postMortemHandler.GetByID)
 				postMortems.PUT("/:id", middleware.RequireEditorOrAdmin(), postMortemHandler.Update)
 				postMortems.DELETE("/:id", middleware.RequireEditorOrAdmin(), postMortemHandler.Delete)
 				postMortems.POST("/:id/publish", middleware.RequireEditorOrAdmin(), postMortemHandler.Publish)
 				postMortems.POST("/:id/unpublish", middleware.RequireEditorOrAdmin(), postMortemHandler.Unpublish)
 				postMortems.GET("/:id/action-items", actionItemHandler.GetByPostMortemID)
+				postMortems.GET("/:id/pdf", postMortemHandler.ExportPDF)
+				postMortems.GET("/:id/markdown", postMortemHandler.ExportMarkdown)
+				postMortems.GET("/:id/html", postMortemHandler.ExportHTML)
+				postMortems.GET("/:id/versions", postMortemHandler.ListVersions)
+				postMortems.GET("/:id/versions/diff", postMortemHandler.DiffVersions)
+				postMortems.GET("/:id/versions/:version", postMortemHandler.GetVersion)
+				postMortems.GET("/:id/causal-tree", postMortemHandler.GetCausalTree)
+				postMortems.POST("/:id/causal-tree/nodes", middleware.RequireEditorOrAdmin(), postMortemHandler.AddCausalNode)
+				postMortems.PUT("/:id/causal-tree/nodes/:nodeId", middleware.RequireEditorOrAdmin(), postMortemHandler.UpdateCausalNode)
+				postMortems.DELETE("/:id/causal-tree/nodes/:nodeId", middleware.RequireEditorOrAdmin(), postMortemHandler.DeleteCausalNode)
+				postMortems.PUT("/:id/reviewers", middleware.RequireEditorOrAdmin(), postMortemHandler.AssignReviewers)
+				postMortems.POST("/:id/submit-review", middleware.RequireEditorOrAdmin(), postMortemHandler.SubmitForReview)
+				postMortems.POST("/:id/review", middleware.RequireEditorOrAdmin(), postMortemHandler.Review)
+				postMortems.GET("/:id/comments", postMortemHandler.GetReviewComments)
+				postMortems.POST("/:id/comments", middleware.RequireEditorOrAdmin(), postMortemHandler.AddReviewComment)
+				postMortems.PUT("/:id/comments/:commentId/resolve", middleware.RequireEditorOrAdmin(), postMortemHandler.ResolveReviewComment)
+			}
+
+			// Knowledge base routes (published post-mortems)
+			knowledgeBase := protected.Group("/knowledge-base")
+			{
+				knowledgeBase.GET("/search", knowledgeBaseHandler.Search)
+			}
+
+			// Post-mortem template routes (changes are admin only)
+			postMortemTemplates := protected.Group("/post-mortem-templates")
+			{
+				postMortemTemplates.POST("", middleware.RequireAdmin(), postMortemTemplateHandler.Create)
+				postMortemTemplates.GET("", postMortemTemplateHandler.GetAll)
+				postMortemTemplates.GET("/:id", postMortemTemplateHandler.GetByID)
+				postMortemTemplates.PUT("/:id", middleware.RequireAdmin(), postMortemTemplateHandler.Update)
+				postMortemTemplates.DELETE("/:id", middleware.RequireAdmin(), postMortemTemplateHandler.Delete)
+			}
+
+			// Mandatory post-mortem policy routes (changes are admin only)
+			postMortemPolicies := protected.Group("/post-mortem-policies")
+			{
+				postMortemPolicies.POST("", middleware.RequireAdmin(), postMortemRequirementHandler.CreatePolicy)
+				postMortemPolicies.GET("", postMortemRequirementHandler.GetAllPolicies)
+				postMortemPolicies.GET("/:id", postMortemRequirementHandler.GetPolicyByID)
+				postMortemPolicies.PUT("/:id", middleware.RequireAdmin(), postMortemRequirementHandler.UpdatePolicy)
+				postMortemPolicies.DELETE("/:id", middleware.RequireAdmin(), postMortemRequirementHandler.DeletePolicy)
 			}
 
 			// Action item routes
@@ -134,9 +208,53 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 			{
 				actionItems.POST("", middleware.RequireEditorOrAdmin(), actionItemHandler.Create)
 				actionItems.GET("", actionItemHandler.GetAll)
+				actionItems.GET("/recurring", actionItemHandler.GetRecurring)
+				actionItems.GET("/effectiveness", actionItemHandler.GetEffectiveness)
 				actionItems.GET("/:id", actionItemHandler.GetByID)
 				actionItems.PUT("/:id", middleware.RequireEditorOrAdmin(), actionItemHandler.Update)
 				actionItems.DELETE("/:id", middleware.RequireEditorOrAdmin(), actionItemHandler.Delete)
+				actionItems.POST("/:id/links", middleware.RequireEditorOrAdmin(), actionItemHandler.AddLink)
+				actionItems.DELETE("/:id/links/:linkId", middleware.RequireEditorOrAdmin(), actionItemHandler.RemoveLink)
+				actionItems.POST("/:id/dependencies", middleware.RequireEditorOrAdmin(), actionItemHandler.AddDependency)
+				actionItems.DELETE("/:id/dependencies/:blockerId", middleware.RequireEditorOrAdmin(), actionItemHandler.RemoveDependency)
+				actionItems.POST("/:id/external-issue", middleware.RequireEditorOrAdmin(), actionItemHandler.OpenExternalIssue)
+				actionItems.POST("/:id/verify", middleware.RequireEditorOrAdmin(), actionItemHandler.Verify)
+			}
+
+			// Issue tracker routes
+			protected.GET("/issue-trackers", issueTrackerHandler.GetTrackers)
+
+			// Escalation policy routes
+			escalationPolicies := protected.Group("/escalation-policies")
+			{
+				escalationPolicies.POST("", middleware.RequireAdmin(), escalationHandler.Create)
+				escalationPolicies.GET("", escalationHandler.GetAll)
+				escalationPolicies.GET("/:id", escalationHandler.GetByID)
+				escalationPolicies.PUT("/:id", middleware.RequireAdmin(), escalationHandler.Update)
+				escalationPolicies.DELETE("/:id", middleware.RequireAdmin(), escalationHandler.Delete)
+			}
+
+			// On-call schedule routes
+			onCallSchedules := protected.Group("/oncall-schedules")
+			{
+				onCallSchedules.POST("", middleware.RequireAdmin(), onCallHandler.Create)
+				onCallSchedules.GET("", onCallHandler.GetAll)
+				onCallSchedules.GET("/:id", onCallHandler.GetByID)
+				onCallSchedules.PUT("/:id", middleware.RequireAdmin(), onCallHandler.Update)
+				onCallSchedules.DELETE("/:id", middleware.RequireAdmin(), onCallHandler.Delete)
+				onCallSchedules.GET("/:id/oncall", onCallHandler.GetOnCall)
+				onCallSchedules.GET("/:id/shifts", onCallHandler.GetShifts)
+				onCallSchedules.GET("/:id/overrides", onCallHandler.GetOverrides)
+				onCallSchedules.POST("/:id/overrides", middleware.RequireEditorOrAdmin(), onCallHandler.CreateOverride)
+				onCallSchedules.DELETE("/:id/overrides/:overrideId", middleware.RequireEditorOrAdmin(), onCallHandler.DeleteOverride)
+			}
+
+			// Calendar feed token routes
+			calendar := protected.Group("/calendar")
+			{
+				calendar.GET("/token", calendarHandler.GetToken)
+				calendar.POST("/token", calendarHandler.RegenerateToken)
+				calendar.DELETE("/token", calendarHandler.RevokeToken)
 			}
 
 		// Audit log routes (admin only)
@@ -147,12 +265,34 @@ func RegisterRoutes(r *gin.Engine, authHandler *handler.AuthHandler, jwtMiddlewa
 			auditLogs.GET("/:id", auditLogHandler.GetByID)
 		}
 
+		// Notification outbox routes (admin only)
+		notificationOutbox := protected.Group("/notification-outbox")
+		notificationOutbox.Use(middleware.RequireAdmin())
+		{
+			notificationOutbox.GET("", notificationOutboxHandler.List)
+			notificationOutbox.POST("/replay", notificationOutboxHandler.ReplayAll)
+			notificationOutbox.GET("/:id", notificationOutboxHandler.Get)
+			notificationOutbox.POST("/:id/replay", notificationOutboxHandler.Replay)
+		}
+
+		// Notification delivery log routes (admin only)
+		notificationDeliveries := protected.Group("/notification-deliveries")
+		notificationDeliveries.Use(middleware.RequireAdmin())
+		{
+			notificationDeliveries.GET("", notificationDeliveryHandler.Search)
+		}
+
 		// Report routes
 		reports := protected.Group("/reports")
 		{
 			reports.GET("/monthly", reportHandler.GetMonthlyReport)
 			reports.GET("/monthly/pdf", reportHandler.GetMonthlyReportPDF)
 			reports.GET("/custom", reportHandler.GetCustomReport)
+			reports.GET("/responder-load", reportHandler.GetResponderLoadReport)
+			reports.GET("/responder-load/pdf", reportHandler.GetResponderLoadReportPDF)
+			reports.GET("/action-items", reportHandler.GetActionItemReport)
+			reports.GET("/handoff", reportHandler.GetHandoffReport)
+			reports.POST("/handoff/send", middleware.RequireEditorOrAdmin(), reportHandler.SendHandoffReport)
 		}
 	}
 }
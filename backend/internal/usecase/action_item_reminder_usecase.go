@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/infrastructure/notification"
+	"incidex/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ActionItemReminderUsecase sends due-date reminders and weekly digests of action items,
+// following each recipient's cadence, digest schedule and quiet hours.
+type ActionItemReminderUsecase struct {
+	actionItemRepo      domain.ActionItemRepository
+	userRepo            domain.UserRepository
+	settingRepo         domain.NotificationSettingRepository
+	notificationService *notification.NotificationService
+	// Timezone of users that have not set their own
+	loc *time.Location
+}
+
+func NewActionItemReminderUsecase(
+	actionItemRepo domain.ActionItemRepository,
+	userRepo domain.UserRepository,
+	settingRepo domain.NotificationSettingRepository,
+	notificationService *notification.NotificationService,
+	loc *time.Location,
+) *ActionItemReminderUsecase {
+	return &ActionItemReminderUsecase{
+		actionItemRepo:      actionItemRepo,
+		userRepo:            userRepo,
+		settingRepo:         settingRepo,
+		notificationService: notificationService,
+		loc:                 loc,
+	}
+}
+
+// ProcessReminders notifies assignees of open action items that are due soon or overdue, and escalates
+// overdue high-priority items to the post-mortem author. It is run periodically by the scheduler;
+// notifications falling in a recipient's quiet hours are sent on a later run.
+func (u *ActionItemReminderUsecase) ProcessReminders(ctx context.Context) error {
+	if u.notificationService == nil {
+		return nil
+	}
+	items, err := u.actionItemRepo.FindOpenWithDueDate(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load action items with due dates: %w", err)
+	}
+
+	now := time.Now()
+	settings := make(map[uint]*domain.NotificationSetting)
+	for _, item := range items {
+		assignee, err := u.userRepo.FindByID(ctx, *item.AssigneeID)
+		if err != nil {
+			logger.Log.Warn("Action item assignee not found", zap.Uint("action_item_id", item.ID), zap.Error(err))
+			continue
+		}
+		item.Assignee = assignee
+
+		changed := false
+		setting := u.setting(settings, assignee.ID)
+		loc := setting.Location(u.loc)
+		if !setting.InQuietHours(now, loc) {
+			if kind, ok := dueReminder(item, setting, now, loc); ok {
+				u.notify(item, assignee, kind, now, loc)
+				if kind == domain.ActionItemOverdue {
+					item.OverdueNotifiedAt = &now
+				} else {
+					item.DueSoonNotifiedAt = &now
+				}
+				changed = true
+			}
+		}
+
+		if item.Priority == domain.PriorityHigh && now.After(*item.DueDate) && item.EscalatedAt == nil {
+			if escalated := u.escalate(ctx, settings, item, now); escalated {
+				item.EscalatedAt = &now
+				changed = true
+			}
+		}
+
+		if changed {
+			if err := u.actionItemRepo.UpdateNotificationState(ctx, item); err != nil {
+				logger.Log.Error("Failed to update action item reminder state", zap.Uint("action_item_id", item.ID), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+// dueReminder decides which reminder, if any, the assignee should get now
+func dueReminder(item *domain.ActionItem, setting *domain.NotificationSetting, now time.Time, loc *time.Location) (domain.ActionItemReminderKind, bool) {
+	due := *item.DueDate
+	if now.After(due) {
+		if item.OverdueNotifiedAt == nil {
+			return domain.ActionItemOverdue, true
+		}
+		interval := setting.ActionItemOverdueInterval
+		if interval > 0 && !now.Before(item.OverdueNotifiedAt.AddDate(0, 0, interval)) {
+			return domain.ActionItemOverdue, true
+		}
+		return "", false
+	}
+
+	if item.DueSoonNotifiedAt != nil {
+		return "", false
+	}
+	// Calendar days until the due date in the user's timezone
+	today := startOfDay(now.In(loc))
+	dueDay := startOfDay(due.In(loc))
+	if int(dueDay.Sub(today).Hours()/24) <= setting.ActionItemDueSoonDays {
+		return domain.ActionItemDueSoon, true
+	}
+	return "", false
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// escalate notifies the author of the item's post-mortem (the incident's creator when the item has no post-mortem).
+// It reports false when the recipient is in quiet hours, so the escalation is retried later.
+func (u *ActionItemReminderUsecase) escalate(ctx context.Context, settings map[uint]*domain.NotificationSetting, item *domain.ActionItem, now time.Time) bool {
+	var recipientID uint
+	switch {
+	case item.PostMortem != nil:
+		recipientID = item.PostMortem.AuthorID
+	case item.Incident != nil:
+		recipientID = item.Incident.CreatorID
+	default:
+		return false
+	}
+
+	setting := u.setting(settings, recipientID)
+	loc := setting.Location(u.loc)
+	if setting.InQuietHours(now, loc) {
+		return false
+	}
+	recipient, err := u.userRepo.FindByID(ctx, recipientID)
+	if err != nil {
+		logger.Log.Warn("Action item escalation recipient not found", zap.Uint("user_id", recipientID), zap.Error(err))
+		return true
+	}
+	u.notify(item, recipient, domain.ActionItemEscalated, now, loc)
+	return true
+}
+
+func (u *ActionItemReminderUsecase) notify(item *domain.ActionItem, recipient *domain.User, kind domain.ActionItemReminderKind, now time.Time, loc *time.Location) {
+	line := notification.NewActionItemDigestLine(item, now, loc)
+	if err := u.notificationService.NotifyActionItemDue(line, recipient, kind); err != nil {
+		logger.Log.Error("Failed to send action item reminder", zap.Uint("action_item_id", item.ID), zap.String("kind", string(kind)), zap.Error(err))
+	}
+}
+
+// ProcessDigests sends each active user a weekly digest of their open action items on their chosen
+// weekday and hour. Users without open items get no digest.
+func (u *ActionItemReminderUsecase) ProcessDigests(ctx context.Context) error {
+	if u.notificationService == nil {
+		return nil
+	}
+	users, err := u.userRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load users: %w", err)
+	}
+
+	now := time.Now()
+	for _, user := range users {
+		if !user.IsActive {
+			continue
+		}
+		setting, stored := u.storedSetting(user.ID)
+		loc := setting.Location(u.loc)
+		if !setting.DigestDue(now, loc) || setting.InQuietHours(now, loc) {
+			continue
+		}
+
+		items, err := u.actionItemRepo.FindOpenByAssignee(ctx, user.ID)
+		if err != nil {
+			logger.Log.Error("Failed to load open action items for digest", zap.Uint("user_id", user.ID), zap.Error(err))
+			continue
+		}
+		if len(items) > 0 {
+			lines := make([]notification.ActionItemDigestLine, len(items))
+			for i, item := range items {
+				item.Assignee = user
+				lines[i] = notification.NewActionItemDigestLine(item, now, loc)
+			}
+			if err := u.notificationService.SendActionItemDigest(user, lines); err != nil {
+				logger.Log.Error("Failed to send action item digest", zap.Uint("user_id", user.ID), zap.Error(err))
+				continue
+			}
+		}
+
+		// Remember the week as done, also when there was nothing to send
+		setting.LastDigestSentAt = &now
+		if stored {
+			err = u.settingRepo.Update(setting)
+		} else {
+			err = u.settingRepo.Create(setting)
+		}
+		if err != nil {
+			logger.Log.Error("Failed to record action item digest", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// setting returns the user's notification setting (defaults when none is stored), cached for the run
+func (u *ActionItemReminderUsecase) setting(cache map[uint]*domain.NotificationSetting, userID uint) *domain.NotificationSetting {
+	if setting, ok := cache[userID]; ok {
+		return setting
+	}
+	setting, _ := u.storedSetting(userID)
+	cache[userID] = setting
+	return setting
+}
+
+// storedSetting returns the user's notification setting and whether it is stored
+func (u *ActionItemReminderUsecase) storedSetting(userID uint) (*domain.NotificationSetting, bool) {
+	setting, err := u.settingRepo.GetByUserID(userID)
+	if err != nil {
+		return domain.DefaultNotificationSetting(userID), false
+	}
+	return setting, true
+}
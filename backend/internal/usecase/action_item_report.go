@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"incidex/internal/domain"
+	"sort"
+	"time"
+)
+
+// buildActionItemReport computes the burndown, aging and overdue figures of the action items
+// active in the period. Weeks start on Monday in loc; ages and overdue counts are taken at asOf.
+func buildActionItemReport(startDate, endDate, asOf time.Time, loc *time.Location, items []*domain.ActionItem) *domain.ActionItemReport {
+	report := &domain.ActionItemReport{
+		Period:              domain.ReportPeriod{StartDate: startDate, EndDate: endDate},
+		Timezone:            loc.String(),
+		AsOf:                asOf,
+		Weeks:               []domain.ActionItemWeek{},
+		Aging:               make([]domain.ActionItemAgeBucket, len(domain.ActionItemAgeBuckets)),
+		OverdueByAssignee:   []domain.ActionItemAssigneeOverdue{},
+		OverdueByDepartment: []domain.ActionItemDepartmentOverdue{},
+	}
+	copy(report.Aging, domain.ActionItemAgeBuckets)
+
+	// Weekly burndown, up to the current week
+	for weekStart := startOfWeek(startDate.In(loc)); weekStart.Before(endDate) && !weekStart.After(asOf); weekStart = weekStart.AddDate(0, 0, 7) {
+		weekEnd := weekStart.AddDate(0, 0, 7)
+		from, to := maxTime(weekStart, startDate), minTime(weekEnd, endDate)
+		week := domain.ActionItemWeek{WeekStart: weekStart}
+		for _, item := range items {
+			if inWeek(item.CreatedAt, from, to) {
+				week.Opened++
+			}
+			if item.CompletedAt != nil && inWeek(*item.CompletedAt, from, to) {
+				week.Completed++
+			}
+			if actionItemOpenAt(item, minTime(to, asOf)) {
+				week.OpenAtEnd++
+			}
+		}
+		report.Weeks = append(report.Weeks, week)
+	}
+
+	// Aging and overdue of the items open at asOf
+	assignees := make(map[uint]*domain.ActionItemAssigneeOverdue)
+	var unassigned *domain.ActionItemAssigneeOverdue
+	departments := make(map[string]*domain.ActionItemDepartmentOverdue)
+	var durations []float64
+	for _, item := range items {
+		if item.CompletedAt != nil && inRange(*item.CompletedAt, startDate, endDate) {
+			report.CompletedInPeriod++
+			durations = append(durations, item.CompletedAt.Sub(item.CreatedAt).Hours()/24)
+		}
+		if !actionItemOpenAt(item, asOf) {
+			continue
+		}
+		report.OpenAtEnd++
+
+		ageDays := int(asOf.Sub(item.CreatedAt).Hours() / 24)
+		for i := range report.Aging {
+			bucket := &report.Aging[i]
+			if ageDays < bucket.MinDays || (bucket.MaxDays != nil && ageDays > *bucket.MaxDays) {
+				continue
+			}
+			switch item.Priority {
+			case domain.PriorityHigh:
+				bucket.High++
+			case domain.PriorityLow:
+				bucket.Low++
+			default:
+				bucket.Medium++
+			}
+			bucket.Total++
+			break
+		}
+
+		var entry *domain.ActionItemAssigneeOverdue
+		if item.AssigneeID == nil {
+			if unassigned == nil {
+				unassigned = &domain.ActionItemAssigneeOverdue{UserName: "(Unassigned)"}
+			}
+			entry = unassigned
+		} else {
+			entry = assignees[*item.AssigneeID]
+			if entry == nil {
+				entry = &domain.ActionItemAssigneeOverdue{UserID: item.AssigneeID}
+				if item.Assignee != nil {
+					entry.UserName = item.Assignee.Name
+					entry.Department = item.Assignee.Department
+				}
+				assignees[*item.AssigneeID] = entry
+			}
+		}
+		overdue := item.DueDate != nil && item.DueDate.Before(asOf)
+		entry.Open++
+		if overdue {
+			entry.Overdue++
+		}
+
+		if item.AssigneeID != nil {
+			dept := departments[entry.Department]
+			if dept == nil {
+				dept = &domain.ActionItemDepartmentOverdue{Department: entry.Department}
+				departments[entry.Department] = dept
+			}
+			dept.Open++
+			if overdue {
+				dept.Overdue++
+			}
+		}
+	}
+
+	for _, entry := range assignees {
+		report.OverdueByAssignee = append(report.OverdueByAssignee, *entry)
+	}
+	sort.Slice(report.OverdueByAssignee, func(i, j int) bool {
+		a, b := report.OverdueByAssignee[i], report.OverdueByAssignee[j]
+		if a.Overdue != b.Overdue {
+			return a.Overdue > b.Overdue
+		}
+		return a.UserName < b.UserName
+	})
+	if unassigned != nil {
+		report.OverdueByAssignee = append(report.OverdueByAssignee, *unassigned)
+	}
+	for _, dept := range departments {
+		report.OverdueByDepartment = append(report.OverdueByDepartment, *dept)
+	}
+	sort.Slice(report.OverdueByDepartment, func(i, j int) bool {
+		a, b := report.OverdueByDepartment[i], report.OverdueByDepartment[j]
+		if a.Overdue != b.Overdue {
+			return a.Overdue > b.Overdue
+		}
+		return a.Department < b.Department
+	})
+
+	if len(durations) > 0 {
+		sort.Float64s(durations)
+		median := durations[len(durations)/2]
+		if len(durations)%2 == 0 {
+			median = (durations[len(durations)/2-1] + durations[len(durations)/2]) / 2
+		}
+		report.MedianDaysToComplete = &median
+	}
+
+	return report
+}
+
+// actionItemOpenAt reports whether the item existed and was not completed at t
+func actionItemOpenAt(item *domain.ActionItem, t time.Time) bool {
+	return !item.CreatedAt.After(t) && (item.CompletedAt == nil || item.CompletedAt.After(t))
+}
+
+// inWeek reports whether from <= t < to, so that a moment is counted in one week only
+func inWeek(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return day.AddDate(0, 0, -offset)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
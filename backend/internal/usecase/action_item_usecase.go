@@ -2,47 +2,89 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"incidex/internal/domain"
+	"incidex/internal/pkg/logger"
+	"sort"
+	"strings"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type ActionItemUsecase interface {
-	CreateActionItem(ctx context.Context, postMortemID uint, title, description string, assigneeID *uint, priority domain.Priority, dueDate *time.Time, relatedLinks string) (*domain.ActionItem, error)
+	CreateActionItem(ctx context.Context, incidentID, postMortemID *uint, title, description string, assigneeID *uint, priority domain.Priority, dueDate *time.Time, relatedLinks string, issueTracker domain.IssueTrackerKind) (*domain.ActionItem, error)
 	GetActionItemByID(ctx context.Context, id uint) (*domain.ActionItem, error)
 	GetActionItemsByPostMortemID(ctx context.Context, postMortemID uint) ([]*domain.ActionItem, error)
+	GetActionItemsByIncidentID(ctx context.Context, incidentID uint) ([]*domain.ActionItem, error)
 	UpdateActionItem(ctx context.Context, id uint, title, description string, assigneeID *uint, priority domain.Priority, status domain.ActionStatus, dueDate *time.Time, relatedLinks string) (*domain.ActionItem, error)
 	DeleteActionItem(ctx context.Context, userRole domain.Role, id uint) error
 	GetAllActionItems(ctx context.Context, filters domain.ActionItemFilters, pagination domain.Pagination) ([]*domain.ActionItem, *domain.PaginationResult, error)
+	AddLink(ctx context.Context, userID uint, id uint, incidentID, postMortemID *uint) (*domain.ActionItem, error)
+	RemoveLink(ctx context.Context, id uint, linkID uint) (*domain.ActionItem, error)
+	AddDependency(ctx context.Context, id uint, blockedByID uint) (*domain.ActionItem, error)
+	RemoveDependency(ctx context.Context, id uint, blockedByID uint) (*domain.ActionItem, error)
+	GetRecurringRemediations(ctx context.Context, filters domain.RecurringRemediationFilters) ([]domain.RecurringRemediation, error)
+	OpenExternalIssue(ctx context.Context, id uint, issueTracker domain.IssueTrackerKind) (*domain.ActionItem, error)
+	VerifyActionItem(ctx context.Context, userID uint, id uint, note, evidenceURL string) (*domain.ActionItem, error)
+	GetEffectivenessReport(ctx context.Context, unverifiedDays, limit int) (*domain.ActionItemEffectivenessReport, error)
 }
 
 type actionItemUsecase struct {
 	actionItemRepo domain.ActionItemRepository
 	postMortemRepo domain.PostMortemRepository
+	incidentRepo   domain.IncidentRepository
+	issueTrackers  *IssueTrackerUsecase
 }
 
 func NewActionItemUsecase(
 	actionItemRepo domain.ActionItemRepository,
 	postMortemRepo domain.PostMortemRepository,
+	incidentRepo domain.IncidentRepository,
+	issueTrackers *IssueTrackerUsecase,
 ) ActionItemUsecase {
 	return &actionItemUsecase{
 		actionItemRepo: actionItemRepo,
 		postMortemRepo: postMortemRepo,
+		incidentRepo:   incidentRepo,
+		issueTrackers:  issueTrackers,
 	}
 }
 
 func (u *actionItemUsecase) CreateActionItem(
 	ctx context.Context,
-	postMortemID uint,
+	incidentID, postMortemID *uint,
 	title, description string,
 	assigneeID *uint,
 	priority domain.Priority,
 	dueDate *time.Time,
 	relatedLinks string,
+	issueTracker domain.IssueTrackerKind,
 ) (*domain.ActionItem, error) {
-	// Check if post-mortem exists
-	_, err := u.postMortemRepo.FindByID(ctx, postMortemID)
-	if err != nil {
-		return nil, domain.ErrNotFound("Post-mortem").WithError(err)
+	if incidentID == nil && postMortemID == nil {
+		return nil, domain.ErrValidation("Either incident_id or post_mortem_id is required")
+	}
+	if issueTracker != "" {
+		if u.issueTrackers == nil {
+			return nil, domain.ErrValidation("No issue tracker is configured")
+		}
+		if _, err := u.issueTrackers.tracker(issueTracker); err != nil {
+			return nil, err
+		}
+	}
+
+	// Items of a post-mortem also belong to its incident
+	if postMortemID != nil {
+		pm, err := u.postMortemRepo.FindByID(ctx, *postMortemID)
+		if err != nil {
+			return nil, domain.ErrNotFound("Post-mortem").WithError(err)
+		}
+		if incidentID != nil && *incidentID != pm.IncidentID {
+			return nil, domain.ErrValidation("The post-mortem does not belong to the incident")
+		}
+		incidentID = &pm.IncidentID
+	} else if _, err := u.incidentRepo.FindByID(ctx, *incidentID); err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
 	}
 
 	// Validate priority
@@ -52,6 +94,7 @@ func (u *actionItemUsecase) CreateActionItem(
 
 	// Create action item
 	item := &domain.ActionItem{
+		IncidentID:   incidentID,
 		PostMortemID: postMortemID,
 		Title:        title,
 		Description:  description,
@@ -66,6 +109,14 @@ func (u *actionItemUsecase) CreateActionItem(
 		return nil, domain.ErrDatabase("Failed to create action item", err)
 	}
 
+	// The item is kept when the tracker fails; the issue can be opened again later
+	if issueTracker != "" {
+		if err := u.issueTrackers.OpenIssue(ctx, item, issueTracker); err != nil {
+			logger.Log.Warn("Failed to open external issue for action item",
+				zap.Uint("action_item_id", item.ID), zap.String("tracker", string(issueTracker)), zap.Error(err))
+		}
+	}
+
 	// Reload with relations
 	return u.actionItemRepo.FindByID(ctx, item.ID)
 }
@@ -78,6 +129,13 @@ func (u *actionItemUsecase) GetActionItemsByPostMortemID(ctx context.Context, po
 	return u.actionItemRepo.FindByPostMortemID(ctx, postMortemID)
 }
 
+func (u *actionItemUsecase) GetActionItemsByIncidentID(ctx context.Context, incidentID uint) ([]*domain.ActionItem, error) {
+	if _, err := u.incidentRepo.FindByID(ctx, incidentID); err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+	return u.actionItemRepo.FindByIncidentID(ctx, incidentID)
+}
+
 func (u *actionItemUsecase) UpdateActionItem(
 	ctx context.Context,
 	id uint,
@@ -100,13 +158,45 @@ func (u *actionItemUsecase) UpdateActionItem(
 	}
 
 	// Validate status
-	if status != domain.ActionStatusPending && status != domain.ActionStatusInProgress && status != domain.ActionStatusCompleted {
+	if !isValidActionStatus(status) {
 		return nil, domain.ErrValidation("Invalid status value")
 	}
 
 	// Track old status
 	oldStatus := item.Status
 
+	// Verification needs a verifier and a note, so it is only done through VerifyActionItem
+	if status == domain.ActionStatusVerified && oldStatus != domain.ActionStatusVerified {
+		return nil, domain.ErrValidation("Use the verify endpoint to verify an action item")
+	}
+
+	// A verified item is only unverified by reopening it, so a client that does not know the verified status cannot drop the verification
+	if oldStatus == domain.ActionStatusVerified && status == domain.ActionStatusCompleted {
+		return nil, domain.ErrValidation("A verified action item can only be reopened (pending or in_progress)")
+	}
+
+	// Blocked items cannot be completed while a blocker is open
+	if status.IsDone() && !oldStatus.IsDone() {
+		blockers, err := u.actionItemRepo.FindOpenBlockers(ctx, id)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to check blocking action items", err)
+		}
+		if len(blockers) > 0 {
+			ids := make([]string, len(blockers))
+			for i, blocker := range blockers {
+				ids[i] = fmt.Sprintf("#%d", blocker.ID)
+			}
+			return nil, domain.ErrConflict(fmt.Sprintf("Action item is blocked by open action items: %s", strings.Join(ids, ", ")))
+		}
+	}
+
+	// A new due date gets its reminders again
+	if !sameDueDate(item.DueDate, dueDate) {
+		item.DueSoonNotifiedAt = nil
+		item.OverdueNotifiedAt = nil
+		item.EscalatedAt = nil
+	}
+
 	// Update fields
 	item.Title = title
 	item.Description = description
@@ -117,16 +207,21 @@ func (u *actionItemUsecase) UpdateActionItem(
 	item.RelatedLinks = relatedLinks
 
 	// Set CompletedAt when status changes to completed
-	if status == domain.ActionStatusCompleted && oldStatus != domain.ActionStatusCompleted {
+	if status.IsDone() && !oldStatus.IsDone() {
 		now := time.Now()
 		item.CompletedAt = &now
 	}
 
 	// Clear CompletedAt if status changes from completed to something else
-	if status != domain.ActionStatusCompleted && oldStatus == domain.ActionStatusCompleted {
+	if !status.IsDone() && oldStatus.IsDone() {
 		item.CompletedAt = nil
 	}
 
+	// Reopening a verified item drops the verification
+	if status != domain.ActionStatusVerified && oldStatus == domain.ActionStatusVerified {
+		item.ClearVerification()
+	}
+
 	if err := u.actionItemRepo.Update(ctx, item); err != nil {
 		return nil, domain.ErrDatabase("Failed to update action item", err)
 	}
@@ -154,5 +249,242 @@ func (u *actionItemUsecase) GetAllActionItems(
 	filters domain.ActionItemFilters,
 	pagination domain.Pagination,
 ) ([]*domain.ActionItem, *domain.PaginationResult, error) {
+	if filters.Source != "" && !filters.Source.IsValid() {
+		return nil, nil, domain.ErrValidation("Invalid source value")
+	}
 	return u.actionItemRepo.FindAll(ctx, filters, pagination)
 }
+
+// AddLink links the action item to a further incident, or to a post-mortem and its incident
+func (u *actionItemUsecase) AddLink(ctx context.Context, userID uint, id uint, incidentID, postMortemID *uint) (*domain.ActionItem, error) {
+	item, err := u.actionItemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Action item").WithError(err)
+	}
+	if incidentID == nil && postMortemID == nil {
+		return nil, domain.ErrValidation("Either incident_id or post_mortem_id is required")
+	}
+
+	link := &domain.ActionItemLink{ActionItemID: id, CreatedByID: userID}
+	if postMortemID != nil {
+		pm, err := u.postMortemRepo.FindByID(ctx, *postMortemID)
+		if err != nil {
+			return nil, domain.ErrNotFound("Post-mortem").WithError(err)
+		}
+		if incidentID != nil && *incidentID != pm.IncidentID {
+			return nil, domain.ErrValidation("The post-mortem does not belong to the incident")
+		}
+		link.IncidentID = pm.IncidentID
+		link.PostMortemID = postMortemID
+	} else {
+		if _, err := u.incidentRepo.FindByID(ctx, *incidentID); err != nil {
+			return nil, domain.ErrNotFound("Incident").WithError(err)
+		}
+		link.IncidentID = *incidentID
+	}
+
+	if item.IncidentID != nil && *item.IncidentID == link.IncidentID {
+		return nil, domain.ErrConflict("The action item already belongs to this incident")
+	}
+	for _, existing := range item.Links {
+		if existing.IncidentID == link.IncidentID {
+			return nil, domain.ErrConflict("The action item is already linked to this incident")
+		}
+	}
+
+	if err := u.actionItemRepo.CreateLink(ctx, link); err != nil {
+		return nil, domain.ErrDatabase("Failed to link action item", err)
+	}
+	return u.actionItemRepo.FindByID(ctx, id)
+}
+
+func (u *actionItemUsecase) RemoveLink(ctx context.Context, id uint, linkID uint) (*domain.ActionItem, error) {
+	link, err := u.actionItemRepo.FindLinkByID(ctx, linkID)
+	if err != nil || link.ActionItemID != id {
+		return nil, domain.ErrNotFound("Action item link")
+	}
+	if err := u.actionItemRepo.DeleteLink(ctx, linkID); err != nil {
+		return nil, domain.ErrDatabase("Failed to unlink action item", err)
+	}
+	return u.actionItemRepo.FindByID(ctx, id)
+}
+
+// AddDependency makes the action item wait for blockedByID; dependency cycles are refused
+func (u *actionItemUsecase) AddDependency(ctx context.Context, id uint, blockedByID uint) (*domain.ActionItem, error) {
+	if _, err := u.actionItemRepo.FindByID(ctx, id); err != nil {
+		return nil, domain.ErrNotFound("Action item").WithError(err)
+	}
+	if _, err := u.actionItemRepo.FindByID(ctx, blockedByID); err != nil {
+		return nil, domain.ErrNotFound("Blocking action item").WithError(err)
+	}
+
+	dependencies, err := u.actionItemRepo.FindAllDependencies(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get action item dependencies", err)
+	}
+	if domain.DependencyCreatesCycle(dependencies, id, blockedByID) {
+		return nil, domain.ErrValidation("The dependency would create a cycle")
+	}
+
+	if err := u.actionItemRepo.AddDependency(ctx, id, blockedByID); err != nil {
+		return nil, domain.ErrDatabase("Failed to add action item dependency", err)
+	}
+	return u.actionItemRepo.FindByID(ctx, id)
+}
+
+func (u *actionItemUsecase) RemoveDependency(ctx context.Context, id uint, blockedByID uint) (*domain.ActionItem, error) {
+	if _, err := u.actionItemRepo.FindByID(ctx, id); err != nil {
+		return nil, domain.ErrNotFound("Action item").WithError(err)
+	}
+	if err := u.actionItemRepo.RemoveDependency(ctx, id, blockedByID); err != nil {
+		return nil, domain.ErrDatabase("Failed to remove action item dependency", err)
+	}
+	return u.actionItemRepo.FindByID(ctx, id)
+}
+
+// GetRecurringRemediations lists the action items that came out of several incidents, most incidents first
+func (u *actionItemUsecase) GetRecurringRemediations(ctx context.Context, filters domain.RecurringRemediationFilters) ([]domain.RecurringRemediation, error) {
+	if filters.MinIncidents < 2 {
+		filters.MinIncidents = 2
+	}
+	if filters.Limit < 1 || filters.Limit > 100 {
+		filters.Limit = 50
+	}
+	if filters.Status != "" && !isValidActionStatus(domain.ActionStatus(filters.Status)) {
+		return nil, domain.ErrValidation("Invalid status value")
+	}
+
+	counts, err := u.actionItemRepo.FindRecurring(ctx, filters)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get recurring remediations", err)
+	}
+	ids := make([]uint, len(counts))
+	for i, count := range counts {
+		ids[i] = count.ActionItemID
+	}
+	incidents, err := u.actionItemRepo.FindIncidents(ctx, ids)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incidents of action items", err)
+	}
+
+	result := make([]domain.RecurringRemediation, 0, len(counts))
+	for _, count := range counts {
+		item, err := u.actionItemRepo.FindByID(ctx, count.ActionItemID)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to get action item", err)
+		}
+		result = append(result, domain.NewRecurringRemediation(item, incidents[count.ActionItemID]))
+	}
+	return result, nil
+}
+
+func isValidActionStatus(status domain.ActionStatus) bool {
+	return status == domain.ActionStatusPending || status == domain.ActionStatusInProgress || status == domain.ActionStatusCompleted || status == domain.ActionStatusVerified
+}
+
+func sameDueDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// OpenExternalIssue opens an issue for an existing action item in the tracker
+func (u *actionItemUsecase) OpenExternalIssue(ctx context.Context, id uint, issueTracker domain.IssueTrackerKind) (*domain.ActionItem, error) {
+	if u.issueTrackers == nil {
+		return nil, domain.ErrValidation("No issue tracker is configured")
+	}
+	item, err := u.actionItemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Action item").WithError(err)
+	}
+	if err := u.issueTrackers.OpenIssue(ctx, item, issueTracker); err != nil {
+		return nil, err
+	}
+	return u.actionItemRepo.FindByID(ctx, id)
+}
+
+// VerifyActionItem records that a completed item was checked to actually prevent a recurrence
+func (u *actionItemUsecase) VerifyActionItem(ctx context.Context, userID uint, id uint, note, evidenceURL string) (*domain.ActionItem, error) {
+	item, err := u.actionItemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Action item").WithError(err)
+	}
+	switch item.Status {
+	case domain.ActionStatusCompleted:
+	case domain.ActionStatusVerified:
+		return nil, domain.ErrConflict("Action item is already verified")
+	default:
+		return nil, domain.ErrValidation("Only completed action items can be verified")
+	}
+	if strings.TrimSpace(note) == "" {
+		return nil, domain.ErrValidation("Verification note is required")
+	}
+
+	now := time.Now()
+	item.Status = domain.ActionStatusVerified
+	item.VerifiedByID = &userID
+	item.VerifiedAt = &now
+	item.VerificationNote = note
+	item.VerificationEvidenceURL = evidenceURL
+	item.VerifiedBy = nil
+
+	if err := u.actionItemRepo.Update(ctx, item); err != nil {
+		return nil, domain.ErrDatabase("Failed to verify action item", err)
+	}
+	return u.actionItemRepo.FindByID(ctx, id)
+}
+
+// GetEffectivenessReport lists the items completed more than unverifiedDays ago that are still unverified,
+// and the completed items whose service or tag had another incident after completion (most incidents first)
+func (u *actionItemUsecase) GetEffectivenessReport(ctx context.Context, unverifiedDays, limit int) (*domain.ActionItemEffectivenessReport, error) {
+	if unverifiedDays < 0 {
+		return nil, domain.ErrValidation("unverified_days must not be negative")
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	unverified, err := u.actionItemRepo.FindUnverified(ctx, time.Now().AddDate(0, 0, -unverifiedDays))
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get unverified action items", err)
+	}
+	if len(unverified) > limit {
+		unverified = unverified[:limit]
+	}
+
+	matches, err := u.actionItemRepo.FindRepeatIncidents(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get repeat incidents", err)
+	}
+	repeats := domain.GroupRepeatIncidents(matches)
+	itemIDs := make([]uint, 0, len(repeats))
+	for itemID := range repeats {
+		itemIDs = append(itemIDs, itemID)
+	}
+	sort.Slice(itemIDs, func(i, j int) bool {
+		a, b := len(repeats[itemIDs[i]]), len(repeats[itemIDs[j]])
+		if a != b {
+			return a > b
+		}
+		return itemIDs[i] < itemIDs[j]
+	})
+	if len(itemIDs) > limit {
+		itemIDs = itemIDs[:limit]
+	}
+
+	recurrences := make([]domain.ActionItemRecurrence, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		item, err := u.actionItemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to get action item", err)
+		}
+		recurrences = append(recurrences, domain.ActionItemRecurrence{ActionItem: item, Incidents: repeats[itemID]})
+	}
+
+	return &domain.ActionItemEffectivenessReport{
+		UnverifiedDays: unverifiedDays,
+		Unverified:     unverified,
+		Recurrences:    recurrences,
+	}, nil
+}
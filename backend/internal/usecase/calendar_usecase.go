@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"incidex/internal/domain"
+	"incidex/internal/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// Period covered by on-call feeds, relative to the time of the request
+	calendarFeedPast   = 30 * 24 * time.Hour
+	calendarFeedFuture = 90 * 24 * time.Hour
+)
+
+// OnCallFeed is the data behind an on-call iCalendar feed.
+type OnCallFeed struct {
+	Shifts    []domain.OnCallShift
+	Schedules map[uint]*domain.OnCallSchedule
+}
+
+type CalendarUsecase interface {
+	GetFeedToken(ctx context.Context, userID uint) (*domain.CalendarFeedToken, error)
+	RegenerateFeedToken(ctx context.Context, userID uint) (string, *domain.CalendarFeedToken, error)
+	RevokeFeedToken(ctx context.Context, userID uint) error
+	Authenticate(ctx context.Context, token string) (*domain.User, error)
+	GetUserOnCallFeed(ctx context.Context, userID uint) (*OnCallFeed, error)
+	GetScheduleOnCallFeed(ctx context.Context, scheduleID uint) (*OnCallFeed, error)
+	GetUserActionItemFeed(ctx context.Context, userID uint) ([]*domain.ActionItem, error)
+}
+
+type calendarUsecase struct {
+	tokenRepo      domain.CalendarFeedTokenRepository
+	actionItemRepo domain.ActionItemRepository
+	onCallUsecase  OnCallUsecase
+}
+
+func NewCalendarUsecase(
+	tokenRepo domain.CalendarFeedTokenRepository,
+	actionItemRepo domain.ActionItemRepository,
+	onCallUsecase OnCallUsecase,
+) CalendarUsecase {
+	return &calendarUsecase{
+		tokenRepo:      tokenRepo,
+		actionItemRepo: actionItemRepo,
+		onCallUsecase:  onCallUsecase,
+	}
+}
+
+func (u *calendarUsecase) GetFeedToken(ctx context.Context, userID uint) (*domain.CalendarFeedToken, error) {
+	token, err := u.tokenRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("Calendar feed token").WithError(err)
+		}
+		return nil, domain.ErrDatabase("Failed to get calendar feed token", err)
+	}
+	return token, nil
+}
+
+// RegenerateFeedToken issues a new feed token for the user, invalidating any previous one.
+// The plain token is only returned here; it cannot be retrieved again later.
+func (u *calendarUsecase) RegenerateFeedToken(ctx context.Context, userID uint) (string, *domain.CalendarFeedToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, domain.ErrInternal("Failed to generate calendar feed token", err)
+	}
+	plain := hex.EncodeToString(raw)
+
+	token := &domain.CalendarFeedToken{
+		UserID:    userID,
+		TokenHash: hashFeedToken(plain),
+	}
+	if err := u.tokenRepo.Replace(ctx, token); err != nil {
+		return "", nil, domain.ErrDatabase("Failed to save calendar feed token", err)
+	}
+	return plain, token, nil
+}
+
+func (u *calendarUsecase) RevokeFeedToken(ctx context.Context, userID uint) error {
+	if err := u.tokenRepo.DeleteByUserID(ctx, userID); err != nil {
+		return domain.ErrDatabase("Failed to revoke calendar feed token", err)
+	}
+	return nil
+}
+
+// Authenticate resolves a feed token to its active owner.
+func (u *calendarUsecase) Authenticate(ctx context.Context, token string) (*domain.User, error) {
+	if token == "" {
+		return nil, domain.ErrUnauthorized("Invalid calendar feed token")
+	}
+
+	feedToken, err := u.tokenRepo.FindByTokenHash(ctx, hashFeedToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUnauthorized("Invalid calendar feed token")
+		}
+		return nil, domain.ErrDatabase("Failed to get calendar feed token", err)
+	}
+	if feedToken.User == nil || !feedToken.User.IsActive {
+		return nil, domain.ErrUnauthorized("Invalid calendar feed token")
+	}
+
+	if err := u.tokenRepo.TouchLastUsed(ctx, feedToken.ID, time.Now()); err != nil {
+		logger.Log.Warn("Failed to update calendar feed token usage", zap.Uint("token_id", feedToken.ID), zap.Error(err))
+	}
+
+	return feedToken.User, nil
+}
+
+func (u *calendarUsecase) GetUserOnCallFeed(ctx context.Context, userID uint) (*OnCallFeed, error) {
+	from, to := calendarFeedWindow()
+
+	schedules, err := u.onCallUsecase.GetAllSchedules(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get on-call schedules", err)
+	}
+	shifts, err := u.onCallUsecase.GetUserShifts(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &OnCallFeed{Shifts: shifts, Schedules: make(map[uint]*domain.OnCallSchedule, len(schedules))}
+	for _, schedule := range schedules {
+		feed.Schedules[schedule.ID] = schedule
+	}
+	return feed, nil
+}
+
+func (u *calendarUsecase) GetScheduleOnCallFeed(ctx context.Context, scheduleID uint) (*OnCallFeed, error) {
+	from, to := calendarFeedWindow()
+
+	schedule, err := u.onCallUsecase.GetScheduleByID(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := u.onCallUsecase.GetShifts(ctx, scheduleID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OnCallFeed{
+		Shifts:    shifts,
+		Schedules: map[uint]*domain.OnCallSchedule{schedule.ID: schedule},
+	}, nil
+}
+
+func (u *calendarUsecase) GetUserActionItemFeed(ctx context.Context, userID uint) ([]*domain.ActionItem, error) {
+	items, err := u.actionItemRepo.FindOpenWithDueDateByAssignee(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get action items", err)
+	}
+	return items, nil
+}
+
+func calendarFeedWindow() (time.Time, time.Time) {
+	now := time.Now()
+	return now.Add(-calendarFeedPast), now.Add(calendarFeedFuture)
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
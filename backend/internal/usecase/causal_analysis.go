@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+	"strings"
+	"time"
+)
+
+// Maximum length of a causal node description
+const maxCausalDescriptionLength = 2000
+
+// CausalNodeInput is the editable content of a causal analysis node
+type CausalNodeInput struct {
+	ParentID    *uint
+	Description string
+	Category    domain.CausalCategory
+	IsRootCause bool
+	Position    int
+}
+
+func (in CausalNodeInput) validate() error {
+	description := strings.TrimSpace(in.Description)
+	if description == "" {
+		return domain.ErrValidation("description is required")
+	}
+	if len([]rune(description)) > maxCausalDescriptionLength {
+		return domain.ErrValidation(fmt.Sprintf("description must be at most %d characters", maxCausalDescriptionLength))
+	}
+	if !in.Category.IsValid() {
+		return domain.ErrValidation("invalid category: " + string(in.Category))
+	}
+	return nil
+}
+
+// GetCausalTree returns the causal analysis tree of a post-mortem
+func (u *postMortemUsecase) GetCausalTree(ctx context.Context, postMortemID uint) (*domain.CausalTree, error) {
+	if _, err := u.findPostMortem(ctx, postMortemID); err != nil {
+		return nil, err
+	}
+
+	nodes, err := u.causalRepo.FindByPostMortemID(ctx, postMortemID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get causal analysis", err)
+	}
+	return domain.BuildCausalTree(postMortemID, nodes), nil
+}
+
+// AddCausalNode adds a cause to the causal analysis tree and returns the updated tree
+func (u *postMortemUsecase) AddCausalNode(ctx context.Context, userID uint, userRole domain.Role, postMortemID uint, input CausalNodeInput) (*domain.CausalTree, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	if _, err := u.findEditablePostMortem(ctx, userID, userRole, postMortemID); err != nil {
+		return nil, err
+	}
+
+	nodes, err := u.causalRepo.FindByPostMortemID(ctx, postMortemID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get causal analysis", err)
+	}
+	if len(nodes) >= domain.MaxCausalNodes {
+		return nil, domain.ErrValidation(fmt.Sprintf("a causal analysis can have at most %d nodes", domain.MaxCausalNodes))
+	}
+	if err := domain.ValidateCausalParent(nodes, 0, input.ParentID); err != nil {
+		return nil, err
+	}
+
+	node := &domain.CausalNode{
+		PostMortemID: postMortemID,
+		ParentID:     input.ParentID,
+		Description:  strings.TrimSpace(input.Description),
+		Category:     input.Category,
+		IsRootCause:  input.IsRootCause,
+		Position:     input.Position,
+	}
+	if err := u.causalRepo.Create(ctx, node); err != nil {
+		return nil, domain.ErrDatabase("Failed to add causal node", err)
+	}
+
+	return u.GetCausalTree(ctx, postMortemID)
+}
+
+// UpdateCausalNode edits a cause, possibly moving it (with its subtree) under another parent
+func (u *postMortemUsecase) UpdateCausalNode(ctx context.Context, userID uint, userRole domain.Role, postMortemID, nodeID uint, input CausalNodeInput) (*domain.CausalTree, error) {
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+	if _, err := u.findEditablePostMortem(ctx, userID, userRole, postMortemID); err != nil {
+		return nil, err
+	}
+
+	node, err := u.findCausalNode(ctx, postMortemID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := u.causalRepo.FindByPostMortemID(ctx, postMortemID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get causal analysis", err)
+	}
+	if err := domain.ValidateCausalParent(nodes, nodeID, input.ParentID); err != nil {
+		return nil, err
+	}
+
+	node.ParentID = input.ParentID
+	node.Description = strings.TrimSpace(input.Description)
+	node.Category = input.Category
+	node.IsRootCause = input.IsRootCause
+	node.Position = input.Position
+	if err := u.causalRepo.Update(ctx, node); err != nil {
+		return nil, domain.ErrDatabase("Failed to update causal node", err)
+	}
+
+	return u.GetCausalTree(ctx, postMortemID)
+}
+
+// DeleteCausalNode removes a cause together with all causes below it
+func (u *postMortemUsecase) DeleteCausalNode(ctx context.Context, userID uint, userRole domain.Role, postMortemID, nodeID uint) (*domain.CausalTree, error) {
+	if _, err := u.findEditablePostMortem(ctx, userID, userRole, postMortemID); err != nil {
+		return nil, err
+	}
+	if _, err := u.findCausalNode(ctx, postMortemID, nodeID); err != nil {
+		return nil, err
+	}
+
+	nodes, err := u.causalRepo.FindByPostMortemID(ctx, postMortemID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get causal analysis", err)
+	}
+	ids := append([]uint{nodeID}, domain.CausalDescendants(nodes, nodeID)...)
+	if err := u.causalRepo.Delete(ctx, ids); err != nil {
+		return nil, domain.ErrDatabase("Failed to delete causal node", err)
+	}
+
+	return u.GetCausalTree(ctx, postMortemID)
+}
+
+// GetCausalCategorySummary aggregates the causal analysis categories of post-mortems created
+// in the last N months (including the current month). An empty status includes all post-mortems.
+func (u *postMortemUsecase) GetCausalCategorySummary(ctx context.Context, months int, status domain.PMStatus) (*domain.CausalCategorySummary, error) {
+	if months < 1 || months > 24 {
+		return nil, domain.ErrValidation("months must be between 1 and 24")
+	}
+	if status != "" && !status.IsValid() {
+		return nil, domain.ErrValidation("invalid status: " + string(status))
+	}
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months - 1), 0)
+
+	summary, err := u.causalRepo.GetCategorySummary(ctx, startDate, now, status)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to aggregate causal analysis", err)
+	}
+	return summary, nil
+}
+
+func (u *postMortemUsecase) findPostMortem(ctx context.Context, id uint) (*domain.PostMortem, error) {
+	pm, err := u.postMortemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Post-mortem").WithError(err)
+	}
+	return pm, nil
+}
+
+// findEditablePostMortem returns the post-mortem if the user may edit it (editors only their own, never while published)
+func (u *postMortemUsecase) findEditablePostMortem(ctx context.Context, userID uint, userRole domain.Role, id uint) (*domain.PostMortem, error) {
+	pm, err := u.findPostMortem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if userRole == domain.RoleEditor && pm.AuthorID != userID {
+		return nil, domain.ErrForbidden("You can only update your own post-mortems")
+	}
+	if pm.Status == domain.PMStatusPublished {
+		return nil, domain.ErrValidation("Published post-mortems must be unpublished before editing")
+	}
+	return pm, nil
+}
+
+func (u *postMortemUsecase) findCausalNode(ctx context.Context, postMortemID, nodeID uint) (*domain.CausalNode, error) {
+	node, err := u.causalRepo.FindByID(ctx, nodeID)
+	if err != nil || node.PostMortemID != postMortemID {
+		return nil, domain.ErrNotFound("Causal node")
+	}
+	return node, nil
+}
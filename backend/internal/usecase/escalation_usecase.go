@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/infrastructure/notification"
+	"incidex/internal/pkg/logger"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type EscalationUsecase interface {
+	CreatePolicy(ctx context.Context, creatorID uint, name, description string, trigger domain.EscalationTrigger, isActive bool, severity domain.Severity, tagID *uint, service string, levels []domain.EscalationLevel) (*domain.EscalationPolicy, error)
+	GetAllPolicies(ctx context.Context) ([]*domain.EscalationPolicy, error)
+	GetPolicyByID(ctx context.Context, id uint) (*domain.EscalationPolicy, error)
+	UpdatePolicy(ctx context.Context, id uint, name, description string, trigger domain.EscalationTrigger, isActive bool, severity domain.Severity, tagID *uint, service string, levels []domain.EscalationLevel) (*domain.EscalationPolicy, error)
+	DeletePolicy(ctx context.Context, id uint) error
+	GetIncidentEscalation(ctx context.Context, incidentID uint) (*domain.IncidentEscalation, error)
+	StartEscalation(ctx context.Context, incident *domain.Incident) error
+	ReevaluateEscalation(ctx context.Context, incident *domain.Incident) error
+	ProcessEscalations(ctx context.Context) error
+}
+
+type escalationUsecase struct {
+	policyRepo          domain.EscalationPolicyRepository
+	escalationRepo      domain.IncidentEscalationRepository
+	incidentRepo        domain.IncidentRepository
+	tagRepo             domain.TagRepository
+	userRepo            domain.UserRepository
+	activityRepo        domain.IncidentActivityRepository
+	notificationService *notification.NotificationService
+	onCallUsecase       OnCallUsecase
+}
+
+func NewEscalationUsecase(
+	policyRepo domain.EscalationPolicyRepository,
+	escalationRepo domain.IncidentEscalationRepository,
+	incidentRepo domain.IncidentRepository,
+	tagRepo domain.TagRepository,
+	userRepo domain.UserRepository,
+	activityRepo domain.IncidentActivityRepository,
+	notificationService *notification.NotificationService,
+	onCallUsecase OnCallUsecase,
+) EscalationUsecase {
+	return &escalationUsecase{
+		policyRepo:          policyRepo,
+		escalationRepo:      escalationRepo,
+		incidentRepo:        incidentRepo,
+		tagRepo:             tagRepo,
+		userRepo:            userRepo,
+		activityRepo:        activityRepo,
+		notificationService: notificationService,
+		onCallUsecase:       onCallUsecase,
+	}
+}
+
+func (u *escalationUsecase) CreatePolicy(ctx context.Context, creatorID uint, name, description string, trigger domain.EscalationTrigger, isActive bool, severity domain.Severity, tagID *uint, service string, levels []domain.EscalationLevel) (*domain.EscalationPolicy, error) {
+	if err := u.validatePolicy(ctx, name, trigger, severity, tagID, levels); err != nil {
+		return nil, err
+	}
+
+	policy := &domain.EscalationPolicy{
+		Name:        name,
+		Description: description,
+		Trigger:     trigger,
+		IsActive:    isActive,
+		Severity:    severity,
+		TagID:       tagID,
+		Service:     service,
+		CreatorID:   creatorID,
+		Levels:      normalizeLevels(levels),
+	}
+
+	if err := u.policyRepo.Create(ctx, policy); err != nil {
+		return nil, domain.ErrDatabase("Failed to create escalation policy", err)
+	}
+
+	return u.policyRepo.FindByID(ctx, policy.ID)
+}
+
+func (u *escalationUsecase) GetAllPolicies(ctx context.Context) ([]*domain.EscalationPolicy, error) {
+	return u.policyRepo.FindAll(ctx)
+}
+
+func (u *escalationUsecase) GetPolicyByID(ctx context.Context, id uint) (*domain.EscalationPolicy, error) {
+	policy, err := u.policyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Escalation policy").WithError(err)
+	}
+	return policy, nil
+}
+
+func (u *escalationUsecase) UpdatePolicy(ctx context.Context, id uint, name, description string, trigger domain.EscalationTrigger, isActive bool, severity domain.Severity, tagID *uint, service string, levels []domain.EscalationLevel) (*domain.EscalationPolicy, error) {
+	policy, err := u.policyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Escalation policy").WithError(err)
+	}
+
+	if err := u.validatePolicy(ctx, name, trigger, severity, tagID, levels); err != nil {
+		return nil, err
+	}
+
+	policy.Name = name
+	policy.Description = description
+	policy.Trigger = trigger
+	policy.IsActive = isActive
+	policy.Severity = severity
+	policy.TagID = tagID
+	policy.Service = service
+	policy.Levels = normalizeLevels(levels)
+
+	if err := u.policyRepo.Update(ctx, policy); err != nil {
+		return nil, domain.ErrDatabase("Failed to update escalation policy", err)
+	}
+
+	return u.policyRepo.FindByID(ctx, policy.ID)
+}
+
+func (u *escalationUsecase) DeletePolicy(ctx context.Context, id uint) error {
+	if _, err := u.policyRepo.FindByID(ctx, id); err != nil {
+		return domain.ErrNotFound("Escalation policy").WithError(err)
+	}
+	if err := u.policyRepo.Delete(ctx, id); err != nil {
+		return domain.ErrDatabase("Failed to delete escalation policy", err)
+	}
+	return nil
+}
+
+func (u *escalationUsecase) GetIncidentEscalation(ctx context.Context, incidentID uint) (*domain.IncidentEscalation, error) {
+	escalation, err := u.escalationRepo.FindByIncidentID(ctx, incidentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("Escalation")
+		}
+		return nil, domain.ErrDatabase("Failed to get escalation", err)
+	}
+	return escalation, nil
+}
+
+// StartEscalation selects the most specific active policy for the incident and pages its first level.
+// Incidents that match no policy, or no longer need escalating (e.g. created already resolved), are left alone.
+func (u *escalationUsecase) StartEscalation(ctx context.Context, incident *domain.Incident) error {
+	if !incident.IsOpen() {
+		return nil
+	}
+
+	policies, err := u.policyRepo.FindActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load escalation policies: %w", err)
+	}
+
+	var selected *domain.EscalationPolicy
+	for _, policy := range policies {
+		if len(policy.Levels) == 0 || !policy.Matches(incident) {
+			continue
+		}
+		// FindActive returns policies in ID order, so the oldest policy wins ties
+		if selected == nil || policy.Specificity() > selected.Specificity() {
+			selected = policy
+		}
+	}
+	if selected == nil || !requiresEscalation(selected, incident) {
+		return nil
+	}
+
+	escalation := &domain.IncidentEscalation{
+		IncidentID: incident.ID,
+		PolicyID:   selected.ID,
+		Status:     domain.EscalationStatusActive,
+		Policy:     selected,
+	}
+	if err := u.escalationRepo.Create(ctx, escalation); err != nil {
+		return fmt.Errorf("failed to create escalation: %w", err)
+	}
+
+	return u.advance(ctx, escalation, incident, time.Now())
+}
+
+// ReevaluateEscalation stops a running escalation once its trigger condition no longer holds.
+func (u *escalationUsecase) ReevaluateEscalation(ctx context.Context, incident *domain.Incident) error {
+	escalation, err := u.escalationRepo.FindByIncidentID(ctx, incident.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if escalation.Status != domain.EscalationStatusActive || escalation.Policy == nil {
+		return nil
+	}
+	if requiresEscalation(escalation.Policy, incident) {
+		return nil
+	}
+
+	escalation.Status = domain.EscalationStatusStopped
+	escalation.NextEscalationAt = nil
+	return u.escalationRepo.Update(ctx, escalation)
+}
+
+// ProcessEscalations advances every escalation whose timeout has elapsed.
+// It is intended to be run periodically by the scheduler.
+func (u *escalationUsecase) ProcessEscalations(ctx context.Context) error {
+	now := time.Now()
+	escalations, err := u.escalationRepo.FindDue(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to load due escalations: %w", err)
+	}
+
+	for _, escalation := range escalations {
+		incident, err := u.incidentRepo.FindByID(ctx, escalation.IncidentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// インシデントが削除された場合は停止する
+				escalation.Status = domain.EscalationStatusStopped
+				escalation.NextEscalationAt = nil
+				if err := u.escalationRepo.Update(ctx, escalation); err != nil {
+					logger.Log.Error("Failed to stop escalation", zap.Uint("escalation_id", escalation.ID), zap.Error(err))
+				}
+				continue
+			}
+			logger.Log.Error("Failed to load incident for escalation", zap.Uint("incident_id", escalation.IncidentID), zap.Error(err))
+			continue
+		}
+
+		if escalation.Policy == nil || !requiresEscalation(escalation.Policy, incident) {
+			escalation.Status = domain.EscalationStatusStopped
+			escalation.NextEscalationAt = nil
+			if err := u.escalationRepo.Update(ctx, escalation); err != nil {
+				logger.Log.Error("Failed to stop escalation", zap.Uint("escalation_id", escalation.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		if err := u.advance(ctx, escalation, incident, now); err != nil {
+			logger.Log.Error("Failed to advance escalation", zap.Uint("escalation_id", escalation.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// advance pages the next level of the policy and schedules the one after it.
+func (u *escalationUsecase) advance(ctx context.Context, escalation *domain.IncidentEscalation, incident *domain.Incident, now time.Time) error {
+	level := escalation.Policy.LevelByNumber(escalation.CurrentLevel + 1)
+	if level == nil {
+		escalation.Status = domain.EscalationStatusExhausted
+		escalation.NextEscalationAt = nil
+		return u.escalationRepo.Update(ctx, escalation)
+	}
+
+	userIDs := u.resolveTargets(ctx, level.Targets, now)
+	for _, userID := range userIDs {
+		u.page(ctx, incident, userID, level.LevelNumber)
+	}
+	if len(userIDs) == 0 {
+		logger.Log.Warn("Escalation level has no reachable targets",
+			zap.Uint("policy_id", escalation.PolicyID),
+			zap.Int("level", level.LevelNumber),
+		)
+	}
+
+	next := now.Add(time.Duration(level.TimeoutMinutes) * time.Minute)
+	escalation.CurrentLevel = level.LevelNumber
+	escalation.LastEscalatedAt = &now
+	escalation.NextEscalationAt = &next
+	return u.escalationRepo.Update(ctx, escalation)
+}
+
+// resolveTargets expands escalation targets into a de-duplicated list of user IDs.
+func (u *escalationUsecase) resolveTargets(ctx context.Context, targets []domain.EscalationTarget, at time.Time) []uint {
+	seen := make(map[uint]bool)
+	var userIDs []uint
+	for _, target := range targets {
+		var ids []uint
+		switch target.TargetType {
+		case domain.EscalationTargetUser:
+			ids = []uint{target.TargetID}
+		case domain.EscalationTargetSchedule:
+			shift, err := u.onCallUsecase.WhoIsOnCall(ctx, target.TargetID, at)
+			if err != nil {
+				logger.Log.Warn("No on-call user for escalation schedule", zap.Uint("schedule_id", target.TargetID), zap.Error(err))
+				continue
+			}
+			ids = []uint{shift.UserID}
+		default:
+			logger.Log.Warn("Unknown escalation target type", zap.String("target_type", string(target.TargetType)))
+		}
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	return userIDs
+}
+
+// page notifies a single user and records the escalation on the incident timeline.
+func (u *escalationUsecase) page(ctx context.Context, incident *domain.Incident, userID uint, levelNumber int) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		logger.Log.Warn("Failed to load escalation target user", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+	if user == nil {
+		logger.Log.Warn("Escalation target user not found", zap.Uint("user_id", userID))
+		return
+	}
+
+	activity := &domain.IncidentActivity{
+		IncidentID:   incident.ID,
+		UserID:       user.ID,
+		ActivityType: domain.ActivityTypeEscalated,
+		NewValue:     strconv.Itoa(levelNumber),
+		Comment:      fmt.Sprintf("エスカレーションレベル%d: %s に通知しました", levelNumber, user.Name),
+		CreatedAt:    time.Now(),
+	}
+	if err := u.activityRepo.Create(activity); err != nil {
+		logger.Log.Error("Failed to log escalation activity", zap.Error(err))
+	}
+
+	if u.notificationService != nil {
+		if err := u.notificationService.NotifyEscalation(incident, user, levelNumber); err != nil {
+			logger.Log.Error("Failed to send escalation notification", zap.Error(err))
+		}
+	}
+}
+
+func (u *escalationUsecase) validatePolicy(ctx context.Context, name string, trigger domain.EscalationTrigger, severity domain.Severity, tagID *uint, levels []domain.EscalationLevel) error {
+	if name == "" {
+		return domain.ErrValidation("Policy name is required")
+	}
+	if trigger != domain.EscalationTriggerUnacknowledged && trigger != domain.EscalationTriggerUnresolved {
+		return domain.ErrValidation("Invalid escalation trigger")
+	}
+	if severity != "" && !isValidSeverity(severity) {
+		return domain.ErrValidation("Invalid severity")
+	}
+	if tagID != nil {
+		if _, err := u.tagRepo.FindByID(ctx, *tagID); err != nil {
+			return domain.ErrValidation(fmt.Sprintf("Tag with ID %d not found", *tagID))
+		}
+	}
+	if len(levels) == 0 {
+		return domain.ErrValidation("At least one escalation level is required")
+	}
+	for i, level := range levels {
+		if level.TimeoutMinutes <= 0 {
+			return domain.ErrValidation(fmt.Sprintf("Level %d: timeout must be greater than 0", i+1))
+		}
+		if len(level.Targets) == 0 {
+			return domain.ErrValidation(fmt.Sprintf("Level %d: at least one target is required", i+1))
+		}
+		for _, target := range level.Targets {
+			if err := u.validateTarget(ctx, target); err != nil {
+				return domain.ErrValidation(fmt.Sprintf("Level %d: %s", i+1, err.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+func (u *escalationUsecase) validateTarget(ctx context.Context, target domain.EscalationTarget) error {
+	switch target.TargetType {
+	case domain.EscalationTargetUser:
+		// FindByID returns no error for a missing user
+		if user, err := u.userRepo.FindByID(ctx, target.TargetID); err != nil || user == nil {
+			return fmt.Errorf("user with ID %d not found", target.TargetID)
+		}
+	case domain.EscalationTargetSchedule:
+		if _, err := u.onCallUsecase.GetScheduleByID(ctx, target.TargetID); err != nil {
+			return fmt.Errorf("on-call schedule with ID %d not found", target.TargetID)
+		}
+	default:
+		return fmt.Errorf("invalid target type %q", target.TargetType)
+	}
+	return nil
+}
+
+// normalizeLevels numbers levels by their position in the request.
+func normalizeLevels(levels []domain.EscalationLevel) []domain.EscalationLevel {
+	normalized := make([]domain.EscalationLevel, len(levels))
+	for i, level := range levels {
+		normalized[i] = domain.EscalationLevel{
+			LevelNumber:    i + 1,
+			TimeoutMinutes: level.TimeoutMinutes,
+			Targets:        make([]domain.EscalationTarget, len(level.Targets)),
+		}
+		for j, target := range level.Targets {
+			normalized[i].Targets[j] = domain.EscalationTarget{
+				TargetType: target.TargetType,
+				TargetID:   target.TargetID,
+			}
+		}
+	}
+	return normalized
+}
+
+// requiresEscalation reports whether the policy's trigger condition still holds for the incident.
+func requiresEscalation(policy *domain.EscalationPolicy, incident *domain.Incident) bool {
+	if !incident.IsOpen() {
+		return false
+	}
+	switch policy.Trigger {
+	case domain.EscalationTriggerUnacknowledged:
+		return !incident.IsAcknowledged()
+	case domain.EscalationTriggerUnresolved:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"sort"
+	"time"
+)
+
+// SLA deadlines up to this long after the handoff are listed as upcoming
+const handoffSLAHorizon = 24 * time.Hour
+
+// handoffEventTypes are the activities listed as notable events in a handoff report
+var handoffEventTypes = map[domain.ActivityType]bool{
+	domain.ActivityTypeComment:              true,
+	domain.ActivityTypeDetected:             true,
+	domain.ActivityTypeInvestigationStarted: true,
+	domain.ActivityTypeRootCauseIdentified:  true,
+	domain.ActivityTypeMitigation:           true,
+	domain.ActivityTypeTimelineResolved:     true,
+	domain.ActivityTypeOther:                true,
+}
+
+// buildHandoffReport sorts incidents of the shift into the report sections.
+func buildHandoffReport(from, to time.Time, incidents []*domain.Incident, activities []domain.IncidentActivity) *domain.HandoffReport {
+	report := &domain.HandoffReport{
+		From:          from,
+		To:            to,
+		GeneratedAt:   time.Now(),
+		Opened:        []domain.HandoffIncident{},
+		Changed:       []domain.HandoffIncident{},
+		Resolved:      []domain.HandoffIncident{},
+		StillOpen:     []domain.HandoffIncident{},
+		UpcomingSLA:   []domain.HandoffIncident{},
+		NotableEvents: []domain.HandoffEvent{},
+	}
+
+	titles := make(map[uint]string, len(incidents))
+	for _, incident := range incidents {
+		titles[incident.ID] = incident.Title
+	}
+
+	changes := make(map[uint][]string)
+	for _, activity := range activities {
+		if _, ok := titles[activity.IncidentID]; !ok {
+			continue
+		}
+		if change := describeChange(activity); change != "" {
+			changes[activity.IncidentID] = append(changes[activity.IncidentID], change)
+		}
+		if handoffEventTypes[activity.ActivityType] {
+			userName := ""
+			if activity.User != nil {
+				userName = activity.User.Name
+			}
+			report.NotableEvents = append(report.NotableEvents, domain.HandoffEvent{
+				IncidentID:    activity.IncidentID,
+				IncidentTitle: titles[activity.IncidentID],
+				ActivityType:  activity.ActivityType,
+				UserName:      userName,
+				Comment:       activity.Comment,
+				At:            activity.CreatedAt,
+			})
+		}
+	}
+
+	for _, incident := range incidents {
+		item := toHandoffIncident(incident, changes[incident.ID])
+
+		opened := inRange(incident.DetectedAt, from, to)
+		resolved := incident.ResolvedAt != nil && inRange(*incident.ResolvedAt, from, to)
+		if opened {
+			report.Opened = append(report.Opened, item)
+		}
+		if resolved {
+			report.Resolved = append(report.Resolved, item)
+		}
+		if !opened && !resolved && len(item.Changes) > 0 {
+			report.Changed = append(report.Changed, item)
+		}
+
+		if openAt(incident, to) {
+			report.StillOpen = append(report.StillOpen, item)
+			if incident.SLADeadline != nil && !incident.SLADeadline.After(to.Add(handoffSLAHorizon)) {
+				report.UpcomingSLA = append(report.UpcomingSLA, item)
+			}
+		}
+	}
+
+	sort.Slice(report.UpcomingSLA, func(i, j int) bool {
+		return report.UpcomingSLA[i].SLADeadline.Before(*report.UpcomingSLA[j].SLADeadline)
+	})
+
+	return report
+}
+
+func toHandoffIncident(incident *domain.Incident, changes []string) domain.HandoffIncident {
+	item := domain.HandoffIncident{
+		ID:          incident.ID,
+		Title:       incident.Title,
+		Severity:    incident.Severity,
+		Status:      incident.Status,
+		Service:     incident.Service,
+		DetectedAt:  incident.DetectedAt,
+		ResolvedAt:  incident.ResolvedAt,
+		SLADeadline: incident.SLADeadline,
+		SLAViolated: incident.SLAViolated,
+		Changes:     changes,
+	}
+	if incident.Assignee != nil {
+		item.AssigneeName = incident.Assignee.Name
+	}
+	return item
+}
+
+// describeChange summarizes a field change activity, or returns "" for other activities.
+func describeChange(activity domain.IncidentActivity) string {
+	switch activity.ActivityType {
+	case domain.ActivityTypeStatusChange:
+		return fmt.Sprintf("ステータス: %s → %s", activity.OldValue, activity.NewValue)
+	case domain.ActivityTypeSeverityChange:
+		return fmt.Sprintf("重要度: %s → %s", activity.OldValue, activity.NewValue)
+	case domain.ActivityTypeAssigneeChange:
+		if activity.OldValue == "" && activity.NewValue == "" {
+			return "担当者を変更"
+		}
+		return fmt.Sprintf("担当者: %s → %s", valueOrDash(activity.OldValue), valueOrDash(activity.NewValue))
+	case domain.ActivityTypeAcknowledged:
+		return "受諾"
+	case domain.ActivityTypeEscalated:
+		return fmt.Sprintf("エスカレーション (レベル%s)", activity.NewValue)
+	case domain.ActivityTypeReopened:
+		return "再オープン"
+	}
+	return ""
+}
+
+// openAt reports whether the incident had been detected and was not yet resolved at the given time.
+func openAt(incident *domain.Incident, at time.Time) bool {
+	if incident.DetectedAt.After(at) {
+		return false
+	}
+	if incident.ResolvedAt != nil {
+		return incident.ResolvedAt.After(at)
+	}
+	return incident.Status == domain.StatusOpen || incident.Status == domain.StatusInvestigating
+}
+
+func inRange(t, from, to time.Time) bool {
+	return !t.Before(from) && !t.After(to)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
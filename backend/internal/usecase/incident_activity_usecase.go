@@ -1,10 +1,14 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"incidex/internal/domain"
 	"incidex/internal/infrastructure/notification"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type IncidentActivityUsecase struct {
@@ -89,6 +93,27 @@ func (u *IncidentActivityUsecase) GetActivities(incidentID uint, limit int) ([]*
 	return u.activityRepo.FindByIncidentID(incidentID, limit)
 }
 
+// GetLifecycle derives the lifecycle intervals (MTTA/MTTD/MTTM/MTTR and phases) of an incident from its timeline events.
+func (u *IncidentActivityUsecase) GetLifecycle(ctx context.Context, incidentID uint) (*domain.IncidentLifecycle, error) {
+	incident, err := u.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+
+	activities, err := u.activityRepo.FindByIncidentID(incidentID, 0)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incident activities", err)
+	}
+
+	events := make([]domain.IncidentActivity, len(activities))
+	for i, activity := range activities {
+		events[i] = *activity
+	}
+
+	lifecycle := domain.NewIncidentLifecycle(incident, events)
+	return &lifecycle, nil
+}
+
 // GetRecentActivities retrieves recent activities across all incidents.
 func (u *IncidentActivityUsecase) GetRecentActivities(limit int) ([]*domain.IncidentActivity, error) {
 	return u.activityRepo.FindRecent(limit)
@@ -97,22 +122,7 @@ func (u *IncidentActivityUsecase) GetRecentActivities(limit int) ([]*domain.Inci
 // AddTimelineEvent adds a timeline event to an incident.
 func (u *IncidentActivityUsecase) AddTimelineEvent(incidentID uint, userID uint, eventType domain.ActivityType, eventTime time.Time, description string) (*domain.IncidentActivity, error) {
 	// Validate event type
-	validEventTypes := []domain.ActivityType{
-		domain.ActivityTypeDetected,
-		domain.ActivityTypeInvestigationStarted,
-		domain.ActivityTypeRootCauseIdentified,
-		domain.ActivityTypeMitigation,
-		domain.ActivityTypeTimelineResolved,
-		domain.ActivityTypeOther,
-	}
-	isValid := false
-	for _, validType := range validEventTypes {
-		if eventType == validType {
-			isValid = true
-			break
-		}
-	}
-	if !isValid {
+	if !eventType.IsTimelineEvent() {
 		return nil, domain.ErrValidation("invalid event type: " + string(eventType))
 	}
 
@@ -130,3 +140,101 @@ func (u *IncidentActivityUsecase) AddTimelineEvent(incidentID uint, userID uint,
 
 	return activity, nil
 }
+
+// GetTimeline retrieves the timeline events of an incident ordered by event time.
+func (u *IncidentActivityUsecase) GetTimeline(incidentID uint) ([]*domain.IncidentActivity, error) {
+	events, err := u.activityRepo.FindTimelineByIncidentID(incidentID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get timeline events", err)
+	}
+	return events, nil
+}
+
+// UpdateTimelineEvent corrects the type, time and description of a timeline event.
+// The previous values are kept as a revision.
+func (u *IncidentActivityUsecase) UpdateTimelineEvent(incidentID, eventID, editorID uint, eventType domain.ActivityType, eventTime time.Time, description string) (*domain.IncidentActivity, error) {
+	if !eventType.IsTimelineEvent() {
+		return nil, domain.ErrValidation("invalid event type: " + string(eventType))
+	}
+
+	activity, err := u.findTimelineEvent(incidentID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if activity.ActivityType == eventType && activity.CreatedAt.Equal(eventTime) && activity.Comment == description {
+		return activity, nil
+	}
+
+	revision := &domain.TimelineEventRevision{
+		ActivityID:     activity.ID,
+		IncidentID:     activity.IncidentID,
+		EditorID:       editorID,
+		Action:         domain.TimelineRevisionUpdated,
+		OldEventType:   activity.ActivityType,
+		OldEventTime:   activity.CreatedAt,
+		OldDescription: activity.Comment,
+		NewEventType:   eventType,
+		NewEventTime:   &eventTime,
+		NewDescription: description,
+		CreatedAt:      time.Now(),
+	}
+
+	activity.ActivityType = eventType
+	activity.CreatedAt = eventTime
+	activity.Comment = description
+
+	if err := u.activityRepo.UpdateTimelineEvent(activity, revision); err != nil {
+		return nil, domain.ErrDatabase("Failed to update timeline event", err)
+	}
+
+	return activity, nil
+}
+
+// DeleteTimelineEvent deletes a timeline event, keeping its values as a revision.
+func (u *IncidentActivityUsecase) DeleteTimelineEvent(incidentID, eventID, editorID uint) error {
+	activity, err := u.findTimelineEvent(incidentID, eventID)
+	if err != nil {
+		return err
+	}
+
+	revision := &domain.TimelineEventRevision{
+		ActivityID:     activity.ID,
+		IncidentID:     activity.IncidentID,
+		EditorID:       editorID,
+		Action:         domain.TimelineRevisionDeleted,
+		OldEventType:   activity.ActivityType,
+		OldEventTime:   activity.CreatedAt,
+		OldDescription: activity.Comment,
+		CreatedAt:      time.Now(),
+	}
+
+	if err := u.activityRepo.DeleteTimelineEvent(activity, revision); err != nil {
+		return domain.ErrDatabase("Failed to delete timeline event", err)
+	}
+	return nil
+}
+
+// GetTimelineRevisions retrieves the edit and deletion history of an incident's timeline, newest first.
+func (u *IncidentActivityUsecase) GetTimelineRevisions(incidentID uint) ([]*domain.TimelineEventRevision, error) {
+	revisions, err := u.activityRepo.FindRevisionsByIncidentID(incidentID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get timeline history", err)
+	}
+	return revisions, nil
+}
+
+// findTimelineEvent loads a timeline event, treating events of other incidents and non-timeline activities as not found.
+func (u *IncidentActivityUsecase) findTimelineEvent(incidentID, eventID uint) (*domain.IncidentActivity, error) {
+	activity, err := u.activityRepo.FindByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("Timeline event")
+		}
+		return nil, domain.ErrDatabase("Failed to get timeline event", err)
+	}
+	if activity.IncidentID != incidentID || !activity.ActivityType.IsTimelineEvent() {
+		return nil, domain.ErrNotFound("Timeline event")
+	}
+	return activity, nil
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"incidex/internal/domain"
+	"incidex/internal/infrastructure/notification"
 	"time"
 )
 
 type IncidentTemplateUsecase struct {
-	templateRepo domain.IncidentTemplateRepository
-	tagRepo      domain.TagRepository
-	incidentRepo domain.IncidentRepository
-	userRepo     domain.UserRepository
+	templateRepo        domain.IncidentTemplateRepository
+	tagRepo             domain.TagRepository
+	incidentRepo        domain.IncidentRepository
+	userRepo            domain.UserRepository
+	activityRepo        domain.IncidentActivityRepository
+	notificationService *notification.NotificationService
+	escalationUsecase   EscalationUsecase
+	onCallUsecase       OnCallUsecase
 }
 
 func NewIncidentTemplateUsecase(
@@ -19,12 +24,20 @@ func NewIncidentTemplateUsecase(
 	tagRepo domain.TagRepository,
 	incidentRepo domain.IncidentRepository,
 	userRepo domain.UserRepository,
+	activityRepo domain.IncidentActivityRepository,
+	notificationService *notification.NotificationService,
+	escalationUsecase EscalationUsecase,
+	onCallUsecase OnCallUsecase,
 ) *IncidentTemplateUsecase {
 	return &IncidentTemplateUsecase{
-		templateRepo: templateRepo,
-		tagRepo:      tagRepo,
-		incidentRepo: incidentRepo,
-		userRepo:     userRepo,
+		templateRepo:        templateRepo,
+		tagRepo:             tagRepo,
+		incidentRepo:        incidentRepo,
+		userRepo:            userRepo,
+		activityRepo:        activityRepo,
+		notificationService: notificationService,
+		escalationUsecase:   escalationUsecase,
+		onCallUsecase:       onCallUsecase,
 	}
 }
 
@@ -176,10 +189,32 @@ func (u *IncidentTemplateUsecase) CreateIncidentFromTemplate(ctx context.Context
 	// Calculate SLA deadline
 	incident.SLADeadline = incident.CalculateSLADeadline()
 
+	// Auto-assign the current on-call user if no assignee was given
+	autoAssigned := assignOnCall(ctx, u.onCallUsecase, incident)
+
 	// Create incident
 	if err := u.incidentRepo.Create(ctx, incident); err != nil {
 		return nil, err
 	}
+	if autoAssigned {
+		recordOnCallAssignment(ctx, u.activityRepo, u.userRepo, incident, creatorID)
+	}
+
+	// Notify the assignee
+	if u.notificationService != nil {
+		if creator, err := u.userRepo.FindByID(ctx, creatorID); err == nil {
+			if err := u.notificationService.NotifyIncidentCreated(incident, creator); err != nil {
+				fmt.Printf("Failed to send notification: %v\n", err)
+			}
+		}
+	}
+
+	// Start escalation if a policy matches
+	if u.escalationUsecase != nil {
+		if err := u.escalationUsecase.StartEscalation(ctx, incident); err != nil {
+			fmt.Printf("Failed to start escalation: %v\n", err)
+		}
+	}
 
 	// Reload to get all relations
 	return u.incidentRepo.FindByID(ctx, incident.ID)
@@ -17,13 +17,14 @@ import (
 )
 
 type IncidentUsecase interface {
-	CreateIncident(ctx context.Context, creatorID uint, title, description string, severity domain.Severity, status domain.Status, impactScope string, detectedAt time.Time, assigneeID *uint, tagIDs []uint) (*domain.Incident, error)
+	CreateIncident(ctx context.Context, creatorID uint, title, description string, severity domain.Severity, status domain.Status, impactScope, service string, detectedAt time.Time, assigneeID *uint, tagIDs []uint, impact domain.CustomerImpact) (*domain.Incident, error)
 	GetAllIncidents(ctx context.Context, filters domain.IncidentFilters, pagination domain.Pagination) ([]*domain.Incident, *domain.PaginationResult, error)
 	GetIncidentByID(ctx context.Context, id uint) (*domain.Incident, error)
-	UpdateIncident(ctx context.Context, userID uint, userRole domain.Role, id uint, title, description string, severity domain.Severity, status domain.Status, impactScope string, detectedAt time.Time, resolvedAt *time.Time, assigneeID *uint, tagIDs []uint) (*domain.Incident, error)
+	UpdateIncident(ctx context.Context, userID uint, userRole domain.Role, id uint, title, description string, severity domain.Severity, status domain.Status, impactScope string, service *string, detectedAt time.Time, resolvedAt *time.Time, assigneeID *uint, tagIDs []uint, impact domain.CustomerImpactUpdate) (*domain.Incident, error)
 	DeleteIncident(ctx context.Context, userRole domain.Role, id uint) error
 	RegenerateSummary(ctx context.Context, id uint) (string, error)
 	AssignIncident(ctx context.Context, userID uint, incidentID uint, assigneeID *uint) (*domain.Incident, error)
+	AcknowledgeIncident(ctx context.Context, userID uint, incidentID uint) (*domain.Incident, error)
 }
 
 type incidentUsecase struct {
@@ -34,9 +35,15 @@ type incidentUsecase struct {
 	notificationService *notification.NotificationService
 	aiService           *ai.OpenAIService
 	cacheRepo           domain.CacheRepository
+	escalationUsecase   EscalationUsecase
+	onCallUsecase       OnCallUsecase
+	// Mandatory post-mortems (optional)
+	postMortemRequirements *PostMortemRequirementUsecase
+	// Similar post-mortem suggestions (optional)
+	knowledgeBase *KnowledgeBaseUsecase
 }
 
-func NewIncidentUsecase(incidentRepo domain.IncidentRepository, tagRepo domain.TagRepository, userRepo domain.UserRepository, activityRepo domain.IncidentActivityRepository, notificationService *notification.NotificationService, aiService *ai.OpenAIService, cacheRepo domain.CacheRepository) IncidentUsecase {
+func NewIncidentUsecase(incidentRepo domain.IncidentRepository, tagRepo domain.TagRepository, userRepo domain.UserRepository, activityRepo domain.IncidentActivityRepository, notificationService *notification.NotificationService, aiService *ai.OpenAIService, cacheRepo domain.CacheRepository, escalationUsecase EscalationUsecase, onCallUsecase OnCallUsecase, postMortemRequirements *PostMortemRequirementUsecase, knowledgeBase *KnowledgeBaseUsecase) IncidentUsecase {
 	return &incidentUsecase{
 		incidentRepo:        incidentRepo,
 		tagRepo:             tagRepo,
@@ -45,10 +52,15 @@ func NewIncidentUsecase(incidentRepo domain.IncidentRepository, tagRepo domain.T
 		notificationService: notificationService,
 		aiService:           aiService,
 		cacheRepo:           cacheRepo,
+		escalationUsecase:   escalationUsecase,
+		onCallUsecase:       onCallUsecase,
+
+		postMortemRequirements: postMortemRequirements,
+		knowledgeBase:          knowledgeBase,
 	}
 }
 
-func (u *incidentUsecase) CreateIncident(ctx context.Context, creatorID uint, title, description string, severity domain.Severity, status domain.Status, impactScope string, detectedAt time.Time, assigneeID *uint, tagIDs []uint) (*domain.Incident, error) {
+func (u *incidentUsecase) CreateIncident(ctx context.Context, creatorID uint, title, description string, severity domain.Severity, status domain.Status, impactScope, service string, detectedAt time.Time, assigneeID *uint, tagIDs []uint, impact domain.CustomerImpact) (*domain.Incident, error) {
 	// Validate severity
 	if !isValidSeverity(severity) {
 		return nil, errors.New("invalid severity")
@@ -94,16 +106,25 @@ func (u *incidentUsecase) CreateIncident(ctx context.Context, creatorID uint, ti
 		Severity:                 severity,
 		Status:                   status,
 		ImpactScope:              impactScope,
+		Service:                  service,
 		DetectedAt:               detectedAt,
 		AssigneeID:               assigneeID,
 		CreatorID:                creatorID,
 		Tags:                     tags,
 		SLATargetResolutionHours: slaHours,
+		CustomerImpact:           impact,
+	}
+
+	if err := incident.ValidateCustomerImpact(time.Now()); err != nil {
+		return nil, err
 	}
 
 	// Calculate and set SLA deadline
 	incident.SLADeadline = incident.CalculateSLADeadline()
 
+	// Auto-assign the current on-call user if no assignee was given
+	autoAssigned := assignOnCall(ctx, u.onCallUsecase, incident)
+
 	if err := u.incidentRepo.Create(ctx, incident); err != nil {
 		return nil, err
 	}
@@ -119,6 +140,9 @@ func (u *incidentUsecase) CreateIncident(ctx context.Context, creatorID uint, ti
 		// Log error but don't fail the incident creation
 		logger.Log.Error("Failed to log creation activity", zap.Error(err))
 	}
+	if autoAssigned {
+		recordOnCallAssignment(ctx, u.activityRepo, u.userRepo, incident, creatorID)
+	}
 
 	// Send notification
 	if u.notificationService != nil {
@@ -130,6 +154,20 @@ func (u *incidentUsecase) CreateIncident(ctx context.Context, creatorID uint, ti
 		}
 	}
 
+	// Start escalation if a policy matches
+	if u.escalationUsecase != nil {
+		if err := u.escalationUsecase.StartEscalation(ctx, incident); err != nil {
+			logger.Log.Error("Failed to start escalation", zap.Uint("incident_id", incident.ID), zap.Error(err))
+		}
+	}
+
+	// Incidents recorded as already resolved start their post-mortem deadline right away
+	if u.postMortemRequirements != nil && (status == domain.StatusResolved || status == domain.StatusClosed) {
+		if err := u.postMortemRequirements.EvaluateResolution(ctx, incident); err != nil {
+			logger.Log.Error("Failed to evaluate post-mortem requirement", zap.Uint("incident_id", incident.ID), zap.Error(err))
+		}
+	}
+
 	// Cache the summary if generated (TTL = 0 means no expiration)
 	if summary != "" {
 		cacheKey := fmt.Sprintf("incident:summary:%d", incident.ID)
@@ -144,7 +182,21 @@ func (u *incidentUsecase) CreateIncident(ctx context.Context, creatorID uint, ti
 	u.invalidateSearchCache(ctx)
 
 	// Reload to get all relations
-	return u.incidentRepo.FindByID(ctx, incident.ID)
+	created, err := u.incidentRepo.FindByID(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Suggest similar past post-mortems
+	if u.knowledgeBase != nil {
+		similar, err := u.knowledgeBase.FindSimilar(ctx, created)
+		if err != nil {
+			logger.Log.Warn("Failed to find similar post-mortems", zap.Uint("incident_id", created.ID), zap.Error(err))
+		} else {
+			created.SimilarPostMortems = similar
+		}
+	}
+	return created, nil
 }
 
 func (u *incidentUsecase) GetAllIncidents(ctx context.Context, filters domain.IncidentFilters, pagination domain.Pagination) ([]*domain.Incident, *domain.PaginationResult, error) {
@@ -197,7 +249,43 @@ func (u *incidentUsecase) GetIncidentByID(ctx context.Context, id uint) (*domain
 	return u.incidentRepo.FindByID(ctx, id)
 }
 
-func (u *incidentUsecase) UpdateIncident(ctx context.Context, userID uint, userRole domain.Role, id uint, title, description string, severity domain.Severity, status domain.Status, impactScope string, detectedAt time.Time, resolvedAt *time.Time, assigneeID *uint, tagIDs []uint) (*domain.Incident, error) {
+// assignOnCall sets the current on-call user as the assignee of an incident that has none and reports whether it did
+func assignOnCall(ctx context.Context, onCallUsecase OnCallUsecase, incident *domain.Incident) bool {
+	if incident.AssigneeID != nil || onCallUsecase == nil {
+		return false
+	}
+	onCallUserID, err := onCallUsecase.FindAssignee(ctx, incident, time.Now())
+	if err != nil {
+		logger.Log.Warn("Failed to resolve on-call assignee", zap.Error(err))
+		return false
+	}
+	if onCallUserID == nil {
+		return false
+	}
+	incident.AssigneeID = onCallUserID
+	return true
+}
+
+// recordOnCallAssignment logs the on-call auto-assignment of a newly created incident on its timeline
+func recordOnCallAssignment(ctx context.Context, activityRepo domain.IncidentActivityRepository, userRepo domain.UserRepository, incident *domain.Incident, creatorID uint) {
+	assigneeName := "Unknown"
+	if assignee, err := userRepo.FindByID(ctx, *incident.AssigneeID); err == nil {
+		assigneeName = assignee.Name
+	}
+	activity := &domain.IncidentActivity{
+		IncidentID:   incident.ID,
+		UserID:       creatorID,
+		ActivityType: domain.ActivityTypeAssigneeChange,
+		NewValue:     assigneeName,
+		Comment:      fmt.Sprintf("オンコール担当者 %s を自動で割り当てました", assigneeName),
+		CreatedAt:    time.Now(),
+	}
+	if err := activityRepo.Create(activity); err != nil {
+		logger.Log.Error("Failed to log auto-assign activity", zap.Error(err))
+	}
+}
+
+func (u *incidentUsecase) UpdateIncident(ctx context.Context, userID uint, userRole domain.Role, id uint, title, description string, severity domain.Severity, status domain.Status, impactScope string, service *string, detectedAt time.Time, resolvedAt *time.Time, assigneeID *uint, tagIDs []uint, impact domain.CustomerImpactUpdate) (*domain.Incident, error) {
 	// Fetch existing incident
 	incident, err := u.incidentRepo.FindByID(ctx, id)
 	if err != nil {
@@ -322,15 +410,24 @@ func (u *incidentUsecase) UpdateIncident(ctx context.Context, userID uint, userR
 		incident.ImpactScope != impactScope
 
 	// Update incident fields
+	oldStatus := incident.Status
 	incident.Title = title
 	incident.Description = description
 	incident.Severity = severity
 	incident.Status = status
 	incident.ImpactScope = impactScope
+	if service != nil {
+		incident.Service = *service
+	}
 	incident.DetectedAt = detectedAt
 	incident.ResolvedAt = resolvedAt
 	incident.AssigneeID = assigneeID
 	incident.Tags = tags
+	incident.ApplyCustomerImpact(impact)
+
+	if err := incident.ValidateCustomerImpact(time.Now()); err != nil {
+		return nil, err
+	}
 
 	// Update SLA if severity changed
 	if incident.Severity != severity {
@@ -341,10 +438,25 @@ func (u *incidentUsecase) UpdateIncident(ctx context.Context, userID uint, userR
 	// Check and update SLA violation status
 	incident.SLAViolated = incident.CheckSLAViolation()
 
+	// Closing requires a published post-mortem when a mandatory post-mortem policy applies
+	if u.postMortemRequirements != nil && status == domain.StatusClosed && oldStatus != domain.StatusClosed {
+		if err := u.postMortemRequirements.CheckClose(ctx, incident); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := u.incidentRepo.Update(ctx, incident); err != nil {
 		return nil, err
 	}
 
+	// Start the post-mortem deadline when the incident is resolved
+	if u.postMortemRequirements != nil && (oldStatus == domain.StatusOpen || oldStatus == domain.StatusInvestigating) &&
+		(status == domain.StatusResolved || status == domain.StatusClosed) {
+		if err := u.postMortemRequirements.EvaluateResolution(ctx, incident); err != nil {
+			logger.Log.Error("Failed to evaluate post-mortem requirement", zap.Uint("incident_id", incident.ID), zap.Error(err))
+		}
+	}
+
 	// Save all activities
 	for _, activity := range activities {
 		if err := u.activityRepo.Create(activity); err != nil {
@@ -387,6 +499,13 @@ func (u *incidentUsecase) UpdateIncident(ctx context.Context, userID uint, userR
 		}
 	}
 
+	// Stop escalation once the incident is no longer open
+	if u.escalationUsecase != nil {
+		if err := u.escalationUsecase.ReevaluateEscalation(ctx, incident); err != nil {
+			logger.Log.Error("Failed to reevaluate escalation", zap.Uint("incident_id", incident.ID), zap.Error(err))
+		}
+	}
+
 	// Delete summary cache if summary-affecting fields changed
 	if summaryChanged {
 		cacheKey := fmt.Sprintf("incident:summary:%d", incident.ID)
@@ -557,6 +676,53 @@ func (u *incidentUsecase) AssignIncident(ctx context.Context, userID uint, incid
 	return reloadedIncident, nil
 }
 
+func (u *incidentUsecase) AcknowledgeIncident(ctx context.Context, userID uint, incidentID uint) (*domain.Incident, error) {
+	incident, err := u.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+	if incident.IsAcknowledged() {
+		return nil, domain.ErrConflict("Incident has already been acknowledged")
+	}
+	if !incident.IsOpen() {
+		return nil, domain.ErrValidation("Only open incidents can be acknowledged")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrNotFound("User").WithError(err)
+	}
+
+	now := time.Now()
+	incident.AcknowledgedAt = &now
+	incident.AcknowledgedByID = &userID
+	incident.AcknowledgedBy = nil
+	if err := u.incidentRepo.Update(ctx, incident); err != nil {
+		return nil, domain.ErrDatabase("Failed to acknowledge incident", err)
+	}
+
+	activity := &domain.IncidentActivity{
+		IncidentID:   incident.ID,
+		UserID:       userID,
+		ActivityType: domain.ActivityTypeAcknowledged,
+		Comment:      fmt.Sprintf("%s がインシデントを受諾しました", user.Name),
+		CreatedAt:    now,
+	}
+	if err := u.activityRepo.Create(activity); err != nil {
+		logger.Log.Error("Failed to log acknowledge activity", zap.Error(err))
+	}
+
+	if u.escalationUsecase != nil {
+		if err := u.escalationUsecase.ReevaluateEscalation(ctx, incident); err != nil {
+			logger.Log.Error("Failed to reevaluate escalation", zap.Uint("incident_id", incident.ID), zap.Error(err))
+		}
+	}
+
+	u.invalidateSearchCache(ctx)
+
+	return u.incidentRepo.FindByID(ctx, incident.ID)
+}
+
 // Helper functions
 
 func isValidSeverity(severity domain.Severity) bool {
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/infrastructure/issuetracker"
+	"incidex/internal/pkg/logger"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// IssueTrackerUsecase opens external issues for action items and applies the trackers' webhooks,
+// so that items worked on in GitHub Issues or Jira are completed in incidex as well.
+type IssueTrackerUsecase struct {
+	actionItemRepo domain.ActionItemRepository
+	trackers       issuetracker.Registry
+}
+
+func NewIssueTrackerUsecase(actionItemRepo domain.ActionItemRepository, trackers issuetracker.Registry) *IssueTrackerUsecase {
+	return &IssueTrackerUsecase{
+		actionItemRepo: actionItemRepo,
+		trackers:       trackers,
+	}
+}
+
+// Trackers returns the kinds of the configured trackers
+func (u *IssueTrackerUsecase) Trackers() []domain.IssueTrackerKind {
+	kinds := make([]domain.IssueTrackerKind, 0, len(u.trackers))
+	for _, kind := range []domain.IssueTrackerKind{domain.IssueTrackerGitHub, domain.IssueTrackerJira} {
+		if _, ok := u.trackers[kind]; ok {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+func (u *IssueTrackerUsecase) tracker(kind domain.IssueTrackerKind) (domain.IssueTracker, error) {
+	tracker, ok := u.trackers[kind]
+	if !ok {
+		return nil, domain.ErrValidation(fmt.Sprintf("Issue tracker %q is not configured", kind))
+	}
+	return tracker, nil
+}
+
+// OpenIssue opens an issue for the action item in the tracker and stores its key
+func (u *IssueTrackerUsecase) OpenIssue(ctx context.Context, item *domain.ActionItem, kind domain.IssueTrackerKind) error {
+	tracker, err := u.tracker(kind)
+	if err != nil {
+		return err
+	}
+	if item.ExternalKey != "" {
+		return domain.ErrConflict(fmt.Sprintf("Action item is already tracked as %s", item.ExternalKey))
+	}
+
+	issue, err := tracker.CreateIssue(ctx, item)
+	if err != nil {
+		return domain.ErrExternalAPI(string(kind), err)
+	}
+
+	item.ExternalTracker = kind
+	item.ExternalKey = issue.Key
+	item.ExternalURL = issue.URL
+	if err := u.actionItemRepo.UpdateExternalIssue(ctx, item); err != nil {
+		return domain.ErrDatabase("Failed to save external issue", err)
+	}
+	return nil
+}
+
+// HandleWebhook applies a tracker webhook: closing the issue completes the action item, reopening it reopens the item.
+// Events for issues not opened by incidex are ignored.
+func (u *IssueTrackerUsecase) HandleWebhook(ctx context.Context, kind domain.IssueTrackerKind, header http.Header, body []byte) error {
+	tracker, err := u.tracker(kind)
+	if err != nil {
+		return domain.ErrNotFound("Issue tracker").WithError(err)
+	}
+
+	event, err := tracker.ParseWebhook(header, body)
+	if errors.Is(err, issuetracker.ErrInvalidSignature) {
+		return domain.ErrUnauthorized("Invalid webhook signature")
+	}
+	if err != nil {
+		return domain.ErrBadRequest(err.Error())
+	}
+	if event == nil {
+		return nil
+	}
+
+	item, err := u.actionItemRepo.FindByExternalIssue(ctx, kind, event.Key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return domain.ErrDatabase("Failed to find action item of the issue", err)
+	}
+
+	completed := item.Status.IsDone()
+	switch {
+	case event.Closed && !completed:
+		// Blocked items stay open until their blockers are done, as when completing them in incidex
+		blockers, err := u.actionItemRepo.FindOpenBlockers(ctx, item.ID)
+		if err != nil {
+			return domain.ErrDatabase("Failed to check blocking action items", err)
+		}
+		if len(blockers) > 0 {
+			logger.Log.Warn("Issue closed but action item is blocked by open action items, not completing it",
+				zap.Uint("action_item_id", item.ID),
+				zap.String("tracker", string(kind)),
+				zap.String("key", event.Key),
+				zap.Int("open_blockers", len(blockers)))
+			return nil
+		}
+		closedAt := time.Now()
+		if event.ClosedAt != nil {
+			closedAt = *event.ClosedAt
+		}
+		item.Status = domain.ActionStatusCompleted
+		item.CompletedAt = &closedAt
+	case !event.Closed && completed:
+		item.Status = domain.ActionStatusInProgress
+		item.CompletedAt = nil
+		item.ClearVerification()
+	default:
+		return nil
+	}
+
+	if err := u.actionItemRepo.Update(ctx, item); err != nil {
+		return domain.ErrDatabase("Failed to update action item", err)
+	}
+	logger.Log.Info("Action item synced from issue tracker",
+		zap.Uint("action_item_id", item.ID),
+		zap.String("tracker", string(kind)),
+		zap.String("key", event.Key),
+		zap.String("status", string(item.Status)))
+	return nil
+}
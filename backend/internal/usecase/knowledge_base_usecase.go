@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"html"
+	"incidex/internal/domain"
+	"strings"
+)
+
+// KnowledgeBaseUsecase searches the lessons learned of published post-mortems
+// and suggests similar past post-mortems for new incidents.
+type KnowledgeBaseUsecase struct {
+	knowledgeBaseRepo domain.KnowledgeBaseRepository
+	incidentRepo      domain.IncidentRepository
+}
+
+func NewKnowledgeBaseUsecase(knowledgeBaseRepo domain.KnowledgeBaseRepository, incidentRepo domain.IncidentRepository) *KnowledgeBaseUsecase {
+	return &KnowledgeBaseUsecase{
+		knowledgeBaseRepo: knowledgeBaseRepo,
+		incidentRepo:      incidentRepo,
+	}
+}
+
+// Fields of a post-mortem that are searched and highlighted, in display order
+var knowledgeBaseFields = []struct {
+	key   string
+	value func(pm *domain.PostMortem) string
+}{
+	{"root_cause", func(pm *domain.PostMortem) string { return pm.RootCause }},
+	{"lessons_learned", func(pm *domain.PostMortem) string { return pm.LessonsLearned }},
+	{"what_went_wrong", func(pm *domain.PostMortem) string { return pm.WhatWentWrong }},
+}
+
+// Search returns a page of published post-mortems ranked by relevance, with highlighted snippets
+// and facets counted over all matches
+func (u *KnowledgeBaseUsecase) Search(ctx context.Context, query domain.KnowledgeBaseQuery, pagination domain.Pagination) (*domain.KnowledgeBaseResult, error) {
+	if query.Category != "" && !query.Category.IsValid() {
+		return nil, domain.ErrValidation("invalid causal category")
+	}
+	if query.Severity != "" && !isValidSeverity(query.Severity) {
+		return nil, domain.ErrValidation("invalid severity")
+	}
+	if pagination.Page < 1 {
+		pagination.Page = 1
+	}
+	if pagination.Limit < 1 || pagination.Limit > 100 {
+		pagination.Limit = 20
+	}
+
+	matches, total, err := u.knowledgeBaseRepo.Search(ctx, query, pagination)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to search post-mortems", err)
+	}
+	facets, err := u.knowledgeBaseRepo.Facets(ctx, query)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to count post-mortem facets", err)
+	}
+	hits, err := u.buildHits(ctx, matches, query.Terms())
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.KnowledgeBaseResult{
+		Hits:   hits,
+		Facets: *facets,
+		Pagination: &domain.PaginationResult{
+			Page:       pagination.Page,
+			Limit:      pagination.Limit,
+			Total:      total,
+			TotalPages: int((total + int64(pagination.Limit) - 1) / int64(pagination.Limit)),
+		},
+	}, nil
+}
+
+// FindSimilar suggests published post-mortems of past incidents that resemble the incident,
+// by the words of its title and description, its service and its tags
+func (u *KnowledgeBaseUsecase) FindSimilar(ctx context.Context, incident *domain.Incident) ([]domain.KnowledgeBaseHit, error) {
+	terms := domain.SimilarityTerms(incident.Title + " " + incident.Description)
+
+	tagIDs := make([]uint, 0, len(incident.Tags))
+	for _, tag := range incident.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	matches, err := u.knowledgeBaseRepo.FindSimilar(ctx, terms, incident.Service, tagIDs, []uint{incident.ID}, domain.MaxSimilarPostMortems)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to find similar post-mortems", err)
+	}
+	return u.buildHits(ctx, matches, terms)
+}
+
+// FindSimilarForIncident suggests similar past post-mortems for an existing incident
+func (u *KnowledgeBaseUsecase) FindSimilarForIncident(ctx context.Context, incidentID uint) ([]domain.KnowledgeBaseHit, error) {
+	incident, err := u.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+	return u.FindSimilar(ctx, incident)
+}
+
+// buildHits loads the matched post-mortems in ranking order and highlights the terms
+func (u *KnowledgeBaseUsecase) buildHits(ctx context.Context, matches []domain.KnowledgeBaseMatch, terms []string) ([]domain.KnowledgeBaseHit, error) {
+	hits := make([]domain.KnowledgeBaseHit, 0, len(matches))
+	if len(matches) == 0 {
+		return hits, nil
+	}
+
+	ids := make([]uint, len(matches))
+	for i, match := range matches {
+		ids[i] = match.PostMortemID
+	}
+	postMortems, err := u.knowledgeBaseRepo.FindPostMortems(ctx, ids)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get post-mortems", err)
+	}
+	categories, err := u.knowledgeBaseRepo.FindCausalCategories(ctx, ids)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get causal categories", err)
+	}
+	byID := make(map[uint]*domain.PostMortem, len(postMortems))
+	for _, pm := range postMortems {
+		byID[pm.ID] = pm
+	}
+
+	for _, match := range matches {
+		pm, ok := byID[match.PostMortemID]
+		if !ok {
+			continue
+		}
+		hit := domain.KnowledgeBaseHit{
+			PostMortemID:     pm.ID,
+			IncidentID:       pm.IncidentID,
+			PublishedAt:      pm.PublishedAt,
+			Score:            match.Score,
+			Tags:             []domain.Tag{},
+			CausalCategories: categories[pm.ID],
+			Highlights:       []domain.KnowledgeBaseHighlight{},
+		}
+		if hit.CausalCategories == nil {
+			hit.CausalCategories = []domain.CausalCategory{}
+		}
+		if pm.Incident != nil {
+			hit.IncidentTitle = pm.Incident.Title
+			hit.Severity = pm.Incident.Severity
+			hit.Service = pm.Incident.Service
+			hit.Tags = append(hit.Tags, pm.Incident.Tags...)
+		}
+		hit.Highlights = highlightPostMortem(pm, terms)
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+// highlightPostMortem returns a snippet of every searched field containing a term.
+// Without terms (or matches) the start of the first filled field is shown instead.
+func highlightPostMortem(pm *domain.PostMortem, terms []string) []domain.KnowledgeBaseHighlight {
+	highlights := []domain.KnowledgeBaseHighlight{}
+	if len(terms) > 0 {
+		for _, field := range knowledgeBaseFields {
+			if snippet, ok := domain.HighlightSnippet(field.value(pm), terms, domain.KnowledgeBaseSnippetLen); ok {
+				highlights = append(highlights, domain.KnowledgeBaseHighlight{Field: field.key, Snippet: snippet})
+			}
+		}
+		if len(highlights) > 0 {
+			return highlights
+		}
+	}
+
+	for _, field := range knowledgeBaseFields {
+		text := strings.TrimSpace(field.value(pm))
+		if text == "" {
+			continue
+		}
+		text = strings.Join(strings.Fields(text), " ")
+		if runes := []rune(text); len(runes) > domain.KnowledgeBaseSnippetLen {
+			text = string(runes[:domain.KnowledgeBaseSnippetLen]) + "…"
+		}
+		highlights = append(highlights, domain.KnowledgeBaseHighlight{Field: field.key, Snippet: html.EscapeString(text)})
+		break
+	}
+	return highlights
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"incidex/internal/domain"
+)
+
+// NotificationDeliveryUsecase reads the log of notification delivery attempts
+type NotificationDeliveryUsecase struct {
+	deliveryRepo domain.NotificationDeliveryRepository
+	incidentRepo domain.IncidentRepository
+}
+
+func NewNotificationDeliveryUsecase(deliveryRepo domain.NotificationDeliveryRepository, incidentRepo domain.IncidentRepository) *NotificationDeliveryUsecase {
+	return &NotificationDeliveryUsecase{
+		deliveryRepo: deliveryRepo,
+		incidentRepo: incidentRepo,
+	}
+}
+
+// Search returns a page of delivery attempts matching the filters, newest first
+func (u *NotificationDeliveryUsecase) Search(ctx context.Context, filters domain.NotificationDeliveryFilters, pagination domain.Pagination) ([]*domain.NotificationDelivery, *domain.PaginationResult, error) {
+	if filters.Channel != "" && !filters.Channel.IsValid() {
+		return nil, nil, domain.ErrValidation("invalid channel")
+	}
+	if filters.Result != "" && !filters.Result.IsValid() {
+		return nil, nil, domain.ErrValidation("invalid result")
+	}
+	if filters.StartDate != nil && filters.EndDate != nil && filters.EndDate.Before(*filters.StartDate) {
+		return nil, nil, domain.ErrValidation("end_date must not be before start_date")
+	}
+	if pagination.Page < 1 {
+		pagination.Page = 1
+	}
+	if pagination.Limit < 1 || pagination.Limit > 200 {
+		pagination.Limit = 50
+	}
+
+	deliveries, total, err := u.deliveryRepo.FindAll(ctx, filters, pagination)
+	if err != nil {
+		return nil, nil, domain.ErrDatabase("Failed to search notification deliveries", err)
+	}
+	return deliveries, &domain.PaginationResult{
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(pagination.Limit) - 1) / int64(pagination.Limit)),
+	}, nil
+}
+
+// ListForIncident returns a page of the delivery attempts of notifications about an incident
+func (u *NotificationDeliveryUsecase) ListForIncident(ctx context.Context, incidentID uint, pagination domain.Pagination) ([]*domain.NotificationDelivery, *domain.PaginationResult, error) {
+	if _, err := u.incidentRepo.FindByID(ctx, incidentID); err != nil {
+		return nil, nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+	return u.Search(ctx, domain.NotificationDeliveryFilters{IncidentID: &incidentID}, pagination)
+}
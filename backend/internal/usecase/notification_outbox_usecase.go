@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"incidex/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationOutboxUsecase lets admins inspect queued notifications and replay dead-lettered ones
+type NotificationOutboxUsecase struct {
+	outboxRepo domain.NotificationOutboxRepository
+}
+
+func NewNotificationOutboxUsecase(outboxRepo domain.NotificationOutboxRepository) *NotificationOutboxUsecase {
+	return &NotificationOutboxUsecase{outboxRepo: outboxRepo}
+}
+
+// List returns a page of queued notifications, newest first
+func (u *NotificationOutboxUsecase) List(ctx context.Context, filters domain.NotificationOutboxFilters, pagination domain.Pagination) ([]*domain.NotificationOutbox, *domain.PaginationResult, error) {
+	if filters.Status != "" && !filters.Status.IsValid() {
+		return nil, nil, domain.ErrValidation("invalid status")
+	}
+	if filters.Channel != "" && !filters.Channel.IsValid() {
+		return nil, nil, domain.ErrValidation("invalid channel")
+	}
+	if pagination.Page < 1 {
+		pagination.Page = 1
+	}
+	if pagination.Limit < 1 || pagination.Limit > 100 {
+		pagination.Limit = 50
+	}
+
+	messages, total, err := u.outboxRepo.FindAll(ctx, filters, pagination)
+	if err != nil {
+		return nil, nil, domain.ErrDatabase("Failed to get queued notifications", err)
+	}
+	return messages, &domain.PaginationResult{
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(pagination.Limit) - 1) / int64(pagination.Limit)),
+	}, nil
+}
+
+func (u *NotificationOutboxUsecase) Get(ctx context.Context, id uint) (*domain.NotificationOutbox, error) {
+	message, err := u.outboxRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("Notification").WithError(err)
+		}
+		return nil, domain.ErrDatabase("Failed to get queued notification", err)
+	}
+	return message, nil
+}
+
+// Replay queues a dead-lettered notification again with a fresh set of attempts
+func (u *NotificationOutboxUsecase) Replay(ctx context.Context, id uint) (*domain.NotificationOutbox, error) {
+	message, err := u.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if message.Status != domain.OutboxDead {
+		return nil, domain.ErrConflict("only dead-lettered notifications can be replayed")
+	}
+
+	message.Replay(time.Now())
+	if err := u.outboxRepo.Update(ctx, message); err != nil {
+		return nil, domain.ErrDatabase("Failed to replay notification", err)
+	}
+	return message, nil
+}
+
+// ReplayAll queues every dead-lettered notification (of one channel, when given) again and returns how many there were
+func (u *NotificationOutboxUsecase) ReplayAll(ctx context.Context, channel domain.NotificationChannel) (int64, error) {
+	if channel != "" && !channel.IsValid() {
+		return 0, domain.ErrValidation("invalid channel")
+	}
+	count, err := u.outboxRepo.ReplayDead(ctx, channel, time.Now())
+	if err != nil {
+		return 0, domain.ErrDatabase("Failed to replay notifications", err)
+	}
+	return count, nil
+}
@@ -1,39 +1,45 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"incidex/internal/domain"
+	"strings"
+
+	"gorm.io/gorm"
 )
 
-// NotificationUsecase は通知設定のユースケース
+// NotificationUsecase は通知設定と通知先のユースケース
 type NotificationUsecase struct {
 	notificationRepo domain.NotificationSettingRepository
+	endpointRepo     domain.NotificationEndpointRepository
 }
 
 // NewNotificationUsecase は新しい通知設定ユースケースを作成します
-func NewNotificationUsecase(notificationRepo domain.NotificationSettingRepository) *NotificationUsecase {
+func NewNotificationUsecase(notificationRepo domain.NotificationSettingRepository, endpointRepo domain.NotificationEndpointRepository) *NotificationUsecase {
 	return &NotificationUsecase{
 		notificationRepo: notificationRepo,
+		endpointRepo:     endpointRepo,
 	}
 }
 
+// NotificationEndpointInput is the editable content of a notification endpoint
+type NotificationEndpointInput struct {
+	Channel domain.NotificationChannel
+	Name    string
+	Target  string
+	Enabled bool
+}
+
 // GetSettingByUserID はユーザーIDで通知設定を取得します
 func (u *NotificationUsecase) GetSettingByUserID(userID uint) (*domain.NotificationSetting, error) {
 	setting, err := u.notificationRepo.GetByUserID(userID)
 	if err != nil {
 		// 設定がない場合はデフォルト設定を返す
-		return &domain.NotificationSetting{
-			UserID:                        userID,
-			EmailEnabled:                  true,
-			SlackEnabled:                  false,
-			NotifyOnIncidentCreated:       true,
-			NotifyOnAssigned:              true,
-			NotifyOnComment:               true,
-			NotifyOnStatusChange:          true,
-			NotifyOnSeverityChange:        true,
-			NotifyOnResolved:              true,
-			NotifyOnEscalation:            true,
-		}, nil
+		setting = domain.DefaultNotificationSetting(userID)
+	}
+	if err := u.fillLegacySlack(context.Background(), setting); err != nil {
+		return nil, err
 	}
 	return setting, nil
 }
@@ -43,6 +49,9 @@ func (u *NotificationUsecase) CreateSetting(setting *domain.NotificationSetting)
 	if setting.UserID == 0 {
 		return errors.New("user_id is required")
 	}
+	if err := setting.ValidateSchedule(); err != nil {
+		return err
+	}
 
 	// 既存の設定があるかチェック
 	existing, _ := u.notificationRepo.GetByUserID(setting.UserID)
@@ -58,6 +67,12 @@ func (u *NotificationUsecase) UpdateSetting(userID uint, setting *domain.Notific
 	if userID == 0 {
 		return errors.New("user_id is required")
 	}
+	if err := setting.ValidateSchedule(); err != nil {
+		return err
+	}
+	if err := u.applyLegacySlack(context.Background(), userID, setting.SlackEnabled, setting.SlackWebhook); err != nil {
+		return err
+	}
 
 	// 既存の設定を取得
 	existing, err := u.notificationRepo.GetByUserID(userID)
@@ -67,9 +82,10 @@ func (u *NotificationUsecase) UpdateSetting(userID uint, setting *domain.Notific
 		return u.notificationRepo.Create(setting)
 	}
 
-	// IDとUserIDを保持して更新
+	// IDとUserID、ダイジェストの送信日時を保持して更新
 	setting.ID = existing.ID
 	setting.UserID = userID
+	setting.LastDigestSentAt = existing.LastDigestSentAt
 
 	return u.notificationRepo.Update(setting)
 }
@@ -78,3 +94,153 @@ func (u *NotificationUsecase) UpdateSetting(userID uint, setting *domain.Notific
 func (u *NotificationUsecase) DeleteSetting(userID uint) error {
 	return u.notificationRepo.Delete(userID)
 }
+
+// ListEndpoints はユーザーの通知先を登録順に返します
+func (u *NotificationUsecase) ListEndpoints(ctx context.Context, userID uint) ([]*domain.NotificationEndpoint, error) {
+	endpoints, err := u.endpointRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get notification endpoints", err)
+	}
+	return endpoints, nil
+}
+
+// CreateEndpoint はユーザーの通知先を登録します
+func (u *NotificationUsecase) CreateEndpoint(ctx context.Context, userID uint, input NotificationEndpointInput) (*domain.NotificationEndpoint, error) {
+	endpoint := &domain.NotificationEndpoint{UserID: userID}
+	input.apply(endpoint)
+	if err := endpoint.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.endpointRepo.Create(ctx, endpoint); err != nil {
+		return nil, domain.ErrDatabase("Failed to create notification endpoint", err)
+	}
+	return endpoint, nil
+}
+
+// UpdateEndpoint はユーザーの通知先を更新します
+func (u *NotificationUsecase) UpdateEndpoint(ctx context.Context, userID, id uint, input NotificationEndpointInput) (*domain.NotificationEndpoint, error) {
+	endpoint, err := u.getOwnEndpoint(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	input.apply(endpoint)
+	if err := endpoint.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.endpointRepo.Update(ctx, endpoint); err != nil {
+		return nil, domain.ErrDatabase("Failed to update notification endpoint", err)
+	}
+	return endpoint, nil
+}
+
+// DeleteEndpoint はユーザーの通知先を削除します
+func (u *NotificationUsecase) DeleteEndpoint(ctx context.Context, userID, id uint) error {
+	if _, err := u.getOwnEndpoint(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := u.endpointRepo.Delete(ctx, id); err != nil {
+		return domain.ErrDatabase("Failed to delete notification endpoint", err)
+	}
+	return nil
+}
+
+func (i NotificationEndpointInput) apply(endpoint *domain.NotificationEndpoint) {
+	endpoint.Channel = i.Channel
+	endpoint.Name = strings.TrimSpace(i.Name)
+	endpoint.Target = i.Target
+	endpoint.Enabled = i.Enabled
+}
+
+// getOwnEndpoint returns an endpoint of the user; other users' endpoints are reported as not found
+func (u *NotificationUsecase) getOwnEndpoint(ctx context.Context, userID, id uint) (*domain.NotificationEndpoint, error) {
+	endpoint, err := u.endpointRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("Notification endpoint").WithError(err)
+		}
+		return nil, domain.ErrDatabase("Failed to get notification endpoint", err)
+	}
+	if endpoint.UserID != userID {
+		return nil, domain.ErrNotFound("Notification endpoint")
+	}
+	return endpoint, nil
+}
+
+// firstSlackEndpoint returns the user's first Slack endpoint, which the deprecated
+// slack_enabled / slack_webhook fields of the notification setting stand for
+func (u *NotificationUsecase) firstSlackEndpoint(ctx context.Context, userID uint) (*domain.NotificationEndpoint, error) {
+	endpoints, err := u.endpointRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get notification endpoints", err)
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.Channel == domain.ChannelSlack {
+			return endpoint, nil
+		}
+	}
+	return nil, nil
+}
+
+// fillLegacySlack sets the deprecated Slack fields of the setting from the user's first Slack endpoint
+func (u *NotificationUsecase) fillLegacySlack(ctx context.Context, setting *domain.NotificationSetting) error {
+	endpoint, err := u.firstSlackEndpoint(ctx, setting.UserID)
+	if err != nil {
+		return err
+	}
+	enabled, webhook := false, ""
+	if endpoint != nil {
+		enabled, webhook = endpoint.Enabled, endpoint.Target
+	}
+	setting.SlackEnabled = &enabled
+	setting.SlackWebhook = &webhook
+	return nil
+}
+
+// applyLegacySlack saves the deprecated Slack fields of a setting update to the user's first Slack endpoint
+// An empty webhook removes the endpoint, as it turned Slack notifications off before endpoints existed
+func (u *NotificationUsecase) applyLegacySlack(ctx context.Context, userID uint, enabled *bool, webhook *string) error {
+	if enabled == nil && webhook == nil {
+		return nil
+	}
+	endpoint, err := u.firstSlackEndpoint(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if webhook != nil && strings.TrimSpace(*webhook) == "" {
+		if endpoint == nil {
+			return nil
+		}
+		if err := u.endpointRepo.Delete(ctx, endpoint.ID); err != nil {
+			return domain.ErrDatabase("Failed to delete notification endpoint", err)
+		}
+		return nil
+	}
+	if endpoint == nil {
+		if webhook == nil {
+			return nil
+		}
+		endpoint = &domain.NotificationEndpoint{UserID: userID, Channel: domain.ChannelSlack, Name: "Slack", Enabled: true}
+	}
+	if webhook != nil {
+		endpoint.Target = *webhook
+	}
+	if enabled != nil {
+		endpoint.Enabled = *enabled
+	}
+	if err := endpoint.Validate(); err != nil {
+		return err
+	}
+
+	if endpoint.ID == 0 {
+		err = u.endpointRepo.Create(ctx, endpoint)
+	} else {
+		err = u.endpointRepo.Update(ctx, endpoint)
+	}
+	if err != nil {
+		return domain.ErrDatabase("Failed to save notification endpoint", err)
+	}
+	return nil
+}
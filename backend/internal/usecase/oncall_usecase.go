@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"time"
+	// Embed the timezone database so schedule timezones resolve in minimal containers
+	_ "time/tzdata"
+
+	"gorm.io/gorm"
+)
+
+type OnCallUsecase interface {
+	CreateSchedule(ctx context.Context, creatorID uint, name, description, timezone string, autoAssign bool, severity domain.Severity, tagID *uint, service string, layers []domain.OnCallLayer) (*domain.OnCallSchedule, error)
+	GetAllSchedules(ctx context.Context) ([]*domain.OnCallSchedule, error)
+	GetScheduleByID(ctx context.Context, id uint) (*domain.OnCallSchedule, error)
+	UpdateSchedule(ctx context.Context, id uint, name, description, timezone string, autoAssign bool, severity domain.Severity, tagID *uint, service string, layers []domain.OnCallLayer) (*domain.OnCallSchedule, error)
+	DeleteSchedule(ctx context.Context, id uint) error
+	CreateOverride(ctx context.Context, creatorID, scheduleID, userID uint, startAt, endAt time.Time, reason string) (*domain.OnCallOverride, error)
+	GetOverrides(ctx context.Context, scheduleID uint, from, to time.Time) ([]domain.OnCallOverride, error)
+	DeleteOverride(ctx context.Context, scheduleID, overrideID uint) error
+	WhoIsOnCall(ctx context.Context, scheduleID uint, at time.Time) (*domain.OnCallShift, error)
+	GetShifts(ctx context.Context, scheduleID uint, from, to time.Time) ([]domain.OnCallShift, error)
+	GetUserShifts(ctx context.Context, userID uint, from, to time.Time) ([]domain.OnCallShift, error)
+	FindAssignee(ctx context.Context, incident *domain.Incident, at time.Time) (*uint, error)
+}
+
+type onCallUsecase struct {
+	scheduleRepo domain.OnCallScheduleRepository
+	overrideRepo domain.OnCallOverrideRepository
+	tagRepo      domain.TagRepository
+	userRepo     domain.UserRepository
+}
+
+func NewOnCallUsecase(
+	scheduleRepo domain.OnCallScheduleRepository,
+	overrideRepo domain.OnCallOverrideRepository,
+	tagRepo domain.TagRepository,
+	userRepo domain.UserRepository,
+) OnCallUsecase {
+	return &onCallUsecase{
+		scheduleRepo: scheduleRepo,
+		overrideRepo: overrideRepo,
+		tagRepo:      tagRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (u *onCallUsecase) CreateSchedule(ctx context.Context, creatorID uint, name, description, timezone string, autoAssign bool, severity domain.Severity, tagID *uint, service string, layers []domain.OnCallLayer) (*domain.OnCallSchedule, error) {
+	if err := u.validateSchedule(ctx, name, timezone, severity, tagID, layers); err != nil {
+		return nil, err
+	}
+
+	schedule := &domain.OnCallSchedule{
+		Name:        name,
+		Description: description,
+		Timezone:    timezone,
+		AutoAssign:  autoAssign,
+		Severity:    severity,
+		TagID:       tagID,
+		Service:     service,
+		CreatorID:   creatorID,
+		Layers:      normalizeLayers(layers),
+	}
+
+	if err := u.scheduleRepo.Create(ctx, schedule); err != nil {
+		return nil, domain.ErrDatabase("Failed to create on-call schedule", err)
+	}
+
+	return u.scheduleRepo.FindByID(ctx, schedule.ID)
+}
+
+func (u *onCallUsecase) GetAllSchedules(ctx context.Context) ([]*domain.OnCallSchedule, error) {
+	return u.scheduleRepo.FindAll(ctx)
+}
+
+func (u *onCallUsecase) GetScheduleByID(ctx context.Context, id uint) (*domain.OnCallSchedule, error) {
+	schedule, err := u.scheduleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("On-call schedule").WithError(err)
+	}
+	return schedule, nil
+}
+
+func (u *onCallUsecase) UpdateSchedule(ctx context.Context, id uint, name, description, timezone string, autoAssign bool, severity domain.Severity, tagID *uint, service string, layers []domain.OnCallLayer) (*domain.OnCallSchedule, error) {
+	schedule, err := u.scheduleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("On-call schedule").WithError(err)
+	}
+
+	if err := u.validateSchedule(ctx, name, timezone, severity, tagID, layers); err != nil {
+		return nil, err
+	}
+
+	schedule.Name = name
+	schedule.Description = description
+	schedule.Timezone = timezone
+	schedule.AutoAssign = autoAssign
+	schedule.Severity = severity
+	schedule.TagID = tagID
+	schedule.Service = service
+	schedule.Layers = normalizeLayers(layers)
+
+	if err := u.scheduleRepo.Update(ctx, schedule); err != nil {
+		return nil, domain.ErrDatabase("Failed to update on-call schedule", err)
+	}
+
+	return u.scheduleRepo.FindByID(ctx, schedule.ID)
+}
+
+func (u *onCallUsecase) DeleteSchedule(ctx context.Context, id uint) error {
+	if _, err := u.scheduleRepo.FindByID(ctx, id); err != nil {
+		return domain.ErrNotFound("On-call schedule").WithError(err)
+	}
+	if err := u.scheduleRepo.Delete(ctx, id); err != nil {
+		return domain.ErrDatabase("Failed to delete on-call schedule", err)
+	}
+	return nil
+}
+
+func (u *onCallUsecase) CreateOverride(ctx context.Context, creatorID, scheduleID, userID uint, startAt, endAt time.Time, reason string) (*domain.OnCallOverride, error) {
+	if _, err := u.scheduleRepo.FindByID(ctx, scheduleID); err != nil {
+		return nil, domain.ErrNotFound("On-call schedule").WithError(err)
+	}
+	if _, err := u.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, domain.ErrValidation(fmt.Sprintf("User with ID %d not found", userID))
+	}
+	if !endAt.After(startAt) {
+		return nil, domain.ErrValidation("end_at must be after start_at")
+	}
+
+	override := &domain.OnCallOverride{
+		ScheduleID: scheduleID,
+		UserID:     userID,
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Reason:     reason,
+		CreatorID:  creatorID,
+	}
+	if err := u.overrideRepo.Create(ctx, override); err != nil {
+		return nil, domain.ErrDatabase("Failed to create on-call override", err)
+	}
+
+	return u.overrideRepo.FindByID(ctx, override.ID)
+}
+
+func (u *onCallUsecase) GetOverrides(ctx context.Context, scheduleID uint, from, to time.Time) ([]domain.OnCallOverride, error) {
+	if _, err := u.scheduleRepo.FindByID(ctx, scheduleID); err != nil {
+		return nil, domain.ErrNotFound("On-call schedule").WithError(err)
+	}
+	return u.overrideRepo.FindByScheduleID(ctx, scheduleID, from, to)
+}
+
+func (u *onCallUsecase) DeleteOverride(ctx context.Context, scheduleID, overrideID uint) error {
+	override, err := u.overrideRepo.FindByID(ctx, overrideID)
+	if err != nil || override.ScheduleID != scheduleID {
+		return domain.ErrNotFound("On-call override")
+	}
+	if err := u.overrideRepo.Delete(ctx, overrideID); err != nil {
+		return domain.ErrDatabase("Failed to delete on-call override", err)
+	}
+	return nil
+}
+
+// WhoIsOnCall answers "who is on call for this schedule at this time".
+func (u *onCallUsecase) WhoIsOnCall(ctx context.Context, scheduleID uint, at time.Time) (*domain.OnCallShift, error) {
+	schedule, err := u.scheduleRepo.FindByID(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("On-call schedule")
+		}
+		return nil, domain.ErrDatabase("Failed to get on-call schedule", err)
+	}
+
+	shift, err := u.onCallAt(ctx, schedule, at)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, domain.ErrNotFound("On-call user")
+	}
+	return shift, nil
+}
+
+// GetShifts returns the effective shifts of a schedule overlapping the given period.
+func (u *onCallUsecase) GetShifts(ctx context.Context, scheduleID uint, from, to time.Time) ([]domain.OnCallShift, error) {
+	if !to.After(from) {
+		return nil, domain.ErrValidation("to must be after from")
+	}
+	schedule, err := u.scheduleRepo.FindByID(ctx, scheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound("On-call schedule")
+		}
+		return nil, domain.ErrDatabase("Failed to get on-call schedule", err)
+	}
+	return u.shiftsBetween(ctx, schedule, from, to)
+}
+
+// GetUserShifts returns the user's shifts across all schedules overlapping the given period.
+func (u *onCallUsecase) GetUserShifts(ctx context.Context, userID uint, from, to time.Time) ([]domain.OnCallShift, error) {
+	if !to.After(from) {
+		return nil, domain.ErrValidation("to must be after from")
+	}
+	schedules, err := u.scheduleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get on-call schedules", err)
+	}
+
+	var result []domain.OnCallShift
+	for _, schedule := range schedules {
+		shifts, err := u.shiftsBetween(ctx, schedule, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, shift := range shifts {
+			if shift.UserID == userID {
+				result = append(result, shift)
+			}
+		}
+	}
+	return result, nil
+}
+
+// FindAssignee returns the current on-call user of the most specific auto-assign schedule
+// matching the incident, or nil if no schedule applies or nobody is on call.
+func (u *onCallUsecase) FindAssignee(ctx context.Context, incident *domain.Incident, at time.Time) (*uint, error) {
+	schedules, err := u.scheduleRepo.FindAutoAssign(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load on-call schedules: %w", err)
+	}
+
+	var selected *domain.OnCallSchedule
+	for _, schedule := range schedules {
+		if !schedule.Matches(incident) {
+			continue
+		}
+		if selected == nil || schedule.Specificity() > selected.Specificity() {
+			selected = schedule
+		}
+	}
+	if selected == nil {
+		return nil, nil
+	}
+
+	shift, err := u.onCallAt(ctx, selected, at)
+	if err != nil || shift == nil {
+		return nil, err
+	}
+	return &shift.UserID, nil
+}
+
+func (u *onCallUsecase) onCallAt(ctx context.Context, schedule *domain.OnCallSchedule, at time.Time) (*domain.OnCallShift, error) {
+	overrides, err := u.overrideRepo.FindByScheduleID(ctx, schedule.ID, at, at)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get on-call overrides", err)
+	}
+	return schedule.OnCallAt(at, overrides), nil
+}
+
+func (u *onCallUsecase) shiftsBetween(ctx context.Context, schedule *domain.OnCallSchedule, from, to time.Time) ([]domain.OnCallShift, error) {
+	// Shifts at the edges are reported in full, so load overrides for up to a weekly rotation beyond the period
+	margin := 8 * 24 * time.Hour
+	overrides, err := u.overrideRepo.FindByScheduleID(ctx, schedule.ID, from.Add(-margin), to.Add(margin))
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get on-call overrides", err)
+	}
+	return schedule.ShiftsBetween(from, to, overrides), nil
+}
+
+func (u *onCallUsecase) validateSchedule(ctx context.Context, name, timezone string, severity domain.Severity, tagID *uint, layers []domain.OnCallLayer) error {
+	if name == "" {
+		return domain.ErrValidation("Schedule name is required")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return domain.ErrValidation(fmt.Sprintf("Invalid timezone: %s", timezone))
+	}
+	if severity != "" && !isValidSeverity(severity) {
+		return domain.ErrValidation("Invalid severity")
+	}
+	if tagID != nil {
+		if _, err := u.tagRepo.FindByID(ctx, *tagID); err != nil {
+			return domain.ErrValidation(fmt.Sprintf("Tag with ID %d not found", *tagID))
+		}
+	}
+	if len(layers) == 0 {
+		return domain.ErrValidation("At least one layer is required")
+	}
+	for i, layer := range layers {
+		if layer.RotationType != domain.RotationDaily && layer.RotationType != domain.RotationWeekly {
+			return domain.ErrValidation(fmt.Sprintf("Layer %d: invalid rotation type", i+1))
+		}
+		if _, err := time.Parse("2006-01-02", layer.StartDate); err != nil {
+			return domain.ErrValidation(fmt.Sprintf("Layer %d: invalid start_date (expected YYYY-MM-DD)", i+1))
+		}
+		if _, err := time.Parse("15:04", layer.HandoffTime); err != nil {
+			return domain.ErrValidation(fmt.Sprintf("Layer %d: invalid handoff_time (expected HH:MM)", i+1))
+		}
+		if len(layer.Participants) == 0 {
+			return domain.ErrValidation(fmt.Sprintf("Layer %d: at least one participant is required", i+1))
+		}
+		for _, participant := range layer.Participants {
+			if _, err := u.userRepo.FindByID(ctx, participant.UserID); err != nil {
+				return domain.ErrValidation(fmt.Sprintf("Layer %d: user with ID %d not found", i+1, participant.UserID))
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeLayers orders layers and participants by their position in the request.
+// Later layers take precedence over earlier ones.
+func normalizeLayers(layers []domain.OnCallLayer) []domain.OnCallLayer {
+	normalized := make([]domain.OnCallLayer, len(layers))
+	for i, layer := range layers {
+		normalized[i] = domain.OnCallLayer{
+			Name:         layer.Name,
+			LayerOrder:   i + 1,
+			RotationType: layer.RotationType,
+			StartDate:    layer.StartDate,
+			HandoffTime:  layer.HandoffTime,
+			Participants: make([]domain.OnCallParticipant, len(layer.Participants)),
+		}
+		for j, participant := range layer.Participants {
+			normalized[i].Participants[j] = domain.OnCallParticipant{
+				UserID:   participant.UserID,
+				Position: j + 1,
+			}
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,440 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/infrastructure/notification"
+	"incidex/internal/pkg/logger"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PostMortemPolicyInput is the editable content of a mandatory post-mortem policy
+type PostMortemPolicyInput struct {
+	Name                 string
+	Description          string
+	IsActive             bool
+	Severity             domain.Severity
+	TagID                *uint
+	DueBusinessDays      int
+	ReminderBusinessDays int
+}
+
+// PostMortemRequirementUsecase enforces mandatory post-mortems: policies decide which resolved
+// incidents need one, reminders and overdue notices go to the assignee, and closing the incident
+// is blocked until the post-mortem is published or an admin waives the requirement.
+type PostMortemRequirementUsecase struct {
+	policyRepo          domain.PostMortemPolicyRepository
+	requirementRepo     domain.PostMortemRequirementRepository
+	postMortemRepo      domain.PostMortemRepository
+	incidentRepo        domain.IncidentRepository
+	tagRepo             domain.TagRepository
+	userRepo            domain.UserRepository
+	notificationService *notification.NotificationService
+	// Location in which business days and compliance months are counted
+	loc *time.Location
+}
+
+func NewPostMortemRequirementUsecase(
+	policyRepo domain.PostMortemPolicyRepository,
+	requirementRepo domain.PostMortemRequirementRepository,
+	postMortemRepo domain.PostMortemRepository,
+	incidentRepo domain.IncidentRepository,
+	tagRepo domain.TagRepository,
+	userRepo domain.UserRepository,
+	notificationService *notification.NotificationService,
+	loc *time.Location,
+) *PostMortemRequirementUsecase {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &PostMortemRequirementUsecase{
+		policyRepo:          policyRepo,
+		requirementRepo:     requirementRepo,
+		postMortemRepo:      postMortemRepo,
+		incidentRepo:        incidentRepo,
+		tagRepo:             tagRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		loc:                 loc,
+	}
+}
+
+// CreatePolicy creates a mandatory post-mortem policy
+func (u *PostMortemRequirementUsecase) CreatePolicy(ctx context.Context, userID uint, input PostMortemPolicyInput) (*domain.PostMortemPolicy, error) {
+	policy := &domain.PostMortemPolicy{CreatorID: userID}
+	if err := u.applyPolicyInput(ctx, policy, input); err != nil {
+		return nil, err
+	}
+
+	if err := u.policyRepo.Create(ctx, policy); err != nil {
+		return nil, domain.ErrDatabase("Failed to create post-mortem policy", err)
+	}
+	return u.GetPolicyByID(ctx, policy.ID)
+}
+
+// GetAllPolicies retrieves all mandatory post-mortem policies
+func (u *PostMortemRequirementUsecase) GetAllPolicies(ctx context.Context) ([]*domain.PostMortemPolicy, error) {
+	policies, err := u.policyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get post-mortem policies", err)
+	}
+	return policies, nil
+}
+
+// GetPolicyByID retrieves a mandatory post-mortem policy
+func (u *PostMortemRequirementUsecase) GetPolicyByID(ctx context.Context, id uint) (*domain.PostMortemPolicy, error) {
+	policy, err := u.policyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Post-mortem policy").WithError(err)
+	}
+	return policy, nil
+}
+
+// UpdatePolicy updates a mandatory post-mortem policy. Due dates already set are kept.
+func (u *PostMortemRequirementUsecase) UpdatePolicy(ctx context.Context, id uint, input PostMortemPolicyInput) (*domain.PostMortemPolicy, error) {
+	policy, err := u.GetPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.applyPolicyInput(ctx, policy, input); err != nil {
+		return nil, err
+	}
+
+	if err := u.policyRepo.Update(ctx, policy); err != nil {
+		return nil, domain.ErrDatabase("Failed to update post-mortem policy", err)
+	}
+	return u.GetPolicyByID(ctx, id)
+}
+
+// DeletePolicy deletes a mandatory post-mortem policy; existing requirements stay in force
+func (u *PostMortemRequirementUsecase) DeletePolicy(ctx context.Context, id uint) error {
+	if _, err := u.GetPolicyByID(ctx, id); err != nil {
+		return err
+	}
+	if err := u.policyRepo.Delete(ctx, id); err != nil {
+		return domain.ErrDatabase("Failed to delete post-mortem policy", err)
+	}
+	return nil
+}
+
+func (u *PostMortemRequirementUsecase) applyPolicyInput(ctx context.Context, policy *domain.PostMortemPolicy, input PostMortemPolicyInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.ErrValidation("name is required")
+	}
+	if input.Severity != "" && !isValidSeverity(input.Severity) {
+		return domain.ErrValidation("invalid severity")
+	}
+	if input.TagID != nil {
+		if _, err := u.tagRepo.FindByID(ctx, *input.TagID); err != nil {
+			return domain.ErrNotFound(fmt.Sprintf("Tag with ID %d", *input.TagID))
+		}
+	}
+	if input.DueBusinessDays == 0 {
+		input.DueBusinessDays = domain.DefaultPostMortemDueBusinessDays
+	}
+	if input.DueBusinessDays < 1 || input.DueBusinessDays > domain.MaxPostMortemDueBusinessDays {
+		return domain.ErrValidation(fmt.Sprintf("due_business_days must be between 1 and %d", domain.MaxPostMortemDueBusinessDays))
+	}
+	if input.ReminderBusinessDays < 0 || input.ReminderBusinessDays >= input.DueBusinessDays {
+		return domain.ErrValidation("reminder_business_days must be at least 0 and less than due_business_days")
+	}
+
+	policy.Name = name
+	policy.Description = input.Description
+	policy.IsActive = input.IsActive
+	policy.Severity = input.Severity
+	policy.TagID = input.TagID
+	policy.DueBusinessDays = input.DueBusinessDays
+	policy.ReminderBusinessDays = input.ReminderBusinessDays
+	return nil
+}
+
+// GetRequirement returns the mandatory post-mortem requirement of an incident
+func (u *PostMortemRequirementUsecase) GetRequirement(ctx context.Context, incidentID uint) (*domain.PostMortemRequirement, error) {
+	requirement, err := u.requirementRepo.FindByIncidentID(ctx, incidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Post-mortem requirement").WithError(err)
+	}
+	return requirement, nil
+}
+
+// EvaluateResolution is called when an incident is resolved (or closed without being resolved).
+// It creates the requirement when a policy applies; resolving a reopened incident again moves the
+// due date of a requirement that is still pending.
+func (u *PostMortemRequirementUsecase) EvaluateResolution(ctx context.Context, incident *domain.Incident) error {
+	resolvedAt := time.Now()
+	if incident.ResolvedAt != nil {
+		resolvedAt = *incident.ResolvedAt
+	}
+
+	requirement, err := u.requirementRepo.FindByIncidentID(ctx, incident.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrDatabase("Failed to get post-mortem requirement", err)
+	}
+	if requirement != nil {
+		if requirement.Status != domain.RequirementStatusPending || requirement.PolicyID == nil {
+			return nil
+		}
+		policy, err := u.policyRepo.FindByID(ctx, *requirement.PolicyID)
+		if err != nil {
+			return nil
+		}
+		u.schedule(requirement, policy, resolvedAt)
+		requirement.RemindedAt = nil
+		requirement.EscalatedAt = nil
+		if err := u.requirementRepo.Update(ctx, requirement); err != nil {
+			return domain.ErrDatabase("Failed to update post-mortem requirement", err)
+		}
+		return nil
+	}
+
+	policies, err := u.policyRepo.FindAll(ctx)
+	if err != nil {
+		return domain.ErrDatabase("Failed to get post-mortem policies", err)
+	}
+	policy := domain.SelectPostMortemPolicy(policies, incident)
+	if policy == nil {
+		return nil
+	}
+
+	requirement = &domain.PostMortemRequirement{
+		IncidentID: incident.ID,
+		PolicyID:   &policy.ID,
+		Status:     domain.RequirementStatusPending,
+	}
+	u.schedule(requirement, policy, resolvedAt)
+
+	// The post-mortem may already have been published before the incident was resolved
+	if pm, err := u.postMortemRepo.FindByIncidentID(ctx, incident.ID); err == nil && pm.Status == domain.PMStatusPublished {
+		now := time.Now()
+		requirement.Status = domain.RequirementStatusCompleted
+		requirement.CompletedAt = &now
+	}
+
+	if err := u.requirementRepo.Create(ctx, requirement); err != nil {
+		return domain.ErrDatabase("Failed to create post-mortem requirement", err)
+	}
+	return nil
+}
+
+// schedule sets the due and reminder dates counted in business days from the resolution
+func (u *PostMortemRequirementUsecase) schedule(requirement *domain.PostMortemRequirement, policy *domain.PostMortemPolicy, resolvedAt time.Time) {
+	requirement.ResolvedAt = resolvedAt
+	requirement.DueAt = domain.AddBusinessDays(resolvedAt.In(u.loc), policy.DueBusinessDays)
+	requirement.RemindAt = domain.AddBusinessDays(requirement.DueAt, -policy.ReminderBusinessDays)
+}
+
+// CheckClose returns a conflict error if the incident needs a post-mortem that has not been published yet
+func (u *PostMortemRequirementUsecase) CheckClose(ctx context.Context, incident *domain.Incident) error {
+	requirement, err := u.requirementRepo.FindByIncidentID(ctx, incident.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrDatabase("Failed to get post-mortem requirement", err)
+	}
+	if requirement != nil && requirement.Status != domain.RequirementStatusPending {
+		return nil
+	}
+	if requirement == nil {
+		// Closed without being resolved first: apply the policies now
+		policies, err := u.policyRepo.FindAll(ctx)
+		if err != nil {
+			return domain.ErrDatabase("Failed to get post-mortem policies", err)
+		}
+		if domain.SelectPostMortemPolicy(policies, incident) == nil {
+			return nil
+		}
+	}
+
+	if pm, err := u.postMortemRepo.FindByIncidentID(ctx, incident.ID); err == nil && pm.Status == domain.PMStatusPublished {
+		if requirement != nil {
+			u.complete(ctx, requirement, time.Now())
+		}
+		return nil
+	}
+	return domain.ErrConflict("This incident requires a published post-mortem before it can be closed; an admin can waive the requirement")
+}
+
+// WaiveRequirement lets an admin close the incident without a published post-mortem
+func (u *PostMortemRequirementUsecase) WaiveRequirement(ctx context.Context, userID uint, userRole domain.Role, incidentID uint, reason string) (*domain.PostMortemRequirement, error) {
+	if userRole != domain.RoleAdmin {
+		return nil, domain.ErrForbidden("Only admins can waive a mandatory post-mortem")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domain.ErrValidation("reason is required")
+	}
+
+	incident, err := u.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+
+	requirement, err := u.requirementRepo.FindByIncidentID(ctx, incidentID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrDatabase("Failed to get post-mortem requirement", err)
+	}
+	if requirement == nil {
+		// The incident has not been resolved yet but a policy applies: record the waiver up front
+		policies, err := u.policyRepo.FindAll(ctx)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to get post-mortem policies", err)
+		}
+		policy := domain.SelectPostMortemPolicy(policies, incident)
+		if policy == nil {
+			return nil, domain.ErrValidation("No post-mortem is required for this incident")
+		}
+		requirement = &domain.PostMortemRequirement{IncidentID: incidentID, PolicyID: &policy.ID}
+		u.schedule(requirement, policy, time.Now())
+	} else if requirement.Status != domain.RequirementStatusPending {
+		return nil, domain.ErrValidation(fmt.Sprintf("The post-mortem requirement is already %s", requirement.Status))
+	}
+
+	requirement.Status = domain.RequirementStatusWaived
+	requirement.WaivedByID = &userID
+	requirement.WaiverReason = reason
+	if requirement.ID == 0 {
+		err = u.requirementRepo.Create(ctx, requirement)
+	} else {
+		err = u.requirementRepo.Update(ctx, requirement)
+	}
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to waive post-mortem requirement", err)
+	}
+	return u.GetRequirement(ctx, incidentID)
+}
+
+// MarkPublished completes the requirement of the incident when its post-mortem is published
+func (u *PostMortemRequirementUsecase) MarkPublished(ctx context.Context, incidentID uint, publishedAt time.Time) {
+	requirement, err := u.requirementRepo.FindByIncidentID(ctx, incidentID)
+	if err != nil || requirement.Status != domain.RequirementStatusPending {
+		return
+	}
+	u.complete(ctx, requirement, publishedAt)
+}
+
+func (u *PostMortemRequirementUsecase) complete(ctx context.Context, requirement *domain.PostMortemRequirement, at time.Time) {
+	requirement.Status = domain.RequirementStatusCompleted
+	requirement.CompletedAt = &at
+	if err := u.requirementRepo.Update(ctx, requirement); err != nil {
+		logger.Log.Error("Failed to complete post-mortem requirement", zap.Uint("incident_id", requirement.IncidentID), zap.Error(err))
+	}
+}
+
+// ProcessReminders sends the reminder before the due date and the overdue notice after it.
+// Each is sent once per requirement; overdue notices also go to admins.
+func (u *PostMortemRequirementUsecase) ProcessReminders(ctx context.Context) error {
+	requirements, err := u.requirementRepo.FindPending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load pending post-mortem requirements: %w", err)
+	}
+
+	now := time.Now()
+	for _, requirement := range requirements {
+		if requirement.Incident == nil {
+			continue
+		}
+
+		// Published without going through PublishPostMortem (e.g. before the requirement existed)
+		if pm, err := u.postMortemRepo.FindByIncidentID(ctx, requirement.IncidentID); err == nil && pm.Status == domain.PMStatusPublished {
+			at := now
+			if pm.PublishedAt != nil {
+				at = *pm.PublishedAt
+			}
+			u.complete(ctx, requirement, at)
+			continue
+		}
+
+		switch {
+		case requirement.IsOverdue(now) && requirement.EscalatedAt == nil:
+			u.notifyDue(ctx, requirement, true)
+			requirement.EscalatedAt = &now
+		case !now.Before(requirement.RemindAt) && requirement.RemindedAt == nil && !requirement.IsOverdue(now):
+			u.notifyDue(ctx, requirement, false)
+			requirement.RemindedAt = &now
+		default:
+			continue
+		}
+		if err := u.requirementRepo.Update(ctx, requirement); err != nil {
+			logger.Log.Error("Failed to update post-mortem requirement", zap.Uint("incident_id", requirement.IncidentID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// notifyDue notifies the incident's assignee (or its creator when unassigned); overdue notices also go to admins
+func (u *PostMortemRequirementUsecase) notifyDue(ctx context.Context, requirement *domain.PostMortemRequirement, overdue bool) {
+	if u.notificationService == nil {
+		return
+	}
+	incident := requirement.Incident
+
+	recipientID := incident.CreatorID
+	if incident.AssigneeID != nil {
+		recipientID = *incident.AssigneeID
+	}
+	recipients := []uint{recipientID}
+	if overdue {
+		users, err := u.userRepo.FindAll(ctx)
+		if err != nil {
+			logger.Log.Warn("Failed to get admins for post-mortem escalation", zap.Error(err))
+		}
+		for _, user := range users {
+			if user.Role == domain.RoleAdmin && user.IsActive && user.ID != recipientID {
+				recipients = append(recipients, user.ID)
+			}
+		}
+	}
+
+	dueAt := requirement.DueAt.In(u.loc)
+	for _, userID := range recipients {
+		user, err := u.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			logger.Log.Warn("Post-mortem reminder recipient not found", zap.Uint("user_id", userID), zap.Error(err))
+			continue
+		}
+		if err := u.notificationService.NotifyPostMortemDue(incident, user, dueAt, overdue); err != nil {
+			logger.Log.Error("Failed to send post-mortem reminder", zap.Uint("incident_id", incident.ID), zap.Error(err))
+		}
+	}
+}
+
+// GetComplianceReport counts mandatory post-mortems per month of resolution over the last months (1-24)
+func (u *PostMortemRequirementUsecase) GetComplianceReport(ctx context.Context, months int) (*domain.PostMortemComplianceReport, error) {
+	if months < 1 || months > 24 {
+		return nil, domain.ErrValidation("months must be between 1 and 24")
+	}
+
+	now := time.Now().In(u.loc)
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, u.loc).AddDate(0, -(months - 1), 0)
+
+	requirements, err := u.requirementRepo.FindResolvedBetween(ctx, start, now)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get post-mortem requirements", err)
+	}
+
+	report := &domain.PostMortemComplianceReport{
+		Period: domain.ReportPeriod{StartDate: start, EndDate: now, Month: int(start.Month()), Year: start.Year()},
+		Months: make([]domain.PostMortemComplianceMonth, months),
+		Total:  domain.PostMortemComplianceMonth{Month: "total"},
+	}
+	index := make(map[string]int, months)
+	for i := range report.Months {
+		month := start.AddDate(0, i, 0).Format("2006-01")
+		report.Months[i].Month = month
+		index[month] = i
+	}
+
+	for _, requirement := range requirements {
+		if i, ok := index[requirement.ResolvedAt.In(u.loc).Format("2006-01")]; ok {
+			report.Months[i].Add(requirement, now)
+		}
+		report.Total.Add(requirement, now)
+	}
+	return report, nil
+}
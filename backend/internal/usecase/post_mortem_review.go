@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/pkg/logger"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Maximum length of a review comment
+const maxReviewCommentLength = 5000
+
+// AssignReviewers sets the reviewers of a post-mortem. Reviewers must be editors or admins and
+// cannot be the author. Reviewers added while the post-mortem is in review are notified.
+func (u *postMortemUsecase) AssignReviewers(ctx context.Context, userID uint, userRole domain.Role, id uint, reviewerIDs []uint) (*domain.PostMortem, error) {
+	pm, err := u.findEditablePostMortem(ctx, userID, userRole, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewerIDs) > domain.MaxRequiredApprovals*2 {
+		return nil, domain.ErrValidation(fmt.Sprintf("a post-mortem can have at most %d reviewers", domain.MaxRequiredApprovals*2))
+	}
+
+	ids := make([]uint, 0, len(reviewerIDs))
+	seen := make(map[uint]bool, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		if seen[reviewerID] {
+			continue
+		}
+		seen[reviewerID] = true
+
+		if reviewerID == pm.AuthorID {
+			return nil, domain.ErrValidation("The author cannot review their own post-mortem")
+		}
+		reviewer, err := u.userRepo.FindByID(ctx, reviewerID)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to get reviewer", err)
+		}
+		if reviewer == nil {
+			return nil, domain.ErrNotFound("Reviewer")
+		}
+		if reviewer.Role == domain.RoleViewer {
+			return nil, domain.ErrValidation(fmt.Sprintf("User %d cannot review post-mortems (viewer role)", reviewerID))
+		}
+		ids = append(ids, reviewerID)
+	}
+
+	previous := make(map[uint]bool, len(pm.Reviewers))
+	for _, reviewer := range pm.Reviewers {
+		previous[reviewer.ReviewerID] = true
+	}
+
+	if err := u.reviewRepo.ReplaceReviewers(ctx, id, ids); err != nil {
+		return nil, domain.ErrDatabase("Failed to assign reviewers", err)
+	}
+
+	if pm.Status == domain.PMStatusInReview {
+		var added []uint
+		for _, reviewerID := range ids {
+			if !previous[reviewerID] {
+				added = append(added, reviewerID)
+			}
+		}
+		u.notifyReviewRecipients(ctx, pm, added, domain.ReviewEventSubmitted, userID, "")
+
+		// Removing a reviewer who had not decided yet may complete the approvals
+		if err := u.approveIfComplete(ctx, pm, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.postMortemRepo.FindByID(ctx, id)
+}
+
+// SubmitForReview requests a review of a draft (or a post-mortem with requested changes).
+// Previous decisions are reset and the number of required approvals is fixed at this point.
+func (u *postMortemUsecase) SubmitForReview(ctx context.Context, userID uint, userRole domain.Role, id uint) (*domain.PostMortem, error) {
+	pm, err := u.findEditablePostMortem(ctx, userID, userRole, id)
+	if err != nil {
+		return nil, err
+	}
+	if pm.Status != domain.PMStatusDraft && pm.Status != domain.PMStatusChangesRequested {
+		return nil, domain.ErrValidation(fmt.Sprintf("Post-mortem in %s status cannot be submitted for review", pm.Status))
+	}
+
+	required, err := u.requiredApprovals(ctx, pm)
+	if err != nil {
+		return nil, err
+	}
+	minReviewers := required
+	if minReviewers < 1 {
+		minReviewers = 1
+	}
+	if len(pm.Reviewers) < minReviewers {
+		return nil, domain.ErrValidation(fmt.Sprintf("Assign at least %d reviewer(s) before submitting for review", minReviewers))
+	}
+
+	if err := u.reviewRepo.ResetDecisions(ctx, id); err != nil {
+		return nil, domain.ErrDatabase("Failed to reset review decisions", err)
+	}
+
+	pm.Status = domain.PMStatusInReview
+	pm.RequiredApprovals = required
+	if err := u.postMortemRepo.Update(ctx, pm); err != nil {
+		return nil, err
+	}
+	u.notifyReview(ctx, pm, domain.ReviewEventSubmitted, userID, "")
+
+	return u.postMortemRepo.FindByID(ctx, id)
+}
+
+// ReviewPostMortem records the decision of an assigned reviewer. Requesting changes sends the
+// post-mortem back to the author; it is approved once enough reviewers have approved it.
+func (u *postMortemUsecase) ReviewPostMortem(ctx context.Context, reviewerID uint, id uint, decision domain.ReviewDecision, comment string) (*domain.PostMortem, error) {
+	if decision != domain.ReviewDecisionApproved && decision != domain.ReviewDecisionChangesRequested {
+		return nil, domain.ErrValidation("decision must be approved or changes_requested")
+	}
+	comment = strings.TrimSpace(comment)
+	if decision == domain.ReviewDecisionChangesRequested && comment == "" {
+		return nil, domain.ErrValidation("comment is required when requesting changes")
+	}
+	if len([]rune(comment)) > maxReviewCommentLength {
+		return nil, domain.ErrValidation(fmt.Sprintf("comment must be at most %d characters", maxReviewCommentLength))
+	}
+
+	pm, err := u.findPostMortem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pm.Status != domain.PMStatusInReview {
+		return nil, domain.ErrValidation("Post-mortem is not in review")
+	}
+
+	var reviewer *domain.PostMortemReviewer
+	for i := range pm.Reviewers {
+		if pm.Reviewers[i].ReviewerID == reviewerID {
+			reviewer = &pm.Reviewers[i]
+			break
+		}
+	}
+	if reviewer == nil {
+		return nil, domain.ErrForbidden("You are not a reviewer of this post-mortem")
+	}
+
+	now := time.Now()
+	reviewer.Decision = decision
+	reviewer.Comment = comment
+	reviewer.DecidedAt = &now
+	if err := u.reviewRepo.UpdateReviewer(ctx, reviewer); err != nil {
+		return nil, domain.ErrDatabase("Failed to save review decision", err)
+	}
+
+	if decision == domain.ReviewDecisionChangesRequested {
+		pm.Status = domain.PMStatusChangesRequested
+		if err := u.postMortemRepo.Update(ctx, pm); err != nil {
+			return nil, err
+		}
+		u.notifyReview(ctx, pm, domain.ReviewEventChangesRequested, reviewerID, comment)
+		return u.postMortemRepo.FindByID(ctx, id)
+	}
+
+	u.notifyReview(ctx, pm, domain.ReviewEventReviewerApproved, reviewerID, comment)
+	if err := u.approveIfComplete(ctx, pm, reviewerID); err != nil {
+		return nil, err
+	}
+	return u.postMortemRepo.FindByID(ctx, id)
+}
+
+// GetReviewComments returns the review comments of a post-mortem, oldest first
+func (u *postMortemUsecase) GetReviewComments(ctx context.Context, id uint) ([]domain.PostMortemReviewComment, error) {
+	if _, err := u.findPostMortem(ctx, id); err != nil {
+		return nil, err
+	}
+	comments, err := u.reviewRepo.FindComments(ctx, id)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get review comments", err)
+	}
+	return comments, nil
+}
+
+// AddReviewComment adds a review comment, optionally anchored to a section and a quoted passage
+func (u *postMortemUsecase) AddReviewComment(ctx context.Context, userID uint, id uint, sectionKey, quote, body string) (*domain.PostMortemReviewComment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, domain.ErrValidation("body is required")
+	}
+	if len([]rune(body)) > maxReviewCommentLength || len([]rune(quote)) > maxReviewCommentLength {
+		return nil, domain.ErrValidation(fmt.Sprintf("body and quote must be at most %d characters", maxReviewCommentLength))
+	}
+
+	pm, err := u.findPostMortem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	sectionKey = strings.TrimSpace(sectionKey)
+	if !pm.IsValidReviewAnchor(sectionKey) {
+		return nil, domain.ErrValidation("unknown section: " + sectionKey)
+	}
+
+	comment := &domain.PostMortemReviewComment{
+		PostMortemID: id,
+		AuthorID:     userID,
+		SectionKey:   sectionKey,
+		Quote:        quote,
+		Body:         body,
+	}
+	if err := u.reviewRepo.CreateComment(ctx, comment); err != nil {
+		return nil, domain.ErrDatabase("Failed to add review comment", err)
+	}
+	return u.reviewRepo.FindCommentByID(ctx, comment.ID)
+}
+
+// ResolveReviewComment marks a review comment as resolved (or reopens it).
+// The post-mortem author, the comment author and admins may resolve comments.
+func (u *postMortemUsecase) ResolveReviewComment(ctx context.Context, userID uint, userRole domain.Role, id, commentID uint, resolved bool) (*domain.PostMortemReviewComment, error) {
+	pm, err := u.findPostMortem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := u.reviewRepo.FindCommentByID(ctx, commentID)
+	if err != nil || comment.PostMortemID != id {
+		return nil, domain.ErrNotFound("Review comment")
+	}
+	if userRole != domain.RoleAdmin && pm.AuthorID != userID && comment.AuthorID != userID {
+		return nil, domain.ErrForbidden("You cannot resolve this review comment")
+	}
+
+	comment.Resolved = resolved
+	if resolved {
+		now := time.Now()
+		comment.ResolvedByID = &userID
+		comment.ResolvedAt = &now
+	} else {
+		comment.ResolvedByID = nil
+		comment.ResolvedAt = nil
+	}
+	if err := u.reviewRepo.UpdateComment(ctx, comment); err != nil {
+		return nil, domain.ErrDatabase("Failed to update review comment", err)
+	}
+	return u.reviewRepo.FindCommentByID(ctx, commentID)
+}
+
+// requiredApprovals returns the number of approvals needed to publish: the number fixed at submission
+// while in review, otherwise the template's number or the system default
+func (u *postMortemUsecase) requiredApprovals(ctx context.Context, pm *domain.PostMortem) (int, error) {
+	if pm.Status == domain.PMStatusInReview || pm.Status == domain.PMStatusApproved {
+		return pm.RequiredApprovals, nil
+	}
+	if pm.TemplateID != nil {
+		template, err := u.templateRepo.FindByID(ctx, *pm.TemplateID)
+		if err != nil {
+			return 0, domain.ErrDatabase("Failed to get post-mortem template", err)
+		}
+		if template.RequiredApprovals != nil {
+			return *template.RequiredApprovals, nil
+		}
+	}
+	return u.defaultRequiredApprovals, nil
+}
+
+// approveIfComplete moves a post-mortem in review to approved once it has enough approvals
+func (u *postMortemUsecase) approveIfComplete(ctx context.Context, pm *domain.PostMortem, actorID uint) error {
+	reviewers, err := u.reviewRepo.FindReviewers(ctx, pm.ID)
+	if err != nil {
+		return domain.ErrDatabase("Failed to get reviewers", err)
+	}
+	pm.Reviewers = reviewers
+
+	required := pm.RequiredApprovals
+	if required < 1 {
+		required = 1
+	}
+	if pm.Approvals() < required {
+		return nil
+	}
+
+	pm.Status = domain.PMStatusApproved
+	if err := u.postMortemRepo.Update(ctx, pm); err != nil {
+		return err
+	}
+	u.notifyReview(ctx, pm, domain.ReviewEventApproved, actorID, "")
+	return nil
+}
+
+// notifyReview notifies the author and all reviewers of a review workflow transition
+func (u *postMortemUsecase) notifyReview(ctx context.Context, pm *domain.PostMortem, event domain.PostMortemReviewEvent, actorID uint, note string) {
+	recipients := []uint{pm.AuthorID}
+	reviewers, err := u.reviewRepo.FindReviewers(ctx, pm.ID)
+	if err != nil {
+		logger.Log.Warn("Failed to get post-mortem reviewers", zap.Uint("post_mortem_id", pm.ID), zap.Error(err))
+	}
+	for _, reviewer := range reviewers {
+		recipients = append(recipients, reviewer.ReviewerID)
+	}
+	u.notifyReviewRecipients(ctx, pm, recipients, event, actorID, note)
+}
+
+func (u *postMortemUsecase) notifyReviewRecipients(ctx context.Context, pm *domain.PostMortem, recipients []uint, event domain.PostMortemReviewEvent, actorID uint, note string) {
+	if u.notificationService == nil || len(recipients) == 0 {
+		return
+	}
+	actor, err := u.userRepo.FindByID(ctx, actorID)
+	if err != nil {
+		actor = nil
+	}
+	if err := u.notificationService.NotifyPostMortemReview(pm, recipients, event, actor, note); err != nil {
+		logger.Log.Error("Failed to send post-mortem review notification", zap.Uint("post_mortem_id", pm.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"incidex/internal/domain"
+	"testing"
+)
+
+// fakeUserRepository holds users by ID. Like the gorm repository, FindByID returns (nil, nil) for a missing user.
+type fakeUserRepository struct {
+	domain.UserRepository
+	users map[uint]*domain.User
+}
+
+func (r *fakeUserRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	return r.users[id], nil
+}
+
+type fakePostMortemRepository struct {
+	domain.PostMortemRepository
+	postMortems map[uint]*domain.PostMortem
+}
+
+func (r *fakePostMortemRepository) FindByID(ctx context.Context, id uint) (*domain.PostMortem, error) {
+	pm, ok := r.postMortems[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return pm, nil
+}
+
+type fakePostMortemReviewRepository struct {
+	domain.PostMortemReviewRepository
+	reviewers map[uint][]uint
+}
+
+func (r *fakePostMortemReviewRepository) ReplaceReviewers(ctx context.Context, postMortemID uint, reviewerIDs []uint) error {
+	r.reviewers[postMortemID] = reviewerIDs
+	return nil
+}
+
+func newReviewTestUsecase() (*postMortemUsecase, *fakePostMortemReviewRepository) {
+	reviewRepo := &fakePostMortemReviewRepository{reviewers: map[uint][]uint{}}
+	u := &postMortemUsecase{
+		postMortemRepo: &fakePostMortemRepository{postMortems: map[uint]*domain.PostMortem{
+			1: {ID: 1, AuthorID: 10, Status: domain.PMStatusDraft},
+		}},
+		userRepo: &fakeUserRepository{users: map[uint]*domain.User{
+			10: {ID: 10, Role: domain.RoleEditor},
+			11: {ID: 11, Role: domain.RoleEditor},
+			12: {ID: 12, Role: domain.RoleViewer},
+		}},
+		reviewRepo: reviewRepo,
+	}
+	return u, reviewRepo
+}
+
+func TestAssignReviewersUnknownReviewer(t *testing.T) {
+	u, reviewRepo := newReviewTestUsecase()
+
+	_, err := u.AssignReviewers(context.Background(), 10, domain.RoleEditor, 1, []uint{11, 999})
+
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) || domainErr.Code != domain.ErrCodeNotFound {
+		t.Fatalf("error = %v, want a not-found error", err)
+	}
+	if _, ok := reviewRepo.reviewers[1]; ok {
+		t.Error("reviewers were saved although one of them does not exist")
+	}
+}
+
+func TestAssignReviewers(t *testing.T) {
+	tests := []struct {
+		name        string
+		reviewerIDs []uint
+		wantCode    domain.ErrorCode // empty: success
+	}{
+		{name: "editor", reviewerIDs: []uint{11, 11}},
+		{name: "author", reviewerIDs: []uint{10}, wantCode: domain.ErrCodeValidation},
+		{name: "viewer", reviewerIDs: []uint{12}, wantCode: domain.ErrCodeValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, reviewRepo := newReviewTestUsecase()
+
+			_, err := u.AssignReviewers(context.Background(), 10, domain.RoleEditor, 1, tt.reviewerIDs)
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("AssignReviewers returned error: %v", err)
+				}
+				if got := reviewRepo.reviewers[1]; len(got) != 1 || got[0] != 11 {
+					t.Errorf("saved reviewers = %v, want [11]", got)
+				}
+				return
+			}
+			var domainErr *domain.DomainError
+			if !errors.As(err, &domainErr) || domainErr.Code != tt.wantCode {
+				t.Fatalf("error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
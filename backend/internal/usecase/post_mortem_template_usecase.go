@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+)
+
+type PostMortemTemplateUsecase struct {
+	templateRepo domain.PostMortemTemplateRepository
+	tagRepo      domain.TagRepository
+	incidentRepo domain.IncidentRepository
+}
+
+func NewPostMortemTemplateUsecase(
+	templateRepo domain.PostMortemTemplateRepository,
+	tagRepo domain.TagRepository,
+	incidentRepo domain.IncidentRepository,
+) *PostMortemTemplateUsecase {
+	return &PostMortemTemplateUsecase{
+		templateRepo: templateRepo,
+		tagRepo:      tagRepo,
+		incidentRepo: incidentRepo,
+	}
+}
+
+// CreateTemplate creates a new post-mortem template
+func (u *PostMortemTemplateUsecase) CreateTemplate(ctx context.Context, userID uint, name, description string, sections []domain.PostMortemTemplateSection, severity domain.Severity, isDefault bool, requiredApprovals *int, tagIDs []uint) (*domain.PostMortemTemplate, error) {
+	tags, err := u.findTags(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &domain.PostMortemTemplate{
+		Name:              name,
+		Description:       description,
+		Sections:          sections,
+		Severity:          severity,
+		IsDefault:         isDefault,
+		RequiredApprovals: requiredApprovals,
+		CreatorID:         userID,
+		Tags:              tags,
+	}
+	if err := validatePostMortemTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := u.templateRepo.Create(ctx, template); err != nil {
+		return nil, domain.ErrDatabase("Failed to create post-mortem template", err)
+	}
+
+	return u.GetTemplateByID(ctx, template.ID)
+}
+
+// GetAllTemplates retrieves all post-mortem templates
+func (u *PostMortemTemplateUsecase) GetAllTemplates(ctx context.Context) ([]*domain.PostMortemTemplate, error) {
+	templates, err := u.templateRepo.FindAll(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get post-mortem templates", err)
+	}
+	return templates, nil
+}
+
+// GetTemplateByID retrieves a post-mortem template by ID
+func (u *PostMortemTemplateUsecase) GetTemplateByID(ctx context.Context, id uint) (*domain.PostMortemTemplate, error) {
+	template, err := u.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFound("Post-mortem template").WithError(err)
+	}
+	return template, nil
+}
+
+// UpdateTemplate updates a post-mortem template. Post-mortems already written with the
+// template keep their sections; the change applies to post-mortems created afterwards.
+func (u *PostMortemTemplateUsecase) UpdateTemplate(ctx context.Context, id uint, name, description string, sections []domain.PostMortemTemplateSection, severity domain.Severity, isDefault bool, requiredApprovals *int, tagIDs []uint) (*domain.PostMortemTemplate, error) {
+	template, err := u.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := u.findTags(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = name
+	template.Description = description
+	template.Sections = sections
+	template.Severity = severity
+	template.IsDefault = isDefault
+	template.RequiredApprovals = requiredApprovals
+	template.Tags = tags
+	if err := validatePostMortemTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := u.templateRepo.Update(ctx, template); err != nil {
+		return nil, domain.ErrDatabase("Failed to update post-mortem template", err)
+	}
+
+	return u.GetTemplateByID(ctx, id)
+}
+
+// DeleteTemplate deletes a post-mortem template
+func (u *PostMortemTemplateUsecase) DeleteTemplate(ctx context.Context, id uint) error {
+	if _, err := u.GetTemplateByID(ctx, id); err != nil {
+		return err
+	}
+	if err := u.templateRepo.Delete(ctx, id); err != nil {
+		return domain.ErrDatabase("Failed to delete post-mortem template", err)
+	}
+	return nil
+}
+
+// GetTemplateForIncident returns the template selected for the incident by its tags and severity
+func (u *PostMortemTemplateUsecase) GetTemplateForIncident(ctx context.Context, incidentID uint) (*domain.PostMortemTemplate, error) {
+	incident, err := u.incidentRepo.FindByID(ctx, incidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+
+	templates, err := u.GetAllTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template := domain.SelectPostMortemTemplate(templates, incident)
+	if template == nil {
+		return nil, domain.ErrNotFound("Post-mortem template for this incident")
+	}
+	return template, nil
+}
+
+func (u *PostMortemTemplateUsecase) findTags(ctx context.Context, tagIDs []uint) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	for _, tagID := range tagIDs {
+		tag, err := u.tagRepo.FindByID(ctx, tagID)
+		if err != nil {
+			return nil, domain.ErrNotFound(fmt.Sprintf("Tag with ID %d", tagID))
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+func validatePostMortemTemplate(template *domain.PostMortemTemplate) error {
+	if template.Severity != "" && !isValidSeverity(template.Severity) {
+		return domain.ErrValidation("invalid severity")
+	}
+	return template.Validate()
+}
@@ -6,19 +6,39 @@ import (
 	"fmt"
 	"incidex/internal/domain"
 	"incidex/internal/infrastructure/ai"
+	"incidex/internal/infrastructure/notification"
+	"incidex/internal/pkg/logger"
+	"strings"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type PostMortemUsecase interface {
-	CreatePostMortem(ctx context.Context, authorID uint, incidentID uint, rootCause, impactAnalysis, whatWentWell, whatWentWrong, lessonsLearned string, fiveWhys *domain.FiveWhysAnalysis) (*domain.PostMortem, error)
+	CreatePostMortem(ctx context.Context, authorID uint, incidentID uint, rootCause, impactAnalysis, whatWentWell, whatWentWrong, lessonsLearned string, fiveWhys *domain.FiveWhysAnalysis, templateID *uint, sections []domain.PostMortemSection) (*domain.PostMortem, error)
 	GetPostMortemByID(ctx context.Context, id uint) (*domain.PostMortem, error)
 	GetPostMortemByIncidentID(ctx context.Context, incidentID uint) (*domain.PostMortem, error)
-	UpdatePostMortem(ctx context.Context, userID uint, userRole domain.Role, id uint, rootCause, impactAnalysis, whatWentWell, whatWentWrong, lessonsLearned string, fiveWhys *domain.FiveWhysAnalysis) (*domain.PostMortem, error)
+	UpdatePostMortem(ctx context.Context, userID uint, userRole domain.Role, id uint, rootCause, impactAnalysis, whatWentWell, whatWentWrong, lessonsLearned string, fiveWhys *domain.FiveWhysAnalysis, sections []domain.PostMortemSection) (*domain.PostMortem, error)
 	PublishPostMortem(ctx context.Context, userID uint, userRole domain.Role, id uint) (*domain.PostMortem, error)
 	UnpublishPostMortem(ctx context.Context, userID uint, userRole domain.Role, id uint) (*domain.PostMortem, error)
 	DeletePostMortem(ctx context.Context, userRole domain.Role, id uint) error
 	GetAllPostMortems(ctx context.Context, filters domain.PostMortemFilters, pagination domain.Pagination) ([]*domain.PostMortem, *domain.PaginationResult, error)
 	GenerateAIRootCauseSuggestion(ctx context.Context, incidentID uint) (string, error)
+	GetCausalTree(ctx context.Context, postMortemID uint) (*domain.CausalTree, error)
+	AddCausalNode(ctx context.Context, userID uint, userRole domain.Role, postMortemID uint, input CausalNodeInput) (*domain.CausalTree, error)
+	UpdateCausalNode(ctx context.Context, userID uint, userRole domain.Role, postMortemID, nodeID uint, input CausalNodeInput) (*domain.CausalTree, error)
+	DeleteCausalNode(ctx context.Context, userID uint, userRole domain.Role, postMortemID, nodeID uint) (*domain.CausalTree, error)
+	GetCausalCategorySummary(ctx context.Context, months int, status domain.PMStatus) (*domain.CausalCategorySummary, error)
+	AssignReviewers(ctx context.Context, userID uint, userRole domain.Role, id uint, reviewerIDs []uint) (*domain.PostMortem, error)
+	SubmitForReview(ctx context.Context, userID uint, userRole domain.Role, id uint) (*domain.PostMortem, error)
+	ReviewPostMortem(ctx context.Context, reviewerID uint, id uint, decision domain.ReviewDecision, comment string) (*domain.PostMortem, error)
+	GetReviewComments(ctx context.Context, id uint) ([]domain.PostMortemReviewComment, error)
+	AddReviewComment(ctx context.Context, userID uint, id uint, sectionKey, quote, body string) (*domain.PostMortemReviewComment, error)
+	ResolveReviewComment(ctx context.Context, userID uint, userRole domain.Role, id, commentID uint, resolved bool) (*domain.PostMortemReviewComment, error)
+	ListVersions(ctx context.Context, id uint) ([]domain.PostMortemVersion, error)
+	GetVersion(ctx context.Context, id uint, version int) (*domain.PostMortemVersion, error)
+	DiffVersions(ctx context.Context, id uint, fromVersion, toVersion int) (*domain.PostMortemVersionDiff, error)
+	GetPostMortemDocument(ctx context.Context, id uint, version int) (*domain.PostMortemDocument, error)
 }
 
 type postMortemUsecase struct {
@@ -26,7 +46,18 @@ type postMortemUsecase struct {
 	incidentRepo   domain.IncidentRepository
 	activityRepo   domain.IncidentActivityRepository
 	userRepo       domain.UserRepository
+	templateRepo   domain.PostMortemTemplateRepository
+	causalRepo     domain.CausalNodeRepository
+	reviewRepo     domain.PostMortemReviewRepository
+	versionRepo    domain.PostMortemVersionRepository
+	actionItemRepo domain.ActionItemRepository
 	aiService      *ai.OpenAIService
+
+	notificationService *notification.NotificationService
+	// Approvals required before publishing when the template does not set its own number
+	defaultRequiredApprovals int
+	// Mandatory post-mortems completed on publish (optional)
+	requirementUsecase *PostMortemRequirementUsecase
 }
 
 func NewPostMortemUsecase(
@@ -34,14 +65,31 @@ func NewPostMortemUsecase(
 	incidentRepo domain.IncidentRepository,
 	activityRepo domain.IncidentActivityRepository,
 	userRepo domain.UserRepository,
+	templateRepo domain.PostMortemTemplateRepository,
+	causalRepo domain.CausalNodeRepository,
+	reviewRepo domain.PostMortemReviewRepository,
+	versionRepo domain.PostMortemVersionRepository,
+	actionItemRepo domain.ActionItemRepository,
 	aiService *ai.OpenAIService,
+	notificationService *notification.NotificationService,
+	defaultRequiredApprovals int,
+	requirementUsecase *PostMortemRequirementUsecase,
 ) PostMortemUsecase {
 	return &postMortemUsecase{
 		postMortemRepo: postMortemRepo,
 		incidentRepo:   incidentRepo,
 		activityRepo:   activityRepo,
 		userRepo:       userRepo,
+		templateRepo:   templateRepo,
+		causalRepo:     causalRepo,
+		reviewRepo:     reviewRepo,
+		versionRepo:    versionRepo,
+		actionItemRepo: actionItemRepo,
 		aiService:      aiService,
+
+		notificationService:      notificationService,
+		defaultRequiredApprovals: defaultRequiredApprovals,
+		requirementUsecase:       requirementUsecase,
 	}
 }
 
@@ -51,6 +99,8 @@ func (u *postMortemUsecase) CreatePostMortem(
 	incidentID uint,
 	rootCause, impactAnalysis, whatWentWell, whatWentWrong, lessonsLearned string,
 	fiveWhys *domain.FiveWhysAnalysis,
+	templateID *uint,
+	sections []domain.PostMortemSection,
 ) (*domain.PostMortem, error) {
 	// Check if incident exists
 	incident, err := u.incidentRepo.FindByID(ctx, incidentID)
@@ -64,6 +114,24 @@ func (u *postMortemUsecase) CreatePostMortem(
 		return nil, domain.ErrConflict("Post-mortem already exists for this incident")
 	}
 
+	// Resolve the template: the requested one, or the one selected by the incident's tags and severity
+	template, err := u.resolveTemplate(ctx, incident, templateID)
+	if err != nil {
+		return nil, err
+	}
+	pmSections := []domain.PostMortemSection{}
+	var pmTemplateID *uint
+	if template != nil {
+		pmTemplateID = &template.ID
+		pmSections = template.NewSections()
+	} else if len(sections) > 0 {
+		return nil, domain.ErrValidation("No post-mortem template applies to this incident; sections cannot be set")
+	}
+	pmSections, err = domain.MergeSections(pmSections, sections)
+	if err != nil {
+		return nil, err
+	}
+
 	// Marshal Five Whys analysis to JSON
 	var fiveWhysJSON string
 	if fiveWhys != nil {
@@ -104,6 +172,8 @@ func (u *postMortemUsecase) CreatePostMortem(
 		LessonsLearned:        lessonsLearned,
 		FiveWhysAnalysis:      fiveWhysJSON,
 		AIRootCauseSuggestion: aiSuggestion,
+		TemplateID:            pmTemplateID,
+		Sections:              pmSections,
 		Status:                domain.PMStatusDraft,
 	}
 
@@ -111,6 +181,23 @@ func (u *postMortemUsecase) CreatePostMortem(
 		return nil, err
 	}
 
+	// Move the action items raised during the incident into the post-mortem
+	moved, err := u.actionItemRepo.AttachToPostMortem(ctx, incidentID, pm.ID)
+	if err != nil {
+		logger.Log.Error("Failed to attach incident action items to post-mortem", zap.Uint("post_mortem_id", pm.ID), zap.Error(err))
+	} else if moved > 0 {
+		activity := &domain.IncidentActivity{
+			IncidentID:   incidentID,
+			UserID:       authorID,
+			ActivityType: domain.ActivityTypeComment,
+			Comment:      fmt.Sprintf("アクションアイテム %d 件をポストモーテムに移動しました", moved),
+			CreatedAt:    time.Now(),
+		}
+		if err := u.activityRepo.Create(activity); err != nil {
+			logger.Log.Error("Failed to log action item move activity", zap.Error(err))
+		}
+	}
+
 	// Reload with relations
 	return u.postMortemRepo.FindByID(ctx, pm.ID)
 }
@@ -130,6 +217,7 @@ func (u *postMortemUsecase) UpdatePostMortem(
 	id uint,
 	rootCause, impactAnalysis, whatWentWell, whatWentWrong, lessonsLearned string,
 	fiveWhys *domain.FiveWhysAnalysis,
+	sections []domain.PostMortemSection,
 ) (*domain.PostMortem, error) {
 	// Get existing post-mortem
 	pm, err := u.postMortemRepo.FindByID(ctx, id)
@@ -142,6 +230,11 @@ func (u *postMortemUsecase) UpdatePostMortem(
 		return nil, domain.ErrForbidden("You can only update your own post-mortems")
 	}
 
+	// The published content is what readers see; it has to be unpublished first
+	if pm.Status == domain.PMStatusPublished {
+		return nil, domain.ErrValidation("Published post-mortems must be unpublished before editing")
+	}
+
 	// Marshal Five Whys analysis to JSON
 	var fiveWhysJSON string
 	if fiveWhys != nil {
@@ -160,10 +253,42 @@ func (u *postMortemUsecase) UpdatePostMortem(
 	pm.LessonsLearned = lessonsLearned
 	pm.FiveWhysAnalysis = fiveWhysJSON
 
+	// Sections that are not sent keep their content
+	if sections != nil {
+		merged, err := domain.MergeSections(pm.Sections, sections)
+		if err != nil {
+			return nil, err
+		}
+		pm.Sections = merged
+	}
+
+	// Editing the content invalidates the review decisions given so far,
+	// and an approved post-mortem goes back to review
+	decisionsReset := pm.Status == domain.PMStatusInReview || pm.Status == domain.PMStatusChangesRequested || pm.Status == domain.PMStatusApproved
+	hadApprovals := false
+	for _, reviewer := range pm.Reviewers {
+		if reviewer.Decision == domain.ReviewDecisionApproved {
+			hadApprovals = true
+			break
+		}
+	}
+	if pm.Status == domain.PMStatusApproved {
+		pm.Status = domain.PMStatusInReview
+	}
+
 	if err := u.postMortemRepo.Update(ctx, pm); err != nil {
 		return nil, err
 	}
 
+	if decisionsReset {
+		if err := u.reviewRepo.ResetDecisions(ctx, id); err != nil {
+			return nil, domain.ErrDatabase("Failed to reset review decisions", err)
+		}
+		if pm.Status == domain.PMStatusInReview && hadApprovals {
+			u.notifyReview(ctx, pm, domain.ReviewEventSubmitted, userID, "承認後に内容が変更されたため、再レビューが必要です")
+		}
+	}
+
 	// Reload with relations
 	return u.postMortemRepo.FindByID(ctx, id)
 }
@@ -190,14 +315,34 @@ func (u *postMortemUsecase) PublishPostMortem(
 		return nil, domain.ErrForbidden("You can only publish your own post-mortems")
 	}
 
-	// Update status
+	// Required template sections must be filled in
+	if missing := pm.MissingRequiredSections(); len(missing) > 0 {
+		return nil, domain.ErrValidation("Required sections are empty: " + strings.Join(missing, ", "))
+	}
+
+	// Peer review: the post-mortem must be approved unless no approval is required
+	if pm.Status != domain.PMStatusApproved {
+		required, err := u.requiredApprovals(ctx, pm)
+		if err != nil {
+			return nil, err
+		}
+		if required > 0 {
+			return nil, domain.ErrValidation(fmt.Sprintf("Post-mortem must be approved by %d reviewer(s) before publishing", required))
+		}
+	}
+
+	// Update status and keep a snapshot of the published content
 	now := time.Now()
 	pm.Status = domain.PMStatusPublished
 	pm.PublishedAt = &now
 
-	if err := u.postMortemRepo.Update(ctx, pm); err != nil {
+	if err := u.publishVersion(ctx, pm, userID); err != nil {
 		return nil, err
 	}
+	u.notifyReview(ctx, pm, domain.ReviewEventPublished, userID, "")
+	if u.requirementUsecase != nil {
+		u.requirementUsecase.MarkPublished(ctx, pm.IncidentID, now)
+	}
 
 	// Reload with relations
 	return u.postMortemRepo.FindByID(ctx, id)
@@ -215,9 +360,9 @@ func (u *postMortemUsecase) UnpublishPostMortem(
 		return nil, err
 	}
 
-	// Check if already draft
-	if pm.Status == domain.PMStatusDraft {
-		return nil, domain.ErrValidation("Post-mortem is already in draft status")
+	// Only published post-mortems can be unpublished
+	if pm.Status != domain.PMStatusPublished {
+		return nil, domain.ErrValidation("Post-mortem is not published")
 	}
 
 	// Check permissions (only author or admin can unpublish)
@@ -225,13 +370,17 @@ func (u *postMortemUsecase) UnpublishPostMortem(
 		return nil, domain.ErrForbidden("You can only unpublish your own post-mortems")
 	}
 
-	// Update status back to draft
+	// Update status back to draft; it has to be reviewed again before it is re-published
 	pm.Status = domain.PMStatusDraft
 	pm.PublishedAt = nil
 
 	if err := u.postMortemRepo.Update(ctx, pm); err != nil {
 		return nil, err
 	}
+	if err := u.reviewRepo.ResetDecisions(ctx, id); err != nil {
+		return nil, domain.ErrDatabase("Failed to reset review decisions", err)
+	}
+	u.notifyReview(ctx, pm, domain.ReviewEventUnpublished, userID, "")
 
 	// Reload with relations
 	return u.postMortemRepo.FindByID(ctx, id)
@@ -283,3 +432,20 @@ func (u *postMortemUsecase) GenerateAIRootCauseSuggestion(ctx context.Context, i
 
 	return suggestion, nil
 }
+
+// resolveTemplate returns the requested template, or the template selected for the incident (nil if none applies)
+func (u *postMortemUsecase) resolveTemplate(ctx context.Context, incident *domain.Incident, templateID *uint) (*domain.PostMortemTemplate, error) {
+	if templateID != nil {
+		template, err := u.templateRepo.FindByID(ctx, *templateID)
+		if err != nil {
+			return nil, domain.ErrNotFound("Post-mortem template").WithError(err)
+		}
+		return template, nil
+	}
+
+	templates, err := u.templateRepo.FindAll(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get post-mortem templates", err)
+	}
+	return domain.SelectPostMortemTemplate(templates, incident), nil
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+)
+
+// ListVersions returns the published versions of a post-mortem, newest first
+func (u *postMortemUsecase) ListVersions(ctx context.Context, id uint) ([]domain.PostMortemVersion, error) {
+	if _, err := u.findPostMortem(ctx, id); err != nil {
+		return nil, err
+	}
+	versions, err := u.versionRepo.FindByPostMortemID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get post-mortem versions", err)
+	}
+	return versions, nil
+}
+
+// GetVersion returns a published version of a post-mortem with its content
+func (u *postMortemUsecase) GetVersion(ctx context.Context, id uint, version int) (*domain.PostMortemVersion, error) {
+	if _, err := u.findPostMortem(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.findVersion(ctx, id, version)
+}
+
+// DiffVersions compares two published versions; toVersion 0 compares against the current working copy
+func (u *postMortemUsecase) DiffVersions(ctx context.Context, id uint, fromVersion, toVersion int) (*domain.PostMortemVersionDiff, error) {
+	if fromVersion < 1 || toVersion < 0 {
+		return nil, domain.ErrValidation("from must be a published version and to must be a version or 0 for the working copy")
+	}
+
+	pm, err := u.findPostMortem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	from, err := u.findVersion(ctx, id, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var to *domain.PostMortemContent
+	if toVersion == 0 {
+		if to, err = u.currentContent(ctx, pm); err != nil {
+			return nil, err
+		}
+	} else {
+		version, err := u.findVersion(ctx, id, toVersion)
+		if err != nil {
+			return nil, err
+		}
+		to = version.Content
+	}
+
+	return &domain.PostMortemVersionDiff{
+		PostMortemID: id,
+		FromVersion:  fromVersion,
+		ToVersion:    toVersion,
+		Changes:      domain.DiffPostMortemContent(from.Content, to),
+	}, nil
+}
+
+// GetPostMortemDocument prepares a post-mortem for export; version 0 exports the current content
+func (u *postMortemUsecase) GetPostMortemDocument(ctx context.Context, id uint, version int) (*domain.PostMortemDocument, error) {
+	if version < 0 {
+		return nil, domain.ErrValidation("version must not be negative")
+	}
+
+	pm, err := u.findPostMortem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc *domain.PostMortemDocument
+	if version > 0 {
+		pmVersion, err := u.findVersion(ctx, id, version)
+		if err != nil {
+			return nil, err
+		}
+		doc = domain.NewPostMortemDocument(pm, nil, pmVersion)
+	} else {
+		nodes, err := u.causalRepo.FindByPostMortemID(ctx, id)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to get causal analysis", err)
+		}
+		doc = domain.NewPostMortemDocument(pm, nodes, nil)
+	}
+
+	// Incident header and timeline
+	incident, err := u.incidentRepo.FindByID(ctx, pm.IncidentID)
+	if err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+	timeline, err := u.activityRepo.FindTimelineByIncidentID(pm.IncidentID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incident timeline", err)
+	}
+	doc.Incident = incident
+	doc.Timeline = timeline
+	return doc, nil
+}
+
+// publishVersion saves the post-mortem being published together with a snapshot of its content as the next version
+func (u *postMortemUsecase) publishVersion(ctx context.Context, pm *domain.PostMortem, userID uint) error {
+	content, err := u.currentContent(ctx, pm)
+	if err != nil {
+		return err
+	}
+
+	version := &domain.PostMortemVersion{
+		PostMortemID:  pm.ID,
+		Version:       pm.CurrentVersion + 1,
+		Content:       content,
+		PublishedByID: userID,
+		PublishedAt:   *pm.PublishedAt,
+	}
+	pm.CurrentVersion = version.Version
+	if err := u.versionRepo.Publish(ctx, pm, version); err != nil {
+		return domain.ErrDatabase("Failed to publish post-mortem", err)
+	}
+	return nil
+}
+
+func (u *postMortemUsecase) currentContent(ctx context.Context, pm *domain.PostMortem) (*domain.PostMortemContent, error) {
+	nodes, err := u.causalRepo.FindByPostMortemID(ctx, pm.ID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get causal analysis", err)
+	}
+	return domain.NewPostMortemContent(pm, nodes), nil
+}
+
+func (u *postMortemUsecase) findVersion(ctx context.Context, id uint, version int) (*domain.PostMortemVersion, error) {
+	pmVersion, err := u.versionRepo.FindByVersion(ctx, id, version)
+	if err != nil {
+		return nil, domain.ErrNotFound(fmt.Sprintf("Post-mortem version %d", version)).WithError(err)
+	}
+	return pmVersion, nil
+}
@@ -3,21 +3,43 @@ package usecase
 import (
 	"context"
 	"incidex/internal/domain"
+	"incidex/internal/infrastructure/markdown"
+	"incidex/internal/infrastructure/notification"
 	"time"
 )
 
 type ReportUsecase interface {
 	GetMonthlyReport(ctx context.Context, year, month int) (*domain.MonthlyReport, error)
 	GetCustomReport(ctx context.Context, startDate, endDate time.Time) (*domain.MonthlyReport, error)
+	GetResponderLoadReport(ctx context.Context, startDate, endDate time.Time, loc *time.Location) (*domain.ResponderLoadReport, error)
+	GetActionItemReport(ctx context.Context, startDate, endDate time.Time, loc *time.Location) (*domain.ActionItemReport, error)
+	GetHandoffReport(ctx context.Context, from, to time.Time) (*domain.HandoffReport, error)
+	SendHandoffReport(ctx context.Context, from, to time.Time, loc *time.Location, scheduleID uint, recipientID *uint) (*domain.User, error)
 }
 
 type reportUsecase struct {
-	reportRepo domain.ReportRepository
+	reportRepo          domain.ReportRepository
+	userRepo            domain.UserRepository
+	actionItemRepo      domain.ActionItemRepository
+	onCallUsecase       OnCallUsecase
+	notificationService *notification.NotificationService
+	documentService     *markdown.DocumentService
 }
 
-func NewReportUsecase(reportRepo domain.ReportRepository) ReportUsecase {
+func NewReportUsecase(
+	reportRepo domain.ReportRepository,
+	userRepo domain.UserRepository,
+	actionItemRepo domain.ActionItemRepository,
+	onCallUsecase OnCallUsecase,
+	notificationService *notification.NotificationService,
+) ReportUsecase {
 	return &reportUsecase{
-		reportRepo: reportRepo,
+		reportRepo:          reportRepo,
+		userRepo:            userRepo,
+		actionItemRepo:      actionItemRepo,
+		onCallUsecase:       onCallUsecase,
+		notificationService: notificationService,
+		documentService:     markdown.NewDocumentService(),
 	}
 }
 
@@ -32,3 +54,105 @@ func (u *reportUsecase) GetMonthlyReport(ctx context.Context, year, month int) (
 func (u *reportUsecase) GetCustomReport(ctx context.Context, startDate, endDate time.Time) (*domain.MonthlyReport, error) {
 	return u.reportRepo.GetMonthlyReport(startDate, endDate)
 }
+
+// GetResponderLoadReport reports per-user and per-department on-call burden for the period.
+// After-hours, weekend and night classification uses loc.
+func (u *reportUsecase) GetResponderLoadReport(ctx context.Context, startDate, endDate time.Time, loc *time.Location) (*domain.ResponderLoadReport, error) {
+	assignments, err := u.reportRepo.GetAssignmentsBetween(startDate, endDate)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incident assignments", err)
+	}
+
+	activities, err := u.reportRepo.GetActivitiesBetween(startDate, endDate, []domain.ActivityType{
+		domain.ActivityTypeAcknowledged,
+		domain.ActivityTypeEscalated,
+	})
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incident activities", err)
+	}
+
+	users, err := u.userRepo.FindAll(ctx)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get users", err)
+	}
+
+	return buildResponderLoad(startDate, endDate, loc, users, assignments, activities), nil
+}
+
+// GetActionItemReport reports the action item burndown, aging and overdue counts for the period.
+// Weeks are counted in loc.
+func (u *reportUsecase) GetActionItemReport(ctx context.Context, startDate, endDate time.Time, loc *time.Location) (*domain.ActionItemReport, error) {
+	items, err := u.actionItemRepo.FindActiveBetween(ctx, startDate, endDate)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get action items", err)
+	}
+
+	asOf := minTime(endDate, time.Now())
+	return buildActionItemReport(startDate, endDate, asOf, loc, items), nil
+}
+
+// GetHandoffReport compiles what happened during a shift and what the next responder needs to pick up.
+func (u *reportUsecase) GetHandoffReport(ctx context.Context, from, to time.Time) (*domain.HandoffReport, error) {
+	if !to.After(from) {
+		return nil, domain.ErrValidation("to must be after from")
+	}
+
+	incidents, err := u.reportRepo.GetHandoffIncidents(from, to)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incidents", err)
+	}
+
+	activities, err := u.reportRepo.GetActivitiesBetween(from, to, nil)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get incident activities", err)
+	}
+
+	return buildHandoffReport(from, to, incidents, activities), nil
+}
+
+// SendHandoffReport emails the handoff report as Markdown. Without recipientID,
+// it goes to whoever is on call for the schedule when the shift ends.
+func (u *reportUsecase) SendHandoffReport(ctx context.Context, from, to time.Time, loc *time.Location, scheduleID uint, recipientID *uint) (*domain.User, error) {
+	var recipient *domain.User
+	if recipientID != nil {
+		user, err := u.userRepo.FindByID(ctx, *recipientID)
+		if err != nil {
+			return nil, domain.ErrDatabase("Failed to get recipient", err)
+		}
+		if user == nil {
+			return nil, domain.ErrNotFound("Recipient")
+		}
+		recipient = user
+	} else {
+		if scheduleID == 0 {
+			return nil, domain.ErrValidation("schedule_id or recipient_id is required")
+		}
+		shift, err := u.onCallUsecase.WhoIsOnCall(ctx, scheduleID, to)
+		if err != nil {
+			return nil, err
+		}
+		recipient = shift.User
+		if recipient == nil {
+			user, err := u.userRepo.FindByID(ctx, shift.UserID)
+			if err != nil {
+				return nil, domain.ErrDatabase("Failed to get on-call user", err)
+			}
+			if user == nil {
+				return nil, domain.ErrNotFound("On-call user")
+			}
+			recipient = user
+		}
+	}
+
+	report, err := u.GetHandoffReport(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	body := u.documentService.GenerateHandoffReport(report, loc)
+	if err := u.notificationService.SendHandoffReport(recipient, from.In(loc), to.In(loc), body); err != nil {
+		return nil, domain.ErrInternal("Failed to send handoff report", err)
+	}
+
+	return recipient, nil
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"fmt"
+	"incidex/internal/domain"
+	"sort"
+	"time"
+)
+
+const (
+	// Weekday business hours; interruptions outside them count as after-hours
+	businessHourStart = 9
+	businessHourEnd   = 18
+	// Night window, attributed to the date on which the night started
+	nightHourStart = 22
+	nightHourEnd   = 6
+	// Events for the same incident and user within this window are one interruption
+	interruptionMergeWindow = 30 * time.Minute
+)
+
+// responderEvent is a moment at which a responder had to turn their attention to an incident.
+type responderEvent struct {
+	userID     uint
+	incidentID uint
+	at         time.Time
+}
+
+type responderAccumulator struct {
+	load      domain.ResponderLoad
+	incidents map[uint]bool
+	nights    map[string]time.Time
+}
+
+// buildResponderLoad computes per-user and per-department burden from assignment history
+// and page/acknowledgement activities. Times are classified in loc.
+func buildResponderLoad(
+	startDate, endDate time.Time,
+	loc *time.Location,
+	users []*domain.User,
+	assignments []domain.IncidentAssignment,
+	activities []domain.IncidentActivity,
+) *domain.ResponderLoadReport {
+	accumulators := make(map[uint]*responderAccumulator)
+	get := func(userID uint) *responderAccumulator {
+		acc, ok := accumulators[userID]
+		if !ok {
+			acc = &responderAccumulator{
+				load:      domain.ResponderLoad{UserID: userID},
+				incidents: make(map[uint]bool),
+				nights:    make(map[string]time.Time),
+			}
+			accumulators[userID] = acc
+		}
+		return acc
+	}
+
+	var events []responderEvent
+	now := time.Now()
+
+	// Time spent as assignee, clipped to the period and to the incident's resolution
+	for _, a := range assignments {
+		start := a.AssignedAt
+		if start.Before(startDate) {
+			start = startDate
+		}
+		end := endDate
+		if a.UnassignedAt != nil && a.UnassignedAt.Before(end) {
+			end = *a.UnassignedAt
+		}
+		if a.Incident != nil && a.Incident.ResolvedAt != nil && a.Incident.ResolvedAt.Before(end) {
+			end = *a.Incident.ResolvedAt
+		}
+		if now.Before(end) {
+			end = now
+		}
+
+		acc := get(a.UserID)
+		if end.After(start) {
+			acc.load.AssigneeHours += end.Sub(start).Hours()
+			acc.incidents[a.IncidentID] = true
+		}
+		if !a.AssignedAt.Before(startDate) && !a.AssignedAt.After(endDate) {
+			acc.incidents[a.IncidentID] = true
+			events = append(events, responderEvent{userID: a.UserID, incidentID: a.IncidentID, at: a.AssignedAt})
+		}
+	}
+
+	for _, activity := range activities {
+		switch activity.ActivityType {
+		case domain.ActivityTypeAcknowledged:
+			get(activity.UserID).incidents[activity.IncidentID] = true
+		case domain.ActivityTypeEscalated:
+		default:
+			continue
+		}
+		events = append(events, responderEvent{userID: activity.UserID, incidentID: activity.IncidentID, at: activity.CreatedAt})
+	}
+
+	// Collapse bursts (page → acknowledge → assign) into a single interruption
+	sort.Slice(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+	type eventKey struct{ userID, incidentID uint }
+	lastCounted := make(map[eventKey]time.Time)
+	for _, event := range events {
+		key := eventKey{event.userID, event.incidentID}
+		if last, ok := lastCounted[key]; ok && event.at.Sub(last) < interruptionMergeWindow {
+			continue
+		}
+		lastCounted[key] = event.at
+
+		acc := get(event.userID)
+		acc.load.Interruptions++
+
+		local := event.at.In(loc)
+		weekend := local.Weekday() == time.Saturday || local.Weekday() == time.Sunday
+		switch {
+		case weekend:
+			acc.load.WeekendInterruptions++
+		case local.Hour() < businessHourStart || local.Hour() >= businessHourEnd:
+			acc.load.AfterHoursInterruptions++
+		}
+		if local.Hour() >= nightHourStart || local.Hour() < nightHourEnd {
+			acc.load.NightInterruptions++
+			night := local
+			if local.Hour() < nightHourEnd {
+				night = local.AddDate(0, 0, -1)
+			}
+			day := time.Date(night.Year(), night.Month(), night.Day(), 0, 0, 0, 0, time.UTC)
+			acc.nights[day.Format("2006-01-02")] = day
+		}
+	}
+
+	usersByID := make(map[uint]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	report := &domain.ResponderLoadReport{
+		Period: domain.ReportPeriod{
+			StartDate: startDate,
+			EndDate:   endDate,
+			Month:     int(startDate.Month()),
+			Year:      startDate.Year(),
+		},
+		Timezone:      loc.String(),
+		BusinessHours: fmt.Sprintf("%02d:00-%02d:00", businessHourStart, businessHourEnd),
+		NightHours:    fmt.Sprintf("%02d:00-%02d:00", nightHourStart, nightHourEnd),
+		Users:         make([]domain.ResponderLoad, 0, len(accumulators)),
+		Departments:   []domain.DepartmentLoad{},
+	}
+
+	departments := make(map[string]*domain.DepartmentLoad)
+	departmentIncidents := make(map[string]map[uint]bool)
+	for userID, acc := range accumulators {
+		load := acc.load
+		load.IncidentsHandled = len(acc.incidents)
+		load.MaxConsecutiveNights = longestConsecutiveDays(acc.nights)
+		if user, ok := usersByID[userID]; ok {
+			load.UserName = user.Name
+			load.Department = user.Department
+		}
+		if load.IncidentsHandled == 0 && load.Interruptions == 0 && load.AssigneeHours == 0 {
+			continue
+		}
+		report.Users = append(report.Users, load)
+
+		dept, ok := departments[load.Department]
+		if !ok {
+			dept = &domain.DepartmentLoad{Department: load.Department}
+			departments[load.Department] = dept
+			departmentIncidents[load.Department] = make(map[uint]bool)
+		}
+		dept.Responders++
+		dept.Interruptions += load.Interruptions
+		dept.AfterHoursInterruptions += load.AfterHoursInterruptions
+		dept.WeekendInterruptions += load.WeekendInterruptions
+		dept.NightInterruptions += load.NightInterruptions
+		dept.AssigneeHours += load.AssigneeHours
+		if load.MaxConsecutiveNights > dept.MaxConsecutiveNights {
+			dept.MaxConsecutiveNights = load.MaxConsecutiveNights
+		}
+		for incidentID := range acc.incidents {
+			departmentIncidents[load.Department][incidentID] = true
+		}
+	}
+
+	for name, dept := range departments {
+		dept.IncidentsHandled = len(departmentIncidents[name])
+		report.Departments = append(report.Departments, *dept)
+	}
+
+	// Heaviest load first
+	sort.Slice(report.Users, func(i, j int) bool {
+		a, b := report.Users[i], report.Users[j]
+		if a.Interruptions != b.Interruptions {
+			return a.Interruptions > b.Interruptions
+		}
+		if a.AssigneeHours != b.AssigneeHours {
+			return a.AssigneeHours > b.AssigneeHours
+		}
+		return a.UserID < b.UserID
+	})
+	sort.Slice(report.Departments, func(i, j int) bool {
+		a, b := report.Departments[i], report.Departments[j]
+		if a.Interruptions != b.Interruptions {
+			return a.Interruptions > b.Interruptions
+		}
+		return a.Department < b.Department
+	})
+
+	return report
+}
+
+// longestConsecutiveDays returns the longest run of consecutive calendar days.
+func longestConsecutiveDays(days map[string]time.Time) int {
+	if len(days) == 0 {
+		return 0
+	}
+	sorted := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		sorted = append(sorted, day)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	longest, current := 1, 1
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Sub(sorted[i-1]) == 24*time.Hour {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
@@ -10,12 +10,14 @@ import (
 
 type StatsUsecase struct {
 	incidentRepo domain.IncidentRepository
+	reportRepo   domain.ReportRepository
 	cacheRepo    domain.CacheRepository
 }
 
-func NewStatsUsecase(incidentRepo domain.IncidentRepository, cacheRepo domain.CacheRepository) *StatsUsecase {
+func NewStatsUsecase(incidentRepo domain.IncidentRepository, reportRepo domain.ReportRepository, cacheRepo domain.CacheRepository) *StatsUsecase {
 	return &StatsUsecase{
 		incidentRepo: incidentRepo,
+		reportRepo:   reportRepo,
 		cacheRepo:    cacheRepo,
 	}
 }
@@ -323,3 +325,38 @@ func (u *StatsUsecase) GetTagStats() ([]TagStats, error) {
 
 	return tagStats, nil
 }
+
+// GetLifecycleMetrics returns MTTA/MTTD/MTTM/MTTR and per-phase durations for incidents
+// detected in the last given number of months, broken down by severity, tag and month
+func (u *StatsUsecase) GetLifecycleMetrics(months int) (*domain.LifecycleMetrics, error) {
+	ctx := context.Background()
+	cacheKey := fmt.Sprintf("stats:lifecycle:%d", months)
+
+	// Try to get from cache
+	if cachedData, err := u.cacheRepo.Get(ctx, cacheKey); err == nil {
+		var metrics domain.LifecycleMetrics
+		if err := json.Unmarshal([]byte(cachedData), &metrics); err == nil {
+			fmt.Printf("Cache hit for lifecycle metrics (months: %d)\n", months)
+			return &metrics, nil
+		}
+	}
+
+	fmt.Printf("Cache miss for lifecycle metrics (months: %d), computing...\n", months)
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(months - 1), 0)
+
+	metrics, err := u.reportRepo.GetLifecycleMetrics(startDate, now)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get lifecycle metrics", err)
+	}
+
+	// Cache the result for 10 minutes
+	if metricsJSON, err := json.Marshal(metrics); err == nil {
+		if err := u.cacheRepo.Set(ctx, cacheKey, string(metricsJSON), 10*time.Minute); err != nil {
+			fmt.Printf("Warning: Failed to cache lifecycle metrics: %v\n", err)
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"incidex/internal/domain"
+	"incidex/internal/infrastructure/timelineimport"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Maximum number of events a single import may create
+const maxTimelineImportEvents = 1000
+
+// Maximum length of a timeline event description
+const maxTimelineDescriptionLength = 5000
+
+// TimelineImportRequest describes the source of a timeline import and how to filter it.
+// Exclude holds indexes from the preview that should not be imported.
+type TimelineImportRequest struct {
+	Format           timelineimport.Format
+	Content          string
+	Location         *time.Location
+	From             *time.Time
+	To               *time.Time
+	Keywords         []string
+	DefaultEventType domain.ActivityType
+	Exclude          []int
+	Preview          bool
+}
+
+// TimelineImportEvent is a parsed event as shown in the preview
+type TimelineImportEvent struct {
+	Index       int                 `json:"index"`
+	Line        int                 `json:"line"`
+	EventTime   time.Time           `json:"event_time"`
+	EventType   domain.ActivityType `json:"event_type"`
+	Author      string              `json:"author,omitempty"`
+	Description string              `json:"description"`
+	Duplicate   bool                `json:"duplicate"`
+	DuplicateOf *uint               `json:"duplicate_of,omitempty"` // 重複する既存タイムラインイベントのID
+	Excluded    bool                `json:"excluded"`
+}
+
+// TimelineImportResult is the preview, or the outcome of committing the import
+type TimelineImportResult struct {
+	Preview     bool                  `json:"preview"`
+	Parsed      int                   `json:"parsed"`
+	Skipped     int                   `json:"skipped"`      // 日時を読み取れなかった行
+	FilteredOut int                   `json:"filtered_out"` // 期間・キーワードで除外された件数
+	Duplicates  int                   `json:"duplicates"`
+	Imported    int                   `json:"imported"`
+	Events      []TimelineImportEvent `json:"events"`
+}
+
+// ImportTimeline parses a chat export or log into timeline events. With Preview set nothing is
+// saved; otherwise every event that is neither a duplicate nor excluded is added to the timeline.
+func (u *IncidentActivityUsecase) ImportTimeline(ctx context.Context, incidentID, userID uint, req TimelineImportRequest) (*TimelineImportResult, error) {
+	if req.DefaultEventType == "" {
+		req.DefaultEventType = domain.ActivityTypeOther
+	}
+	if !req.DefaultEventType.IsTimelineEvent() {
+		return nil, domain.ErrValidation("invalid default event type: " + string(req.DefaultEventType))
+	}
+	if req.From != nil && req.To != nil && req.To.Before(*req.From) {
+		return nil, domain.ErrValidation("to must be after from")
+	}
+	if req.Location == nil {
+		req.Location = time.UTC
+	}
+
+	if _, err := u.incidentRepo.FindByID(ctx, incidentID); err != nil {
+		return nil, domain.ErrNotFound("Incident").WithError(err)
+	}
+
+	parsed, err := timelineimport.Parse(req.Format, req.Content, req.Location)
+	if err != nil {
+		return nil, domain.ErrValidation(err.Error())
+	}
+
+	existing, err := u.activityRepo.FindTimelineByIncidentID(incidentID)
+	if err != nil {
+		return nil, domain.ErrDatabase("Failed to get timeline events", err)
+	}
+	seen := make(map[string]*uint, len(existing))
+	for _, event := range existing {
+		id := event.ID
+		seen[timelineDedupKey(event.CreatedAt, event.Comment)] = &id
+	}
+
+	excluded := make(map[int]bool, len(req.Exclude))
+	for _, index := range req.Exclude {
+		excluded[index] = true
+	}
+
+	entries := parsed.Entries
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	result := &TimelineImportResult{
+		Preview: req.Preview,
+		Parsed:  len(entries),
+		Skipped: parsed.Skipped,
+		Events:  []TimelineImportEvent{},
+	}
+
+	for _, entry := range entries {
+		if !matchesTimelineImportFilter(entry, req) {
+			result.FilteredOut++
+			continue
+		}
+
+		eventType := domain.ActivityType(strings.ToLower(entry.EventType))
+		if !eventType.IsTimelineEvent() {
+			eventType = req.DefaultEventType
+		}
+
+		description := entry.Message
+		if entry.Author != "" {
+			description = fmt.Sprintf("%s: %s", entry.Author, entry.Message)
+		}
+		if runes := []rune(description); len(runes) > maxTimelineDescriptionLength {
+			description = string(runes[:maxTimelineDescriptionLength])
+		}
+
+		event := TimelineImportEvent{
+			Index:       len(result.Events),
+			Line:        entry.Line,
+			EventTime:   entry.Time,
+			EventType:   eventType,
+			Author:      entry.Author,
+			Description: description,
+		}
+		event.Excluded = excluded[event.Index]
+
+		key := timelineDedupKey(entry.Time, description)
+		if existingID, ok := seen[key]; ok {
+			event.Duplicate = true
+			event.DuplicateOf = existingID
+			result.Duplicates++
+		} else {
+			seen[key] = nil
+		}
+
+		result.Events = append(result.Events, event)
+	}
+
+	if len(result.Events) > maxTimelineImportEvents {
+		return nil, domain.ErrValidation(fmt.Sprintf("too many events (%d); narrow the time window or keywords to at most %d", len(result.Events), maxTimelineImportEvents))
+	}
+
+	if req.Preview {
+		return result, nil
+	}
+
+	var activities []*domain.IncidentActivity
+	for _, event := range result.Events {
+		if event.Duplicate || event.Excluded {
+			continue
+		}
+		activities = append(activities, &domain.IncidentActivity{
+			IncidentID:   incidentID,
+			UserID:       userID,
+			ActivityType: event.EventType,
+			Comment:      event.Description,
+			CreatedAt:    event.EventTime,
+		})
+	}
+	if len(activities) > 0 {
+		if err := u.activityRepo.CreateBatch(activities); err != nil {
+			return nil, domain.ErrDatabase("Failed to import timeline events", err)
+		}
+	}
+	result.Imported = len(activities)
+
+	return result, nil
+}
+
+// matchesTimelineImportFilter applies the time window and keywords (any keyword, case-insensitive)
+func matchesTimelineImportFilter(entry timelineimport.Entry, req TimelineImportRequest) bool {
+	if req.From != nil && entry.Time.Before(*req.From) {
+		return false
+	}
+	if req.To != nil && entry.Time.After(*req.To) {
+		return false
+	}
+	if len(req.Keywords) == 0 {
+		return true
+	}
+	text := strings.ToLower(entry.Author + " " + entry.Message)
+	for _, keyword := range req.Keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" && strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// timelineDedupKey identifies an event by its time (to the second) and whitespace/case-normalized description
+func timelineDedupKey(at time.Time, description string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(description), " "))
+	return fmt.Sprintf("%d|%s", at.Unix(), normalized)
+}
@@ -0,0 +1,83 @@
+-- +goose Up
+-- Migration: Add Escalation Policies
+-- Date: 2025-01-01
+-- Description: Adds service and acknowledgement columns to incidents and creates multi-level escalation policy tables
+
+-- Incidents: service and acknowledgement
+ALTER TABLE incidents
+ADD COLUMN IF NOT EXISTS service VARCHAR(100),
+ADD COLUMN IF NOT EXISTS acknowledged_at TIMESTAMP,
+ADD COLUMN IF NOT EXISTS acknowledged_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_incidents_service ON incidents(service);
+
+-- Escalation Policies table
+CREATE TABLE IF NOT EXISTS escalation_policies (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    trigger VARCHAR(20) NOT NULL DEFAULT 'unacknowledged',
+    is_active BOOLEAN DEFAULT true,
+    severity VARCHAR(20),
+    tag_id INTEGER REFERENCES tags(id) ON DELETE SET NULL,
+    service VARCHAR(100),
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_policies_is_active ON escalation_policies(is_active);
+CREATE INDEX IF NOT EXISTS idx_escalation_policies_severity ON escalation_policies(severity);
+CREATE INDEX IF NOT EXISTS idx_escalation_policies_tag_id ON escalation_policies(tag_id);
+CREATE INDEX IF NOT EXISTS idx_escalation_policies_service ON escalation_policies(service);
+CREATE INDEX IF NOT EXISTS idx_escalation_policies_creator_id ON escalation_policies(creator_id);
+
+-- Escalation Levels table
+CREATE TABLE IF NOT EXISTS escalation_levels (
+    id SERIAL PRIMARY KEY,
+    policy_id INTEGER NOT NULL REFERENCES escalation_policies(id) ON DELETE CASCADE,
+    level_number INTEGER NOT NULL,
+    timeout_minutes INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (policy_id, level_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_levels_policy_id ON escalation_levels(policy_id);
+
+-- Escalation Targets table
+CREATE TABLE IF NOT EXISTS escalation_targets (
+    id SERIAL PRIMARY KEY,
+    level_id INTEGER NOT NULL REFERENCES escalation_levels(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL,
+    target_id INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_escalation_targets_level_id ON escalation_targets(level_id);
+
+-- Incident Escalations table
+CREATE TABLE IF NOT EXISTS incident_escalations (
+    id SERIAL PRIMARY KEY,
+    incident_id INTEGER UNIQUE NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    policy_id INTEGER NOT NULL REFERENCES escalation_policies(id) ON DELETE CASCADE,
+    current_level INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    last_escalated_at TIMESTAMP,
+    next_escalation_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_escalations_policy_id ON incident_escalations(policy_id);
+CREATE INDEX IF NOT EXISTS idx_incident_escalations_status ON incident_escalations(status);
+CREATE INDEX IF NOT EXISTS idx_incident_escalations_next_escalation_at ON incident_escalations(next_escalation_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS incident_escalations;
+DROP TABLE IF EXISTS escalation_targets;
+DROP TABLE IF EXISTS escalation_levels;
+DROP TABLE IF EXISTS escalation_policies;
+DROP INDEX IF EXISTS idx_incidents_service;
+ALTER TABLE incidents
+DROP COLUMN IF EXISTS acknowledged_by_id,
+DROP COLUMN IF EXISTS acknowledged_at,
+DROP COLUMN IF EXISTS service;
@@ -0,0 +1,73 @@
+-- +goose Up
+-- Migration: Add On-call Schedules
+-- Date: 2025-01-01
+-- Description: Creates on-call schedules with rotation layers, participants and temporary overrides
+
+-- On-call Schedules table
+CREATE TABLE IF NOT EXISTS on_call_schedules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Tokyo',
+    auto_assign BOOLEAN DEFAULT false,
+    severity VARCHAR(20),
+    tag_id INTEGER REFERENCES tags(id) ON DELETE SET NULL,
+    service VARCHAR(100),
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_on_call_schedules_auto_assign ON on_call_schedules(auto_assign);
+CREATE INDEX IF NOT EXISTS idx_on_call_schedules_severity ON on_call_schedules(severity);
+CREATE INDEX IF NOT EXISTS idx_on_call_schedules_tag_id ON on_call_schedules(tag_id);
+CREATE INDEX IF NOT EXISTS idx_on_call_schedules_service ON on_call_schedules(service);
+CREATE INDEX IF NOT EXISTS idx_on_call_schedules_creator_id ON on_call_schedules(creator_id);
+
+-- On-call Layers table
+CREATE TABLE IF NOT EXISTS on_call_layers (
+    id SERIAL PRIMARY KEY,
+    schedule_id INTEGER NOT NULL REFERENCES on_call_schedules(id) ON DELETE CASCADE,
+    name VARCHAR(200),
+    layer_order INTEGER NOT NULL,
+    rotation_type VARCHAR(20) NOT NULL,
+    start_date VARCHAR(10) NOT NULL,
+    handoff_time VARCHAR(5) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_on_call_layers_schedule_id ON on_call_layers(schedule_id);
+
+-- On-call Participants table
+CREATE TABLE IF NOT EXISTS on_call_participants (
+    id SERIAL PRIMARY KEY,
+    layer_id INTEGER NOT NULL REFERENCES on_call_layers(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_on_call_participants_layer_id ON on_call_participants(layer_id);
+CREATE INDEX IF NOT EXISTS idx_on_call_participants_user_id ON on_call_participants(user_id);
+
+-- On-call Overrides table
+CREATE TABLE IF NOT EXISTS on_call_overrides (
+    id SERIAL PRIMARY KEY,
+    schedule_id INTEGER NOT NULL REFERENCES on_call_schedules(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NOT NULL,
+    reason VARCHAR(500),
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_on_call_overrides_schedule_id ON on_call_overrides(schedule_id);
+CREATE INDEX IF NOT EXISTS idx_on_call_overrides_user_id ON on_call_overrides(user_id);
+CREATE INDEX IF NOT EXISTS idx_on_call_overrides_start_at ON on_call_overrides(start_at);
+CREATE INDEX IF NOT EXISTS idx_on_call_overrides_end_at ON on_call_overrides(end_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS on_call_overrides;
+DROP TABLE IF EXISTS on_call_participants;
+DROP TABLE IF EXISTS on_call_layers;
+DROP TABLE IF EXISTS on_call_schedules;
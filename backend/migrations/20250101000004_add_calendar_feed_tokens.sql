@@ -0,0 +1,19 @@
+-- +goose Up
+-- Migration: Add calendar feed tokens
+-- Date: 2025-01-01
+-- Description: Per-user tokens for subscribing to iCalendar feeds of on-call shifts and action item due dates
+
+-- Calendar Feed Tokens table
+CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feed_tokens_user_id ON calendar_feed_tokens(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feed_tokens_token_hash ON calendar_feed_tokens(token_hash);
+
+-- +goose Down
+DROP TABLE IF EXISTS calendar_feed_tokens;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Migration: Add incident assignment history
+-- Date: 2025-01-01
+-- Description: Records each period a user was assignee of an incident, used by the responder load report
+
+-- Incident Assignments table
+CREATE TABLE IF NOT EXISTS incident_assignments (
+    id SERIAL PRIMARY KEY,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_at TIMESTAMP NOT NULL,
+    unassigned_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_incident_assignments_incident_id ON incident_assignments(incident_id);
+CREATE INDEX IF NOT EXISTS idx_incident_assignments_user_id ON incident_assignments(user_id);
+CREATE INDEX IF NOT EXISTS idx_incident_assignments_assigned_at ON incident_assignments(assigned_at);
+CREATE INDEX IF NOT EXISTS idx_incident_assignments_unassigned_at ON incident_assignments(unassigned_at);
+
+-- Backfill current assignees; earlier reassignments were not recorded with user IDs
+INSERT INTO incident_assignments (incident_id, user_id, assigned_at)
+SELECT id, assignee_id, COALESCE(acknowledged_at, detected_at, created_at)
+FROM incidents
+WHERE assignee_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS incident_assignments;
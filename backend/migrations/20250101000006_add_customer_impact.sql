@@ -0,0 +1,23 @@
+-- +goose Up
+-- Migration: Add customer impact fields to incidents
+-- Date: 2025-01-01
+-- Description: Customer-impact window (independent of detection/resolution) and quantified impact
+
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS impact_started_at TIMESTAMP;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS impact_ended_at TIMESTAMP;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS affected_users BIGINT;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS failed_requests BIGINT;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS estimated_revenue_loss DOUBLE PRECISION;
+ALTER TABLE incidents ADD COLUMN IF NOT EXISTS affected_regions JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS idx_incidents_impact_started_at ON incidents(impact_started_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_incidents_impact_started_at;
+
+ALTER TABLE incidents DROP COLUMN IF EXISTS affected_regions;
+ALTER TABLE incidents DROP COLUMN IF EXISTS estimated_revenue_loss;
+ALTER TABLE incidents DROP COLUMN IF EXISTS failed_requests;
+ALTER TABLE incidents DROP COLUMN IF EXISTS affected_users;
+ALTER TABLE incidents DROP COLUMN IF EXISTS impact_ended_at;
+ALTER TABLE incidents DROP COLUMN IF EXISTS impact_started_at;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Migration: Add timeline event revisions
+-- Date: 2025-01-01
+-- Description: Keeps the original values of timeline events when they are edited or deleted
+
+-- Timeline Event Revisions table
+CREATE TABLE IF NOT EXISTS timeline_event_revisions (
+    id SERIAL PRIMARY KEY,
+    activity_id INTEGER NOT NULL,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    editor_id INTEGER NOT NULL REFERENCES users(id),
+    action VARCHAR(20) NOT NULL,
+    old_event_type VARCHAR(50) NOT NULL,
+    old_event_time TIMESTAMP NOT NULL,
+    old_description TEXT,
+    new_event_type VARCHAR(50),
+    new_event_time TIMESTAMP,
+    new_description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- activity_id has no foreign key so revisions of deleted events are kept
+CREATE INDEX IF NOT EXISTS idx_timeline_event_revisions_activity_id ON timeline_event_revisions(activity_id);
+CREATE INDEX IF NOT EXISTS idx_timeline_event_revisions_incident_id ON timeline_event_revisions(incident_id);
+CREATE INDEX IF NOT EXISTS idx_timeline_event_revisions_created_at ON timeline_event_revisions(created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS timeline_event_revisions;
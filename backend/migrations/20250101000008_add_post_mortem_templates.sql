@@ -0,0 +1,47 @@
+-- +goose Up
+-- Migration: Add post-mortem templates
+-- Date: 2025-01-01
+-- Description: Templates with typed sections, selectable by severity or tag, and per-section post-mortem content
+
+-- Post-Mortem Templates table
+CREATE TABLE IF NOT EXISTS post_mortem_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    sections JSONB NOT NULL DEFAULT '[]',
+    severity VARCHAR(20),
+    is_default BOOLEAN DEFAULT FALSE,
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_mortem_templates_name ON post_mortem_templates(name);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_templates_severity ON post_mortem_templates(severity);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_templates_is_default ON post_mortem_templates(is_default);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_templates_creator_id ON post_mortem_templates(creator_id);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_templates_created_at ON post_mortem_templates(created_at);
+
+-- Post-Mortem Template Tags (many-to-many)
+CREATE TABLE IF NOT EXISTS postmortem_template_tags (
+    post_mortem_template_id INTEGER NOT NULL REFERENCES post_mortem_templates(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (post_mortem_template_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_postmortem_template_tags_tag_id ON postmortem_template_tags(tag_id);
+
+-- Per-section content of post-mortems written with a template
+ALTER TABLE post_mortems ADD COLUMN IF NOT EXISTS template_id INTEGER REFERENCES post_mortem_templates(id) ON DELETE SET NULL;
+ALTER TABLE post_mortems ADD COLUMN IF NOT EXISTS sections JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS idx_post_mortems_template_id ON post_mortems(template_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_post_mortems_template_id;
+
+ALTER TABLE post_mortems DROP COLUMN IF EXISTS sections;
+ALTER TABLE post_mortems DROP COLUMN IF EXISTS template_id;
+
+DROP TABLE IF EXISTS postmortem_template_tags;
+DROP TABLE IF EXISTS post_mortem_templates;
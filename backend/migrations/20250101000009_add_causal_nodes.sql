@@ -0,0 +1,24 @@
+-- +goose Up
+-- Migration: Add causal analysis tree
+-- Date: 2025-01-01
+-- Description: Variable-depth causal analysis of post-mortems (categorized root and contributing causes)
+
+-- Causal Nodes table
+CREATE TABLE IF NOT EXISTS causal_nodes (
+    id SERIAL PRIMARY KEY,
+    post_mortem_id INTEGER NOT NULL REFERENCES post_mortems(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES causal_nodes(id) ON DELETE CASCADE,
+    description TEXT NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    is_root_cause BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_causal_nodes_post_mortem_id ON causal_nodes(post_mortem_id);
+CREATE INDEX IF NOT EXISTS idx_causal_nodes_parent_id ON causal_nodes(parent_id);
+CREATE INDEX IF NOT EXISTS idx_causal_nodes_category ON causal_nodes(category);
+
+-- +goose Down
+DROP TABLE IF EXISTS causal_nodes;
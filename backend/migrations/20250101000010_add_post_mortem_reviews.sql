@@ -0,0 +1,53 @@
+-- +goose Up
+-- Migration: Add post-mortem review workflow
+-- Date: 2025-01-01
+-- Description: Reviewer assignment, review decisions, section-anchored review comments and required approvals
+
+-- Post-mortem Reviewers table
+CREATE TABLE IF NOT EXISTS post_mortem_reviewers (
+    id SERIAL PRIMARY KEY,
+    post_mortem_id INTEGER NOT NULL REFERENCES post_mortems(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    decision VARCHAR(20) NOT NULL DEFAULT 'pending',
+    comment TEXT,
+    decided_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_mortem_reviewer ON post_mortem_reviewers(post_mortem_id, reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_reviewers_reviewer_id ON post_mortem_reviewers(reviewer_id);
+
+-- Post-mortem Review Comments table
+CREATE TABLE IF NOT EXISTS post_mortem_review_comments (
+    id SERIAL PRIMARY KEY,
+    post_mortem_id INTEGER NOT NULL REFERENCES post_mortems(id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    section_key VARCHAR(50),
+    quote TEXT,
+    body TEXT NOT NULL,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    resolved_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_mortem_review_comments_post_mortem_id ON post_mortem_review_comments(post_mortem_id);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_review_comments_author_id ON post_mortem_review_comments(author_id);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_review_comments_section_key ON post_mortem_review_comments(section_key);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_review_comments_created_at ON post_mortem_review_comments(created_at);
+
+-- Required approvals (fixed when the post-mortem is submitted for review; templates may override the default)
+ALTER TABLE post_mortems ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE post_mortem_templates ADD COLUMN IF NOT EXISTS required_approvals INTEGER;
+
+-- Review notifications
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS notify_on_post_mortem_review BOOLEAN DEFAULT true;
+
+-- +goose Down
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS notify_on_post_mortem_review;
+ALTER TABLE post_mortem_templates DROP COLUMN IF EXISTS required_approvals;
+ALTER TABLE post_mortems DROP COLUMN IF EXISTS required_approvals;
+DROP TABLE IF EXISTS post_mortem_review_comments;
+DROP TABLE IF EXISTS post_mortem_reviewers;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Migration: Add post-mortem versions
+-- Date: 2025-01-01
+-- Description: Immutable snapshot of a post-mortem every time it is published
+
+-- Post-mortem Versions table
+CREATE TABLE IF NOT EXISTS post_mortem_versions (
+    id SERIAL PRIMARY KEY,
+    post_mortem_id INTEGER NOT NULL REFERENCES post_mortems(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    content JSONB NOT NULL,
+    published_by_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    published_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_mortem_version ON post_mortem_versions(post_mortem_id, version);
+
+-- Latest published version of each post-mortem (0 = never published)
+ALTER TABLE post_mortems ADD COLUMN IF NOT EXISTS current_version INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE post_mortems DROP COLUMN IF EXISTS current_version;
+DROP TABLE IF EXISTS post_mortem_versions;
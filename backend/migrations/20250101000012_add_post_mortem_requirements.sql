@@ -0,0 +1,55 @@
+-- +goose Up
+-- Migration: Add mandatory post-mortems
+-- Date: 2025-01-01
+-- Description: Policies that require a post-mortem within N business days of resolution, and the per-incident requirement
+
+-- Post-mortem Policies table
+CREATE TABLE IF NOT EXISTS post_mortem_policies (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    is_active BOOLEAN DEFAULT true,
+    severity VARCHAR(20),
+    tag_id INTEGER REFERENCES tags(id) ON DELETE CASCADE,
+    due_business_days INTEGER NOT NULL DEFAULT 5,
+    reminder_business_days INTEGER NOT NULL DEFAULT 1,
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_mortem_policies_is_active ON post_mortem_policies(is_active);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_policies_severity ON post_mortem_policies(severity);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_policies_tag_id ON post_mortem_policies(tag_id);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_policies_creator_id ON post_mortem_policies(creator_id);
+
+-- Post-mortem Requirements table (one per incident)
+CREATE TABLE IF NOT EXISTS post_mortem_requirements (
+    id SERIAL PRIMARY KEY,
+    incident_id INTEGER NOT NULL UNIQUE REFERENCES incidents(id) ON DELETE CASCADE,
+    policy_id INTEGER REFERENCES post_mortem_policies(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    resolved_at TIMESTAMP NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    remind_at TIMESTAMP NOT NULL,
+    reminded_at TIMESTAMP,
+    escalated_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    waived_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    waiver_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_mortem_requirements_policy_id ON post_mortem_requirements(policy_id);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_requirements_status ON post_mortem_requirements(status);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_requirements_resolved_at ON post_mortem_requirements(resolved_at);
+CREATE INDEX IF NOT EXISTS idx_post_mortem_requirements_due_at ON post_mortem_requirements(due_at);
+
+-- Reminder / overdue notifications for mandatory post-mortems
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS notify_on_post_mortem_due BOOLEAN DEFAULT true;
+
+-- +goose Down
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS notify_on_post_mortem_due;
+DROP TABLE IF EXISTS post_mortem_requirements;
+DROP TABLE IF EXISTS post_mortem_policies;
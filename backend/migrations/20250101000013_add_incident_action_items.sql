@@ -0,0 +1,37 @@
+-- +goose Up
+-- Migration: Attach action items to incidents
+-- Date: 2025-01-01
+-- Description: Action items belong to an incident, a post-mortem or both; incident items move into the post-mortem when it is created
+
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS incident_id INTEGER REFERENCES incidents(id) ON DELETE CASCADE;
+
+-- Existing items belong to the incident of their post-mortem
+UPDATE action_items AS ai
+SET incident_id = pm.incident_id
+FROM post_mortems AS pm
+WHERE pm.id = ai.post_mortem_id AND ai.incident_id IS NULL;
+
+-- Deleting a post-mortem keeps its items on the incident
+ALTER TABLE action_items ALTER COLUMN post_mortem_id DROP NOT NULL;
+ALTER TABLE action_items DROP CONSTRAINT IF EXISTS action_items_post_mortem_id_fkey;
+ALTER TABLE action_items ADD CONSTRAINT action_items_post_mortem_id_fkey
+    FOREIGN KEY (post_mortem_id) REFERENCES post_mortems(id) ON DELETE SET NULL;
+
+ALTER TABLE action_items ADD CONSTRAINT chk_action_items_owner
+    CHECK (incident_id IS NOT NULL OR post_mortem_id IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS idx_action_items_incident_id ON action_items(incident_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_action_items_incident_id;
+
+ALTER TABLE action_items DROP CONSTRAINT IF EXISTS chk_action_items_owner;
+
+-- Items that were never moved into a post-mortem cannot be kept
+DELETE FROM action_items WHERE post_mortem_id IS NULL;
+ALTER TABLE action_items DROP CONSTRAINT IF EXISTS action_items_post_mortem_id_fkey;
+ALTER TABLE action_items ADD CONSTRAINT action_items_post_mortem_id_fkey
+    FOREIGN KEY (post_mortem_id) REFERENCES post_mortems(id) ON DELETE CASCADE;
+ALTER TABLE action_items ALTER COLUMN post_mortem_id SET NOT NULL;
+
+ALTER TABLE action_items DROP COLUMN IF EXISTS incident_id;
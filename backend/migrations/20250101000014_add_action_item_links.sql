@@ -0,0 +1,32 @@
+-- +goose Up
+-- Migration: Add action item links and dependencies
+-- Date: 2025-01-01
+-- Description: Action items shared by several incidents/post-mortems, and blocking dependencies between action items
+
+-- Action Item Links table (further incidents of the same remediation)
+CREATE TABLE IF NOT EXISTS action_item_links (
+    id SERIAL PRIMARY KEY,
+    action_item_id INTEGER NOT NULL REFERENCES action_items(id) ON DELETE CASCADE,
+    incident_id INTEGER NOT NULL REFERENCES incidents(id) ON DELETE CASCADE,
+    post_mortem_id INTEGER REFERENCES post_mortems(id) ON DELETE SET NULL,
+    created_by_id INTEGER NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_action_item_link UNIQUE (action_item_id, incident_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_item_links_incident_id ON action_item_links(incident_id);
+CREATE INDEX IF NOT EXISTS idx_action_item_links_post_mortem_id ON action_item_links(post_mortem_id);
+
+-- Action Item Dependencies table (action_item_id cannot be completed before blocked_by_id)
+CREATE TABLE IF NOT EXISTS action_item_dependencies (
+    action_item_id INTEGER NOT NULL REFERENCES action_items(id) ON DELETE CASCADE,
+    blocked_by_id INTEGER NOT NULL REFERENCES action_items(id) ON DELETE CASCADE,
+    PRIMARY KEY (action_item_id, blocked_by_id),
+    CHECK (action_item_id <> blocked_by_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_item_dependencies_blocked_by_id ON action_item_dependencies(blocked_by_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS action_item_dependencies;
+DROP TABLE IF EXISTS action_item_links;
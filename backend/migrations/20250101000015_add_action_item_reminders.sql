@@ -0,0 +1,39 @@
+-- +goose Up
+-- Migration: Add action item reminders and weekly digest
+-- Date: 2025-01-01
+-- Description: Due-soon/overdue reminders of action items, escalation of overdue high-priority items and a weekly digest, configurable per user
+
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS due_soon_notified_at TIMESTAMP;
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS overdue_notified_at TIMESTAMP;
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_action_items_due_date ON action_items(due_date);
+
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS notify_on_action_item_due BOOLEAN DEFAULT true;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS action_item_due_soon_days INTEGER DEFAULT 2;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS action_item_overdue_interval INTEGER DEFAULT 1;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS weekly_digest_enabled BOOLEAN DEFAULT true;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS weekly_digest_weekday INTEGER DEFAULT 1;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS weekly_digest_hour INTEGER DEFAULT 9;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS quiet_hours_start INTEGER;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS quiet_hours_end INTEGER;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS last_digest_sent_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS last_digest_sent_at;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS timezone;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS quiet_hours_end;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS quiet_hours_start;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS weekly_digest_hour;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS weekly_digest_weekday;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS weekly_digest_enabled;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS action_item_overdue_interval;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS action_item_due_soon_days;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS notify_on_action_item_due;
+
+DROP INDEX IF EXISTS idx_action_items_due_date;
+
+ALTER TABLE action_items DROP COLUMN IF EXISTS escalated_at;
+ALTER TABLE action_items DROP COLUMN IF EXISTS overdue_notified_at;
+ALTER TABLE action_items DROP COLUMN IF EXISTS due_soon_notified_at;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Migration: Sync action items with external issue trackers
+-- Date: 2025-01-01
+-- Description: Key and URL of the GitHub/Jira issue opened for an action item, looked up by the trackers' webhooks
+
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS external_tracker VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS external_key VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS external_url VARCHAR(500) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_action_items_external_issue
+    ON action_items(external_tracker, external_key) WHERE external_key <> '';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_action_items_external_issue;
+
+ALTER TABLE action_items DROP COLUMN IF EXISTS external_url;
+ALTER TABLE action_items DROP COLUMN IF EXISTS external_key;
+ALTER TABLE action_items DROP COLUMN IF EXISTS external_tracker;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Migration: Verify completed action items
+-- Date: 2025-01-01
+-- Description: Completed action items can be verified (status 'verified') with a verifier, a note and a link to evidence
+
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS verified_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP;
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS verification_note TEXT;
+ALTER TABLE action_items ADD COLUMN IF NOT EXISTS verification_evidence_url VARCHAR(1000);
+
+CREATE INDEX IF NOT EXISTS idx_action_items_verified_by_id ON action_items(verified_by_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_action_items_verified_by_id;
+
+UPDATE action_items SET status = 'completed' WHERE status = 'verified';
+
+ALTER TABLE action_items DROP COLUMN IF EXISTS verification_evidence_url;
+ALTER TABLE action_items DROP COLUMN IF EXISTS verification_note;
+ALTER TABLE action_items DROP COLUMN IF EXISTS verified_at;
+ALTER TABLE action_items DROP COLUMN IF EXISTS verified_by_id;
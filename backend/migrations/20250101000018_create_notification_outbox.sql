@@ -0,0 +1,27 @@
+-- +goose Up
+-- Migration: Create notification outbox
+-- Date: 2025-01-01
+-- Description: Email and Slack notifications are queued here and delivered by a background worker with retries; notifications that keep failing are dead-lettered
+
+CREATE TABLE IF NOT EXISTS notification_outbox (
+    id SERIAL PRIMARY KEY,
+    channel VARCHAR(20) NOT NULL,
+    recipient TEXT NOT NULL,
+    subject TEXT,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_notification_outbox_status CHECK (status IN ('pending', 'sent', 'dead'))
+);
+
+-- The worker looks up pending notifications that are due
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_due ON notification_outbox(status, next_attempt_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_outbox;
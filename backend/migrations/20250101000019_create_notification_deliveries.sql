@@ -0,0 +1,41 @@
+-- +goose Up
+-- Migration: Create notification delivery log
+-- Date: 2025-01-01
+-- Description: Every attempt to deliver a queued notification is recorded with its recipient, event, incident, payload hash, result and latency
+
+ALTER TABLE notification_outbox ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE notification_outbox ADD COLUMN IF NOT EXISTS event_type VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE notification_outbox ADD COLUMN IF NOT EXISTS incident_id INTEGER REFERENCES incidents(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_user_id ON notification_outbox(user_id);
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id SERIAL PRIMARY KEY,
+    notification_id INTEGER REFERENCES notification_outbox(id) ON DELETE SET NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    channel VARCHAR(20) NOT NULL,
+    recipient TEXT NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    -- No foreign key: the log stays evidence of what was sent after the incident is deleted
+    incident_id INTEGER,
+    subject TEXT,
+    payload_hash VARCHAR(64) NOT NULL,
+    attempt INTEGER NOT NULL,
+    result VARCHAR(20) NOT NULL,
+    error TEXT,
+    latency_ms BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_notification_deliveries_result CHECK (result IN ('sent', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_notification_id ON notification_deliveries(notification_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_user ON notification_deliveries(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_incident ON notification_deliveries(incident_id, created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS notification_deliveries;
+
+DROP INDEX IF EXISTS idx_notification_outbox_user_id;
+ALTER TABLE notification_outbox DROP COLUMN IF EXISTS incident_id;
+ALTER TABLE notification_outbox DROP COLUMN IF EXISTS event_type;
+ALTER TABLE notification_outbox DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- Migration: Create notification endpoints
+-- Date: 2025-01-01
+-- Description: Users register any number of Slack, Microsoft Teams, Discord or Mattermost webhooks and extra email addresses to be notified at; the single Slack webhook of the notification settings moves to an endpoint
+
+CREATE TABLE IF NOT EXISTS notification_endpoints (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL,
+    name VARCHAR(100),
+    target TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_notification_endpoints_channel CHECK (channel IN ('email', 'slack', 'teams', 'discord', 'mattermost'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_endpoints_user_id ON notification_endpoints(user_id);
+
+INSERT INTO notification_endpoints (user_id, channel, name, target, enabled, created_at, updated_at)
+SELECT user_id, 'slack', 'Slack', slack_webhook, COALESCE(slack_enabled, false), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM notification_settings
+WHERE slack_webhook IS NOT NULL AND slack_webhook <> '';
+
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS slack_enabled;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS slack_webhook;
+
+-- +goose Down
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS slack_enabled BOOLEAN DEFAULT false;
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS slack_webhook VARCHAR(512);
+
+-- Only the first Slack endpoint of each user fits back into the settings
+UPDATE notification_settings s
+SET slack_enabled = e.enabled, slack_webhook = e.target
+FROM (
+    SELECT DISTINCT ON (user_id) user_id, enabled, target
+    FROM notification_endpoints
+    WHERE channel = 'slack'
+    ORDER BY user_id, id
+) e
+WHERE s.user_id = e.user_id;
+
+DROP TABLE IF EXISTS notification_endpoints;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Migration: Index the knowledge base search
+-- Date: 2025-01-01
+-- Description: GIN indexes on the search document of post-mortems and incident titles (full-text and trigram for substring matching), used by the knowledge base search and similar post-mortem suggestions
+-- The indexed expressions must stay identical to the ones in persistence/knowledge_base_repository.go
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_post_mortems_kb_document ON post_mortems USING GIN ((
+    setweight(to_tsvector('simple', COALESCE(root_cause, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(lessons_learned, '')), 'A') ||
+    setweight(to_tsvector('simple', COALESCE(what_went_wrong, '')), 'B')
+));
+
+CREATE INDEX IF NOT EXISTS idx_incidents_kb_title ON incidents USING GIN ((
+    setweight(to_tsvector('simple', COALESCE(title, '')), 'C')
+));
+
+CREATE INDEX IF NOT EXISTS idx_post_mortems_kb_text_trgm ON post_mortems USING GIN ((
+    LOWER(COALESCE(root_cause, '') || ' ' || COALESCE(lessons_learned, '') || ' ' || COALESCE(what_went_wrong, ''))
+) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_incidents_kb_title_trgm ON incidents USING GIN ((LOWER(COALESCE(title, ''))) gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_incidents_kb_title_trgm;
+DROP INDEX IF EXISTS idx_post_mortems_kb_text_trgm;
+DROP INDEX IF EXISTS idx_incidents_kb_title;
+DROP INDEX IF EXISTS idx_post_mortems_kb_document;
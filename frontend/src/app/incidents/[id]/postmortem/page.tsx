@@ -158,13 +158,16 @@ export default function PostMortemPage() {
       };
 
       if (postMortem) {
+        // Published post-mortems can only be edited after they are unpublished
+        if (isDraft && postMortem.status === 'published') {
+          await postMortemApi.unpublish(token!, postMortem.id);
+        }
+
         // Update existing
         await postMortemApi.update(token!, postMortem.id, data);
 
-        // Update status if needed
-        if (isDraft && postMortem.status === 'published') {
-          await postMortemApi.unpublish(token!, postMortem.id);
-        } else if (!isDraft && postMortem.status === 'draft') {
+        // Publish if needed
+        if (!isDraft && postMortem.status === 'draft') {
           await postMortemApi.publish(token!, postMortem.id);
         }
 
@@ -294,6 +297,8 @@ export default function PostMortemPage() {
         return 'bg-blue-100 text-blue-800';
       case 'completed':
         return 'bg-green-100 text-green-800';
+      case 'verified':
+        return 'bg-emerald-100 text-emerald-800';
     }
   };
 
@@ -622,7 +627,12 @@ export default function PostMortemPage() {
                       >
                         <option value="pending">Pending</option>
                         <option value="in_progress">In Progress</option>
-                        <option value="completed">Completed</option>
+                        <option value="completed" disabled={editingActionItem.status === 'verified'}>
+                          Completed
+                        </option>
+                        {editingActionItem.status === 'verified' && (
+                          <option value="verified">Verified</option>
+                        )}
                       </select>
                     </div>
                   )}
@@ -1,7 +1,7 @@
 import { User } from './incident';
 
 export type Priority = 'high' | 'medium' | 'low';
-export type ActionStatus = 'pending' | 'in_progress' | 'completed';
+export type ActionStatus = 'pending' | 'in_progress' | 'completed' | 'verified';
 
 export interface ActionItem {
   id: number;
@@ -16,6 +16,10 @@ export interface ActionItem {
   created_at: string;
   updated_at: string;
   completed_at: string | null;
+  verified_by_id: number | null;
+  verified_at: string | null;
+  verification_note: string;
+  verification_evidence_url: string;
   assignee?: User;
 }
 